@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/MagaluCloud/mgc-sdk-go/helpers"
 	mgc_http "github.com/MagaluCloud/mgc-sdk-go/internal/http"
 	"github.com/MagaluCloud/mgc-sdk-go/internal/utils"
 )
@@ -91,6 +92,9 @@ func (s *eventService) List(ctx context.Context, params *ListEventsParams) ([]Ev
 		if params.SourceLike != nil {
 			query.Set("source__like", *params.SourceLike)
 		}
+		if params.Time != nil {
+			query.Set("time", helpers.NewAPITime(*params.Time).String())
+		}
 		if params.TypeLike != nil {
 			query.Set("type__like", *params.TypeLike)
 		}
@@ -109,6 +109,27 @@ func TestEventService_List(t *testing.T) {
 	})
 }
 
+func TestEventService_List_TimeFilter(t *testing.T) {
+	var gotTime string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotTime = r.URL.Query().Get("time")
+		handleListEvents(w, r)
+	}))
+	defer ts.Close()
+
+	cfg := client.NewMgcClient("test-api-key", client.WithBaseURL(client.MgcUrl(ts.URL)))
+	service := New(cfg).Events()
+
+	filter := time.Date(2024, time.January, 2, 9, 0, 0, 0, time.FixedZone("BRT", -3*60*60))
+	if _, err := service.List(context.Background(), &ListEventsParams{Time: &filter}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotTime != "2024-01-02T12:00:00Z" {
+		t.Errorf("expected time=2024-01-02T12:00:00Z, got %s", gotTime)
+	}
+}
+
 func TestListEventsParamsQuery(t *testing.T) {
 	limit := 10
 	offset := 20
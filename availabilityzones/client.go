@@ -69,3 +69,8 @@ func (c *Client) newRequest(ctx context.Context, method, path string, body any)
 func (c *Client) AvailabilityZones() Service {
 	return &service{client: c}
 }
+
+// Regions returns a service to list regions and their availability zones.
+func (c *Client) Regions() RegionsService {
+	return &regionsService{client: c}
+}
@@ -0,0 +1,100 @@
+package availabilityzones
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+	"github.com/MagaluCloud/mgc-sdk-go/internal/utils"
+)
+
+const (
+	regionField = "region"
+	zoneField   = "zone"
+)
+
+// regionNames holds the display names of the known region codes
+var regionNames = map[string]string{
+	"br-se1": "Brazil Southeast 1",
+	"br-ne1": "Brazil Northeast 1",
+}
+
+// RegionsService lists the regions of the platform and the availability zones usable in
+// each one, so region and zone arguments of other services can be checked up front.
+type RegionsService interface {
+	List(ctx context.Context) ([]Region, error)
+	Zones(ctx context.Context, region string) ([]AvailabilityZone, error)
+	ValidateZone(ctx context.Context, region, zone string) error
+}
+
+// regionsService implements the RegionsService interface.
+// This is an internal implementation that should not be used directly.
+type regionsService struct {
+	client *Client
+}
+
+// Name returns the display name of the region, e.g. "Brazil Southeast 1" for br-se1,
+// or its code when the region is not known to the SDK.
+func (r Region) Name() string {
+	if name, ok := regionNames[r.ID]; ok {
+		return name
+	}
+	return r.ID
+}
+
+// List returns every region with its available zones. Blocked zones are left out.
+func (s *regionsService) List(ctx context.Context) ([]Region, error) {
+	return s.client.AvailabilityZones().List(ctx, ListOptions{})
+}
+
+// Zones returns the available zones of region. An unknown region is reported as a
+// ValidationError naming the known ones.
+func (s *regionsService) Zones(ctx context.Context, region string) ([]AvailabilityZone, error) {
+	if region == "" {
+		return nil, &client.ValidationError{Field: regionField, Message: utils.CannotBeEmpty}
+	}
+
+	regions, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, 0, len(regions))
+	for _, r := range regions {
+		if r.ID == region {
+			return r.AvailabilityZones, nil
+		}
+		codes = append(codes, r.ID)
+	}
+	return nil, &client.ValidationError{
+		Field:   regionField,
+		Message: fmt.Sprintf("unknown region %q, available regions are %s", region, strings.Join(codes, ", ")),
+	}
+}
+
+// ValidateZone checks that zone is an available zone of region, returning a
+// ValidationError naming the available zones otherwise.
+func (s *regionsService) ValidateZone(ctx context.Context, region, zone string) error {
+	if zone == "" {
+		return &client.ValidationError{Field: zoneField, Message: utils.CannotBeEmpty}
+	}
+
+	zones, err := s.Zones(ctx, region)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]string, len(zones))
+	for i, z := range zones {
+		ids[i] = z.ID
+	}
+	if slices.Contains(ids, zone) {
+		return nil
+	}
+	return &client.ValidationError{
+		Field:   zoneField,
+		Message: fmt.Sprintf("zone %q is not available in %s, available zones are %s", zone, region, strings.Join(ids, ", ")),
+	}
+}
@@ -0,0 +1,94 @@
+package availabilityzones
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+)
+
+func newRegionsTestService(t *testing.T) RegionsService {
+	t.Helper()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/profile/v0/availability-zones" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"results": [
+			{"region_id": "br-se1", "availability_zones": [{"az_id": "br-se1-a", "block_type": "none"}, {"az_id": "br-se1-b", "block_type": "none"}]},
+			{"region_id": "br-ne1", "availability_zones": [{"az_id": "br-ne1-a", "block_type": "none"}]}
+		]}`))
+	}))
+	t.Cleanup(ts.Close)
+
+	return New(client.NewMgcClient("test-api-key"), WithGlobalBasePath(client.MgcUrl(ts.URL))).Regions()
+}
+
+func TestRegionsService_List(t *testing.T) {
+	regions, err := newRegionsTestService(t).List(context.Background())
+	if err != nil {
+		t.Fatalf("List() unexpected error: %v", err)
+	}
+	if len(regions) != 2 || regions[0].ID != "br-se1" || len(regions[0].AvailabilityZones) != 2 {
+		t.Fatalf("unexpected regions %+v", regions)
+	}
+	if name := regions[0].Name(); name != "Brazil Southeast 1" {
+		t.Errorf("expected name Brazil Southeast 1, got %q", name)
+	}
+	if name := (Region{ID: "xx-new1"}).Name(); name != "xx-new1" {
+		t.Errorf("expected unknown region to be named by its code, got %q", name)
+	}
+}
+
+func TestRegionsService_Zones(t *testing.T) {
+	svc := newRegionsTestService(t)
+
+	zones, err := svc.Zones(context.Background(), "br-ne1")
+	if err != nil {
+		t.Fatalf("Zones() unexpected error: %v", err)
+	}
+	if len(zones) != 1 || zones[0].ID != "br-ne1-a" {
+		t.Errorf("unexpected zones %+v", zones)
+	}
+
+	_, err = svc.Zones(context.Background(), "us-east1")
+	var validationErr *client.ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "region" || !strings.Contains(err.Error(), "br-se1, br-ne1") {
+		t.Errorf("expected a region ValidationError naming the known regions, got %v", err)
+	}
+}
+
+func TestRegionsService_ValidateZone(t *testing.T) {
+	tests := []struct {
+		name      string
+		region    string
+		zone      string
+		wantField string
+	}{
+		{name: "available zone", region: "br-se1", zone: "br-se1-b"},
+		{name: "zone of another region", region: "br-se1", zone: "br-ne1-a", wantField: "zone"},
+		{name: "empty zone", region: "br-se1", wantField: "zone"},
+		{name: "empty region", zone: "br-se1-a", wantField: "region"},
+	}
+
+	svc := newRegionsTestService(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := svc.ValidateZone(context.Background(), tt.region, tt.zone)
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("ValidateZone() unexpected error: %v", err)
+				}
+				return
+			}
+			var validationErr *client.ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Field != tt.wantField {
+				t.Errorf("expected a ValidationError on %s, got %v", tt.wantField, err)
+			}
+		})
+	}
+}
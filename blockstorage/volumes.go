@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/MagaluCloud/mgc-sdk-go/helpers"
 	mgc_http "github.com/MagaluCloud/mgc-sdk-go/internal/http"
 )
 
@@ -109,10 +110,16 @@ type RenameVolumeRequest struct {
 
 // IDOrName represents a reference that can be either an ID or a name.
 // This structure is used when an API can accept either an ID or a name as a parameter.
-type IDOrName struct {
-	ID   *string `json:"id,omitempty"`
-	Name *string `json:"name,omitempty"`
-}
+type IDOrName = helpers.IDOrName
+
+// ParseIDOrName is helpers.ParseIDOrName: UUIDs become IDs and everything else names.
+func ParseIDOrName(s string) IDOrName { return helpers.ParseIDOrName(s) }
+
+// ForceID is helpers.ForceID.
+func ForceID(s string) IDOrName { return helpers.ForceID(s) }
+
+// ForceName is helpers.ForceName.
+func ForceName(s string) IDOrName { return helpers.ForceName(s) }
 
 // ListOptions contains options for listing volumes.
 // All fields are optional and allow controlling pagination and expansion.
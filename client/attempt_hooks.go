@@ -0,0 +1,23 @@
+package client
+
+import "net/http"
+
+// WithAttemptHooks registers hooks called around every HTTP attempt, including retries, for
+// custom retry telemetry or adaptive logic. Either hook may be nil. Attempts are numbered
+// from 1, as in RequestInfo.
+//
+// before is called just before an attempt is sent, with a copy of the request made for that
+// attempt. It may set headers on it, which then apply to that attempt only, but must not
+// change its method, URL or body, nor keep it after returning.
+//
+// after is called once the attempt completes, with either the response or the transport
+// error. The response body is still needed by the client: the hook may inspect the status
+// and headers but must not read or close the body.
+//
+// Both hooks run synchronously in the request goroutine and should not block.
+func WithAttemptHooks(before func(attempt int, req *http.Request), after func(attempt int, resp *http.Response, err error)) Option {
+	return func(c *Config) {
+		c.BeforeAttempt = before
+		c.AfterAttempt = after
+	}
+}
@@ -0,0 +1,24 @@
+package client
+
+import (
+	"context"
+	"time"
+)
+
+// callTimeoutKey is the context key holding a per-call timeout.
+type callTimeoutKey struct{}
+
+// WithCallTimeout returns a context whose requests use timeout instead of the client's
+// Timeout (see WithTimeout), e.g. to give a known-slow call such as a snapshot copy more room
+// without changing the client. Like the client's Timeout, it bounds the whole call, retries
+// included. A deadline already on ctx still applies, so the shorter of the two wins.
+// Zero or negative removes the client's Timeout for the call.
+func WithCallTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, callTimeoutKey{}, timeout)
+}
+
+// CallTimeout returns the timeout set by WithCallTimeout and whether one was set.
+func CallTimeout(ctx context.Context) (time.Duration, bool) {
+	timeout, ok := ctx.Value(callTimeoutKey{}).(time.Duration)
+	return timeout, ok
+}
@@ -0,0 +1,140 @@
+package client
+
+import (
+	"sync"
+	"time"
+
+	"github.com/MagaluCloud/mgc-sdk-go/internal/utils"
+)
+
+// CircuitState represents the state of a CircuitBreaker.
+type CircuitState string
+
+const (
+	// CircuitClosed lets every request through while failures are counted.
+	CircuitClosed CircuitState = "closed"
+	// CircuitOpen rejects every request with ErrCircuitOpen until the cooldown elapses.
+	CircuitOpen CircuitState = "open"
+	// CircuitHalfOpen lets a single probe request through to decide whether to close again.
+	CircuitHalfOpen CircuitState = "half-open"
+)
+
+// CircuitBreaker stops sending requests after repeated failures so callers fail fast
+// while the API is unavailable. It is safe for concurrent use and is shared by every
+// service built from the same CoreClient.
+type CircuitBreaker struct {
+	mu sync.Mutex
+
+	failureThreshold int
+	window           time.Duration
+	cooldown         time.Duration
+	clock            utils.Clock
+
+	state         CircuitState
+	failures      int
+	firstFailure  time.Time
+	openedAt      time.Time
+	probeInFlight bool
+}
+
+// NewCircuitBreaker creates a circuit breaker that opens after failureThreshold consecutive
+// failures happening within window, and half-opens after cooldown to probe the API again.
+func NewCircuitBreaker(failureThreshold int, window, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		failureThreshold: failureThreshold,
+		window:           window,
+		cooldown:         cooldown,
+		clock:            utils.RealClock,
+		state:            CircuitClosed,
+	}
+}
+
+// State returns the current state of the circuit.
+func (cb *CircuitBreaker) State() CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if cb.state == CircuitOpen && cb.clock.Now().Sub(cb.openedAt) >= cb.cooldown {
+		return CircuitHalfOpen
+	}
+	return cb.state
+}
+
+// Allow reports whether a request may be sent. When the cooldown of an open circuit
+// has elapsed, the first caller is allowed through as a probe and the others are rejected
+// until the probe result is recorded.
+func (cb *CircuitBreaker) Allow() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case CircuitOpen:
+		if cb.clock.Now().Sub(cb.openedAt) < cb.cooldown {
+			return false
+		}
+		cb.state = CircuitHalfOpen
+		cb.probeInFlight = true
+		return true
+	case CircuitHalfOpen:
+		if cb.probeInFlight {
+			return false
+		}
+		cb.probeInFlight = true
+		return true
+	default:
+		return true
+	}
+}
+
+// RecordSuccess closes the circuit and resets the failure count.
+func (cb *CircuitBreaker) RecordSuccess() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.state = CircuitClosed
+	cb.failures = 0
+	cb.probeInFlight = false
+}
+
+// Abandon gives up the probe let through by Allow when its request ends without saying
+// anything about the API's health, such as when the caller cancels it, so that the next
+// request can probe instead. It does nothing unless the circuit is half-open.
+func (cb *CircuitBreaker) Abandon() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if cb.state == CircuitHalfOpen {
+		cb.probeInFlight = false
+	}
+}
+
+// RecordFailure counts a failed request and opens the circuit when the threshold is reached.
+// A failed probe reopens the circuit for another cooldown.
+func (cb *CircuitBreaker) RecordFailure() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	now := cb.clock.Now()
+
+	if cb.state == CircuitHalfOpen {
+		cb.open(now)
+		return
+	}
+
+	if cb.failures == 0 || now.Sub(cb.firstFailure) > cb.window {
+		cb.failures = 0
+		cb.firstFailure = now
+	}
+	cb.failures++
+
+	if cb.failures >= cb.failureThreshold {
+		cb.open(now)
+	}
+}
+
+func (cb *CircuitBreaker) open(now time.Time) {
+	cb.state = CircuitOpen
+	cb.openedAt = now
+	cb.failures = 0
+	cb.probeInFlight = false
+}
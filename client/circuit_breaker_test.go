@@ -0,0 +1,133 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MagaluCloud/mgc-sdk-go/internal/utils"
+)
+
+func newTestCircuitBreaker(threshold int, window, cooldown time.Duration) (*CircuitBreaker, *utils.FakeClock) {
+	clock := utils.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	cb := NewCircuitBreaker(threshold, window, cooldown)
+	cb.clock = clock
+	return cb, clock
+}
+
+func TestCircuitBreaker_OpensAfterThreshold(t *testing.T) {
+	cb, _ := newTestCircuitBreaker(3, time.Minute, 30*time.Second)
+
+	for i := 0; i < 2; i++ {
+		if !cb.Allow() {
+			t.Fatalf("expected request %d to be allowed", i+1)
+		}
+		cb.RecordFailure()
+	}
+	if cb.State() != CircuitClosed {
+		t.Fatalf("expected circuit to be closed, got %s", cb.State())
+	}
+
+	cb.RecordFailure()
+	if cb.State() != CircuitOpen {
+		t.Fatalf("expected circuit to be open, got %s", cb.State())
+	}
+	if cb.Allow() {
+		t.Error("expected open circuit to reject requests")
+	}
+}
+
+func TestCircuitBreaker_SuccessResetsFailures(t *testing.T) {
+	cb, _ := newTestCircuitBreaker(2, time.Minute, 30*time.Second)
+
+	cb.RecordFailure()
+	cb.RecordSuccess()
+	cb.RecordFailure()
+
+	if cb.State() != CircuitClosed {
+		t.Errorf("expected circuit to stay closed, got %s", cb.State())
+	}
+}
+
+func TestCircuitBreaker_FailuresOutsideWindow(t *testing.T) {
+	cb, clock := newTestCircuitBreaker(2, 10*time.Second, 30*time.Second)
+
+	cb.RecordFailure()
+	clock.Advance(11 * time.Second)
+	cb.RecordFailure()
+
+	if cb.State() != CircuitClosed {
+		t.Errorf("expected circuit to stay closed, got %s", cb.State())
+	}
+
+	clock.Advance(time.Second)
+	cb.RecordFailure()
+	if cb.State() != CircuitOpen {
+		t.Errorf("expected circuit to be open, got %s", cb.State())
+	}
+}
+
+func TestCircuitBreaker_HalfOpen(t *testing.T) {
+	tests := []struct {
+		name      string
+		probeOK   bool
+		wantState CircuitState
+		wantAllow bool
+	}{
+		{name: "successful probe closes the circuit", probeOK: true, wantState: CircuitClosed, wantAllow: true},
+		{name: "failed probe reopens the circuit", probeOK: false, wantState: CircuitOpen, wantAllow: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cb, clock := newTestCircuitBreaker(1, time.Minute, 30*time.Second)
+
+			cb.RecordFailure()
+			clock.Advance(29 * time.Second)
+			if cb.Allow() {
+				t.Fatal("expected request to be rejected before the cooldown")
+			}
+
+			clock.Advance(time.Second)
+			if cb.State() != CircuitHalfOpen {
+				t.Fatalf("expected circuit to be half-open, got %s", cb.State())
+			}
+			if !cb.Allow() {
+				t.Fatal("expected probe request to be allowed")
+			}
+			if cb.Allow() {
+				t.Fatal("expected only one probe request to be allowed")
+			}
+
+			if tt.probeOK {
+				cb.RecordSuccess()
+			} else {
+				cb.RecordFailure()
+			}
+
+			if cb.State() != tt.wantState {
+				t.Errorf("expected state %s, got %s", tt.wantState, cb.State())
+			}
+			if cb.Allow() != tt.wantAllow {
+				t.Errorf("expected Allow() = %v", tt.wantAllow)
+			}
+		})
+	}
+}
+
+func TestCircuitBreaker_AbandonProbe(t *testing.T) {
+	cb, clock := newTestCircuitBreaker(1, time.Minute, 30*time.Second)
+
+	cb.RecordFailure()
+	clock.Advance(30 * time.Second)
+	if !cb.Allow() {
+		t.Fatal("expected a probe to be allowed after the cooldown")
+	}
+	if cb.Allow() {
+		t.Fatal("expected a second request to be rejected while the probe is in flight")
+	}
+
+	cb.Abandon()
+	if !cb.Allow() {
+		t.Error("expected a new probe to be allowed once the previous one was abandoned")
+	}
+}
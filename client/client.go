@@ -3,20 +3,24 @@
 package client
 
 import (
+	"context"
+	"crypto/tls"
 	"log/slog"
 	"net/http"
+	"sync"
 	"time"
 )
 
 // Default configuration constants for the client.
 const (
-	RequestIDKey           XRequestID = "x-request-id"
-	DefaultUserAgent                  = "mgc-sdk-go"
-	DefaultMaxAttempts                = 3
-	DefaultInitialInterval            = 1 * time.Second
-	DefaultMaxInterval                = 30 * time.Second
-	DefaultBackoffFactor              = 2.0
-	DefaultTimeout                    = 15 * time.Minute
+	RequestIDKey                 XRequestID = "x-request-id"
+	DefaultUserAgent                        = "mgc-sdk-go"
+	DefaultMaxAttempts                      = 3
+	DefaultInitialInterval                  = 1 * time.Second
+	DefaultMaxInterval                      = 30 * time.Second
+	DefaultBackoffFactor                    = 2.0
+	DefaultTimeout                          = 15 * time.Minute
+	DefaultMaxPooledDecodeBuffer            = 1 << 20
 )
 
 // XRequestID represents a request ID type for tracking requests.
@@ -51,14 +55,152 @@ func NewMgcClient(apiKey string, opts ...Option) *CoreClient {
 		opt(cfg)
 	}
 
+	if cfg.InsecureSkipVerify {
+		cfg.HTTPClient = insecureHTTPClient(cfg.HTTPClient, cfg.Logger)
+	}
+	if cfg.ForceHTTP1 {
+		cfg.HTTPClient = http1OnlyClient(cfg.HTTPClient, cfg.Logger)
+	}
+
+	if cfg.CaptureLastExchange {
+		cfg.exchanges = &exchangeRecorder{}
+	}
+	if cfg.Middlewares != nil {
+		cfg.chain = chainMiddlewares(cfg.Middlewares)
+	}
+	cfg.inFlight = &inFlightTracker{}
+	cfg.deprecations = newDeprecationLog()
+	if cfg.MaxConcurrentRequests > 0 {
+		cfg.requestSlots = make(chan struct{}, cfg.MaxConcurrentRequests)
+	}
+
 	cfg.Logger.Debug("creating new core client",
 		"baseURL", cfg.BaseURL.String(),
 		"userAgent", cfg.UserAgent)
 	return &CoreClient{config: *cfg}
 }
 
+// insecureHTTPClient returns a copy of httpClient whose transport skips TLS verification.
+// Clients that already carry a custom transport are returned unchanged.
+func insecureHTTPClient(httpClient *http.Client, logger *slog.Logger) *http.Client {
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+
+	if httpClient.Transport != nil {
+		logger.Warn("InsecureSkipVerify is ignored because the HTTP client has a custom transport")
+		return httpClient
+	}
+
+	logger.Warn("TLS CERTIFICATE VERIFICATION IS DISABLED. " +
+		"InsecureSkipVerify must only be used for local development and never in production")
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} // #nosec G402 -- explicit dev-only opt-in
+
+	insecure := *httpClient
+	insecure.Transport = transport
+	return &insecure
+}
+
+// http1OnlyClient returns a copy of httpClient whose transport only speaks HTTP/1.1.
+// Clients whose transport is not an *http.Transport are returned unchanged.
+func http1OnlyClient(httpClient *http.Client, logger *slog.Logger) *http.Client {
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+
+	var transport *http.Transport
+	switch t := httpClient.Transport.(type) {
+	case nil:
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		transport = t.Clone()
+	default:
+		logger.Warn("ForceHTTP1 is ignored because the HTTP client transport is not an *http.Transport")
+		return httpClient
+	}
+
+	// an empty, non-nil TLSNextProto disables HTTP/2, and the ALPN offer must not advertise it
+	transport.ForceAttemptHTTP2 = false
+	transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	tlsConfig := &tls.Config{}
+	if transport.TLSClientConfig != nil {
+		tlsConfig = transport.TLSClientConfig.Clone()
+	}
+	tlsConfig.NextProtos = []string{"http/1.1"}
+	transport.TLSClientConfig = tlsConfig
+
+	http1 := *httpClient
+	http1.Transport = transport
+	return &http1
+}
+
 // GetConfig returns a pointer to the client's configuration.
 // This method allows access to the current configuration for inspection or modification.
 func (c *CoreClient) GetConfig() *Config {
 	return &c.config
 }
+
+// inFlightTracker counts requests in progress so Close can wait for them.
+type inFlightTracker struct {
+	mu     sync.Mutex
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// BeginRequest registers a request in progress and returns the function that must be
+// called when it finishes. It returns ErrClientClosed once Close has been called.
+// It is called by the request executor.
+func (c *Config) BeginRequest() (func(), error) {
+	t := c.inFlight
+	if t == nil {
+		return func() {}, nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return nil, ErrClientClosed
+	}
+	t.wg.Add(1)
+	return t.wg.Done, nil
+}
+
+// Close stops the client from accepting new requests, which then fail with ErrClientClosed,
+// and waits for in-flight requests (including their retries) to finish. If ctx is done first,
+// Close returns ctx.Err() and the remaining requests keep running until they finish on their own.
+// Idle connections of the HTTP client are closed in both cases.
+// Waiters such as WaitUntilCompleted stop at their next poll.
+func (c *CoreClient) Close(ctx context.Context) error {
+	t := c.config.inFlight
+	if t != nil {
+		t.mu.Lock()
+		t.closed = true
+		t.mu.Unlock()
+	}
+
+	defer func() {
+		if c.config.HTTPClient != nil {
+			c.config.HTTPClient.CloseIdleConnections()
+		}
+	}()
+
+	if t == nil {
+		return nil
+	}
+
+	drained := make(chan struct{})
+	go func() {
+		t.wg.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		c.config.Logger.Warn("client closed before in-flight requests finished", "error", ctx.Err())
+		return ctx.Err()
+	}
+}
@@ -1,6 +1,8 @@
 package client
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"time"
 )
@@ -73,3 +75,27 @@ func TestCoreClient_GetConfig(t *testing.T) {
 		t.Errorf("expected Timeout %v, got %v", expectedTimeout, config.Timeout)
 	}
 }
+
+func TestCoreClient_Close(t *testing.T) {
+	core := NewMgcClient("test-api-key")
+
+	done, err := core.GetConfig().BeginRequest()
+	if err != nil {
+		t.Fatalf("BeginRequest() unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := core.Close(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Close() error = %v, want context.DeadlineExceeded", err)
+	}
+
+	if _, err := core.GetConfig().BeginRequest(); !errors.Is(err, ErrClientClosed) {
+		t.Errorf("BeginRequest() after Close error = %v, want ErrClientClosed", err)
+	}
+
+	done()
+	if err := core.Close(context.Background()); err != nil {
+		t.Errorf("Close() after drain unexpected error: %v", err)
+	}
+}
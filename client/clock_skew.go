@@ -0,0 +1,24 @@
+package client
+
+import "time"
+
+// WithClockSkewTolerance sets how far apart two timestamps may be and still be treated as
+// the same instant by SDK helpers that compare them, such as DeleteIfUnchanged in compute.
+// The SDK compares server timestamps with other server timestamps wherever it can, so the
+// tolerance only matters when a timestamp comes from another source: the local clock of a
+// host whose time drifts, or a value stored with less precision than the API returns.
+// Zero (the default) requires an exact match.
+func WithClockSkewTolerance(tolerance time.Duration) Option {
+	return func(c *Config) {
+		c.ClockSkewTolerance = tolerance
+	}
+}
+
+// SameInstant reports whether a and b are at most ClockSkewTolerance apart.
+func (c *Config) SameInstant(a, b time.Time) bool {
+	diff := a.Sub(b)
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff <= c.ClockSkewTolerance
+}
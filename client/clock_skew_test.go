@@ -0,0 +1,33 @@
+package client
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConfig_SameInstant(t *testing.T) {
+	server := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		tolerance time.Duration
+		other     time.Time
+		want      bool
+	}{
+		{name: "exact match without tolerance", other: server, want: true},
+		{name: "local clock ahead without tolerance", other: server.Add(2 * time.Second), want: false},
+		{name: "local clock ahead within tolerance", tolerance: 5 * time.Second, other: server.Add(2 * time.Second), want: true},
+		{name: "local clock behind within tolerance", tolerance: 5 * time.Second, other: server.Add(-5 * time.Second), want: true},
+		{name: "local clock beyond tolerance", tolerance: 5 * time.Second, other: server.Add(-6 * time.Second), want: false},
+		{name: "other time zone", other: server.In(time.FixedZone("BRT", -3*60*60)), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewMgcClient("key", WithClockSkewTolerance(tt.tolerance)).GetConfig()
+			if got := cfg.SameInstant(server, tt.other); got != tt.want {
+				t.Errorf("SameInstant() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package client
+
+import (
+	"context"
+	"sync"
+)
+
+// WithMaxConcurrentRequests limits the client to n HTTP requests in flight at once, across
+// every service and goroutine sharing it. Further requests wait for a free slot or for their
+// context to be done. It bounds concurrency during large fan-outs such as batch creates.
+// Zero or a negative n (the default) means no limit.
+func WithMaxConcurrentRequests(n int) Option {
+	return func(c *Config) {
+		c.MaxConcurrentRequests = n
+	}
+}
+
+// AcquireRequestSlot waits until fewer than MaxConcurrentRequests requests are in flight and
+// returns the function that releases the slot, which is safe to call more than once.
+// It returns ctx.Err() if ctx is done first. It is called by the request executor.
+func (c *Config) AcquireRequestSlot(ctx context.Context) (func(), error) {
+	slots := c.requestSlots
+	if slots == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	var once sync.Once
+	return func() { once.Do(func() { <-slots }) }, nil
+}
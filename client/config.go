@@ -3,6 +3,7 @@ package client
 import (
 	"log/slog"
 	"net/http"
+	"sync"
 	"time"
 )
 
@@ -25,6 +26,52 @@ type Config struct {
 	RetryConfig   RetryConfig
 	ContentType   string
 	CustomHeaders map[string]string
+	// InsecureSkipVerify disables TLS certificate verification.
+	// It is intended for local development against self-signed endpoints only
+	// and is ignored when the HTTP client already has a custom transport.
+	InsecureSkipVerify bool
+	// ForceHTTP1 disables HTTP/2, see WithForceHTTP1.
+	ForceHTTP1 bool
+	// CompressRequestBodyOver gzip-compresses request bodies larger than this many bytes.
+	// Zero (the default) disables compression.
+	CompressRequestBodyOver int
+	// CircuitBreaker, when set, makes requests fail fast with ErrCircuitOpen after repeated failures.
+	CircuitBreaker *CircuitBreaker
+	// CaptureLastExchange keeps the last request and response, with secrets redacted,
+	// so they can be retrieved with CoreClient.LastExchange for debugging.
+	CaptureLastExchange bool
+	// StrictDecoding makes JSON responses with fields unknown to the SDK fail to decode.
+	// It helps catch API drift in tests and should stay off in production. Types that expose
+	// a RawExtra field collect unknown fields there instead of failing.
+	StrictDecoding bool
+	// OnWarning, when set, is called for every warning returned by the API.
+	OnWarning func(Warning)
+	// OnRequest, when set, is called after every HTTP attempt.
+	OnRequest func(RequestInfo)
+	// MaxConcurrentRequests caps the HTTP requests in flight at once, see WithMaxConcurrentRequests.
+	MaxConcurrentRequests int
+	// MaxPooledDecodeBuffer is the largest buffer, in bytes, kept for reuse when decoding
+	// JSON responses, see WithMaxPooledDecodeBuffer.
+	MaxPooledDecodeBuffer int
+	// ClockSkewTolerance is how far apart two timestamps may be and still be treated as the
+	// same instant, see WithClockSkewTolerance.
+	ClockSkewTolerance time.Duration
+	// DefaultPageSize is the page size of list calls that do not set a Limit, see WithDefaultPageSize.
+	DefaultPageSize int
+	// RetryPolicy holds per-status retry rules that take precedence over RetryConfig, see WithRetryPolicy.
+	RetryPolicy RetryPolicy
+	// BeforeAttempt, when set, is called before every HTTP attempt, see WithAttemptHooks.
+	BeforeAttempt func(attempt int, req *http.Request)
+	// AfterAttempt, when set, is called after every HTTP attempt, see WithAttemptHooks.
+	AfterAttempt func(attempt int, resp *http.Response, err error)
+	// Middlewares is the chain requests go through, see WithMiddlewares and WithDoer.
+	Middlewares []Middleware
+
+	chain        Middleware
+	exchanges    *exchangeRecorder
+	inFlight     *inFlightTracker
+	requestSlots chan struct{}
+	deprecations *sync.Map
 }
 
 // Option is a function type that modifies the client configuration.
@@ -102,3 +149,69 @@ func WithCustomHeader(key, value string) Option {
 		c.CustomHeaders[key] = value
 	}
 }
+
+// WithInsecureSkipVerify disables TLS certificate verification for API requests.
+// This option is meant for development against non-production endpoints with
+// self-signed certificates and must never be used in production.
+// It has no effect when the HTTP client supplied via WithHTTPClient has a custom transport.
+func WithInsecureSkipVerify(skip bool) Option {
+	return func(c *Config) {
+		c.InsecureSkipVerify = skip
+	}
+}
+
+// WithForceHTTP1 makes the client speak HTTP/1.1 only. By default the client negotiates
+// HTTP/2 over TLS when the server offers it, multiplexing concurrent requests over one
+// connection; force HTTP/1.1 when a proxy between the client and the API mishandles HTTP/2.
+// The transport of an HTTP client supplied via WithHTTPClient is copied before the change;
+// transports other than *http.Transport are left unchanged.
+func WithForceHTTP1(force bool) Option {
+	return func(c *Config) {
+		c.ForceHTTP1 = force
+	}
+}
+
+// WithRequestCompression enables gzip compression of request bodies larger than threshold bytes.
+// Compressed requests carry the "Content-Encoding: gzip" header. Smaller bodies are sent as is,
+// since compressing them usually costs more than it saves. A threshold of zero disables compression.
+func WithRequestCompression(threshold int) Option {
+	return func(c *Config) {
+		c.CompressRequestBodyOver = threshold
+	}
+}
+
+// WithCircuitBreaker enables a circuit breaker shared by all services using the client.
+// After failureThreshold consecutive failures (network errors, 5xx or 429 responses) within
+// window, requests fail immediately with ErrCircuitOpen. Once cooldown has elapsed a single
+// probe request is let through: success closes the circuit, failure opens it again.
+func WithCircuitBreaker(failureThreshold int, window, cooldown time.Duration) Option {
+	return func(c *Config) {
+		c.CircuitBreaker = NewCircuitBreaker(failureThreshold, window, cooldown)
+	}
+}
+
+// WithCaptureLastExchange keeps a redacted copy of the last request and response,
+// retrievable with CoreClient.LastExchange. Only the most recent exchange is kept.
+func WithCaptureLastExchange(capture bool) Option {
+	return func(c *Config) {
+		c.CaptureLastExchange = capture
+	}
+}
+
+// WithStrictDecoding rejects JSON responses containing fields the SDK types do not declare.
+// Disabled by default so new API fields do not break existing clients.
+func WithStrictDecoding(strict bool) Option {
+	return func(c *Config) {
+		c.StrictDecoding = strict
+	}
+}
+
+// WithMaxPooledDecodeBuffer sets the largest buffer, in bytes, that is kept for reuse after
+// reading a JSON response. Response bodies are read into pooled buffers to reduce allocations
+// under heavy list load; buffers grown past n by a larger response are left to the garbage
+// collector. Zero uses DefaultMaxPooledDecodeBuffer and a negative n disables reuse.
+func WithMaxPooledDecodeBuffer(n int) Option {
+	return func(c *Config) {
+		c.MaxPooledDecodeBuffer = n
+	}
+}
@@ -3,6 +3,7 @@ package client
 import (
 	"log/slog"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 	"time"
@@ -290,3 +291,120 @@ func TestWithCustomHeader(t *testing.T) {
 			len(config.CustomHeaders), 1)
 	}
 }
+
+func TestWithInsecureSkipVerify(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	t.Run("disabled by default", func(t *testing.T) {
+		core := NewMgcClient("test-api-key")
+		if core.config.InsecureSkipVerify {
+			t.Error("Expected InsecureSkipVerify to be false by default")
+		}
+		if _, err := core.config.HTTPClient.Get(server.URL); err == nil {
+			t.Error("Expected TLS verification error against self-signed server")
+		}
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		core := NewMgcClient("test-api-key", WithInsecureSkipVerify(true))
+		resp, err := core.config.HTTPClient.Get(server.URL)
+		if err != nil {
+			t.Fatalf("Expected request to succeed, got %v", err)
+		}
+		resp.Body.Close()
+		if http.DefaultClient.Transport != nil {
+			t.Error("Expected http.DefaultClient to remain untouched")
+		}
+	})
+
+	t.Run("ignored with custom transport", func(t *testing.T) {
+		transport := &http.Transport{}
+		httpClient := &http.Client{Transport: transport}
+		core := NewMgcClient("test-api-key", WithHTTPClient(httpClient), WithInsecureSkipVerify(true))
+		if core.config.HTTPClient != httpClient || httpClient.Transport != transport {
+			t.Error("Expected custom HTTP client and transport to be kept")
+		}
+		if _, err := core.config.HTTPClient.Get(server.URL); err == nil {
+			t.Error("Expected TLS verification error with custom transport")
+		}
+	})
+}
+
+func TestWithForceHTTP1(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Proto", r.Proto)
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	tests := []struct {
+		name      string
+		opts      []Option
+		wantProto string
+	}{
+		{name: "negotiates HTTP/2 by default", wantProto: "HTTP/2.0"},
+		{name: "forced HTTP/1.1", opts: []Option{WithForceHTTP1(true)}, wantProto: "HTTP/1.1"},
+		{
+			name:      "forced HTTP/1.1 with a custom transport",
+			opts:      []Option{WithHTTPClient(server.Client()), WithForceHTTP1(true)},
+			wantProto: "HTTP/1.1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]Option{WithInsecureSkipVerify(true)}, tt.opts...)
+			core := NewMgcClient("test-api-key", opts...)
+			resp, err := core.config.HTTPClient.Get(server.URL)
+			if err != nil {
+				t.Fatalf("Expected request to succeed, got %v", err)
+			}
+			resp.Body.Close()
+			if got := resp.Header.Get("X-Proto"); got != tt.wantProto {
+				t.Errorf("Expected %s, got %s", tt.wantProto, got)
+			}
+		})
+	}
+
+	if http.DefaultClient.Transport != nil {
+		t.Error("Expected http.DefaultClient to remain untouched")
+	}
+}
+
+func TestWithRequestCompression(t *testing.T) {
+	config := &Config{}
+	threshold := 4096
+
+	WithRequestCompression(threshold)(config)
+
+	if config.CompressRequestBodyOver != threshold {
+		t.Errorf("Expected CompressRequestBodyOver to be %d, got %d", threshold, config.CompressRequestBodyOver)
+	}
+}
+
+func TestWithCircuitBreaker(t *testing.T) {
+	config := &Config{}
+
+	WithCircuitBreaker(5, time.Minute, 30*time.Second)(config)
+
+	if config.CircuitBreaker == nil {
+		t.Fatal("Expected CircuitBreaker to be set")
+	}
+	if config.CircuitBreaker.State() != CircuitClosed {
+		t.Errorf("Expected new circuit breaker to be closed, got %s", config.CircuitBreaker.State())
+	}
+}
+
+func TestWithStrictDecoding(t *testing.T) {
+	config := &Config{}
+
+	WithStrictDecoding(true)(config)
+
+	if !config.StrictDecoding {
+		t.Error("Expected StrictDecoding to be true")
+	}
+}
@@ -0,0 +1,103 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+
+	"github.com/MagaluCloud/mgc-sdk-go/internal/utils"
+)
+
+// Validate reports configuration that would make every request fail or behave surprisingly:
+// a missing API key or HTTP client, a base URL that is not an absolute http(s) URL, negative
+// durations and sizes, retry settings that contradict each other, and a circuit breaker
+// failure threshold below 1. Every problem found is returned as a *ValidationError, joined
+// with errors.Join.
+func (c *Config) Validate() error {
+	var errs []error
+	invalid := func(field, message string) {
+		errs = append(errs, &ValidationError{Field: field, Message: message})
+	}
+
+	if c.APIKey == "" {
+		invalid("apiKey", utils.CannotBeEmpty)
+	}
+	if c.HTTPClient == nil {
+		invalid("httpClient", "cannot be nil")
+	}
+
+	if err := ValidateBaseURL(c.BaseURL); err != nil {
+		errs = append(errs, err)
+	}
+
+	if c.Timeout < 0 {
+		invalid("timeout", "cannot be negative")
+	}
+	if c.CompressRequestBodyOver < 0 {
+		invalid("compressRequestBodyOver", "cannot be negative")
+	}
+	if c.DefaultPageSize < 0 {
+		invalid("defaultPageSize", "cannot be negative")
+	}
+	if c.ClockSkewTolerance < 0 {
+		invalid("clockSkewTolerance", "cannot be negative")
+	}
+
+	retry := c.RetryConfig
+	if retry.MaxAttempts < 1 {
+		invalid("retryConfig.maxAttempts", "must be at least 1")
+	}
+	if retry.InitialInterval < 0 {
+		invalid("retryConfig.initialInterval", "cannot be negative")
+	}
+	if retry.MaxInterval < retry.InitialInterval {
+		invalid("retryConfig.maxInterval", fmt.Sprintf("%v is shorter than initialInterval %v", retry.MaxInterval, retry.InitialInterval))
+	}
+	if retry.BackoffFactor < 1 {
+		invalid("retryConfig.backoffFactor", "must be at least 1")
+	}
+	for _, status := range slices.Sorted(maps.Keys(c.RetryPolicy)) {
+		if status < 100 || status > 599 {
+			invalid(fmt.Sprintf("retryPolicy[%d]", status), "is not an HTTP status code")
+		}
+	}
+
+	if c.CircuitBreaker != nil && c.CircuitBreaker.failureThreshold <= 0 {
+		invalid("circuitBreaker.failureThreshold", "must be at least 1")
+	}
+
+	for i, middleware := range c.Middlewares {
+		if middleware == nil {
+			invalid(fmt.Sprintf("middlewares[%d]", i), "cannot be nil")
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ValidateBaseURL checks that baseURL is an absolute http or https URL.
+func ValidateBaseURL(baseURL MgcUrl) error {
+	if baseURL == "" {
+		return &ValidationError{Field: "baseURL", Message: utils.CannotBeEmpty}
+	}
+	u, err := url.Parse(baseURL.String())
+	if err != nil {
+		return &ValidationError{Field: "baseURL", Message: fmt.Sprintf("is malformed: %v", err)}
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return &ValidationError{Field: "baseURL", Message: fmt.Sprintf("%q must be an absolute http or https URL", baseURL)}
+	}
+	return nil
+}
+
+// NewValidatedMgcClient is NewMgcClient followed by Config.Validate, so a misconfigured
+// client fails at construction instead of on its first request.
+func NewValidatedMgcClient(apiKey string, opts ...Option) (*CoreClient, error) {
+	c := NewMgcClient(apiKey, opts...)
+	if err := c.config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid client configuration: %w", err)
+	}
+	return c, nil
+}
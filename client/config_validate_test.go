@@ -0,0 +1,160 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []Option
+		apiKey    string
+		wantField string
+	}{
+		{
+			name:   "defaults are valid",
+			apiKey: "key",
+		},
+		{
+			name:      "empty API key",
+			wantField: "apiKey",
+		},
+		{
+			name:      "nil HTTP client",
+			apiKey:    "key",
+			opts:      []Option{WithHTTPClient(nil)},
+			wantField: "httpClient",
+		},
+		{
+			name:      "empty base URL",
+			apiKey:    "key",
+			opts:      []Option{WithBaseURL("")},
+			wantField: "baseURL",
+		},
+		{
+			name:      "malformed base URL",
+			apiKey:    "key",
+			opts:      []Option{WithBaseURL("https://api.magalu.cloud/%zz")},
+			wantField: "baseURL",
+		},
+		{
+			name:      "relative base URL",
+			apiKey:    "key",
+			opts:      []Option{WithBaseURL("api.magalu.cloud/br-se1")},
+			wantField: "baseURL",
+		},
+		{
+			name:      "unsupported scheme",
+			apiKey:    "key",
+			opts:      []Option{WithBaseURL("ftp://api.magalu.cloud")},
+			wantField: "baseURL",
+		},
+		{
+			name:      "negative timeout",
+			apiKey:    "key",
+			opts:      []Option{WithTimeout(-time.Second)},
+			wantField: "timeout",
+		},
+		{
+			name:      "negative default page size",
+			apiKey:    "key",
+			opts:      []Option{WithDefaultPageSize(-1)},
+			wantField: "defaultPageSize",
+		},
+		{
+			name:      "negative clock skew tolerance",
+			apiKey:    "key",
+			opts:      []Option{WithClockSkewTolerance(-time.Second)},
+			wantField: "clockSkewTolerance",
+		},
+		{
+			name:      "retry policy for a non-HTTP status",
+			apiKey:    "key",
+			opts:      []Option{WithRetryPolicy(RetryPolicy{http.StatusServiceUnavailable: {MaxAttempts: 3}, 42: {MaxAttempts: 3}})},
+			wantField: "retryPolicy[42]",
+		},
+		{
+			name:      "nil middleware",
+			apiKey:    "key",
+			opts:      []Option{WithMiddlewares(LoggingMiddleware(nil), nil)},
+			wantField: "middlewares[1]",
+		},
+		{
+			name:      "negative compression threshold",
+			apiKey:    "key",
+			opts:      []Option{WithRequestCompression(-1)},
+			wantField: "compressRequestBodyOver",
+		},
+		{
+			name:      "no attempts",
+			apiKey:    "key",
+			opts:      []Option{WithRetryConfig(0, time.Second, time.Second, 2)},
+			wantField: "retryConfig.maxAttempts",
+		},
+		{
+			name:      "negative initial interval",
+			apiKey:    "key",
+			opts:      []Option{WithRetryConfig(3, -time.Second, time.Second, 2)},
+			wantField: "retryConfig.initialInterval",
+		},
+		{
+			name:      "max interval below initial interval",
+			apiKey:    "key",
+			opts:      []Option{WithRetryConfig(3, 10*time.Second, time.Second, 2)},
+			wantField: "retryConfig.maxInterval",
+		},
+		{
+			name:      "shrinking backoff",
+			apiKey:    "key",
+			opts:      []Option{WithRetryConfig(3, time.Second, 10*time.Second, 0.5)},
+			wantField: "retryConfig.backoffFactor",
+		},
+		{
+			name:      "circuit breaker without failures to count",
+			apiKey:    "key",
+			opts:      []Option{WithCircuitBreaker(0, time.Minute, 30*time.Second)},
+			wantField: "circuitBreaker.failureThreshold",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewValidatedMgcClient(tt.apiKey, tt.opts...)
+			if tt.wantField == "" {
+				if err != nil || c == nil {
+					t.Fatalf("NewValidatedMgcClient() = %v, %v", c, err)
+				}
+				return
+			}
+
+			if c != nil {
+				t.Error("expected no client for an invalid configuration")
+			}
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Field != tt.wantField {
+				t.Errorf("expected a ValidationError on %s, got %v", tt.wantField, err)
+			}
+		})
+	}
+}
+
+func TestConfig_Validate_ReportsEveryProblem(t *testing.T) {
+	config := &Config{HTTPClient: http.DefaultClient, BaseURL: BrSe1, Timeout: -1}
+	err := config.Validate()
+
+	fields := map[string]bool{}
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var validationErr *ValidationError
+		if errors.As(e, &validationErr) {
+			fields[validationErr.Field] = true
+		}
+	}
+	for _, field := range []string{"apiKey", "timeout", "retryConfig.maxAttempts", "retryConfig.backoffFactor"} {
+		if !fields[field] {
+			t.Errorf("expected a problem with %s, got %v", field, err)
+		}
+	}
+}
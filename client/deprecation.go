@@ -0,0 +1,18 @@
+package client
+
+import "sync"
+
+// FirstDeprecationNotice reports whether the deprecation of endpoint is seen for the first
+// time by this client, so the request executor logs it only once per endpoint.
+func (c *Config) FirstDeprecationNotice(endpoint string) bool {
+	if c.deprecations == nil {
+		return true
+	}
+	_, seen := c.deprecations.LoadOrStore(endpoint, struct{}{})
+	return !seen
+}
+
+// newDeprecationLog returns the set of endpoints whose deprecation was already logged
+func newDeprecationLog() *sync.Map {
+	return &sync.Map{}
+}
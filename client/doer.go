@@ -0,0 +1,29 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// Doer sends a request prepared by a service client and returns the successful response.
+// The default Doer retries with backoff, honours the circuit breaker and reports exchanges,
+// warnings and request hooks. It returns a *HTTPError for non-2xx responses and the caller
+// closes the body of the returned response.
+type Doer interface {
+	Do(ctx context.Context, req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc adapts a function to the Doer interface.
+type DoerFunc func(ctx context.Context, req *http.Request) (*http.Response, error)
+
+// Do implements Doer.
+func (f DoerFunc) Do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	return f(ctx, req)
+}
+
+// WithDoer adds wrap to the middlewares requests go through, see WithMiddlewares. wrap
+// receives the next Doer of the chain and returns the one to use, e.g. to add caching or
+// metrics without forking the SDK. Response decoding happens after the returned Doer.
+func WithDoer(wrap func(next Doer) Doer) Option {
+	return WithMiddlewares(wrap)
+}
@@ -1,11 +1,24 @@
 package client
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 )
 
+// Sentinel errors returned by the client.
+var (
+	// ErrAuthentication indicates that the API rejected the configured credentials.
+	ErrAuthentication = errors.New("authentication failed")
+	// ErrConnectivity indicates that the API could not be reached or did not answer successfully.
+	ErrConnectivity = errors.New("connectivity check failed")
+	// ErrCircuitOpen is returned without sending the request while the circuit breaker is open.
+	ErrCircuitOpen = errors.New("circuit breaker is open: API calls are failing fast")
+	// ErrClientClosed is returned for requests started after CoreClient.Close was called.
+	ErrClientClosed = errors.New("client is closed")
+)
+
 // HTTPError represents an error that occurred during an HTTP request.
 // This error type includes the HTTP status code, status message, and response body.
 type HTTPError struct {
@@ -46,6 +59,30 @@ func (e *ValidationError) Error() string {
 	return fmt.Sprintf("validation error: %s - %s", e.Field, e.Message)
 }
 
+// DecodeError is returned when a successful response cannot be decoded, typically because
+// a gateway or proxy answered with an HTML page instead of the API's JSON. It carries enough
+// of the response to see the cause; the decoding error is available through errors.Unwrap.
+type DecodeError struct {
+	StatusCode  int
+	ContentType string
+	// Snippet is the start of the response body, truncated to a few hundred bytes. It is
+	// empty for JSON bodies, which may carry secrets.
+	Snippet string
+	Err     error
+}
+
+// Error returns a string representation of the decode error.
+// This method implements the error interface.
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("error decoding response (HTTP %d, Content-Type %q): %v; body: %q",
+		e.StatusCode, e.ContentType, e.Err, e.Snippet)
+}
+
+// Unwrap returns the underlying decoding error.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
 // RetryError represents an error that occurred after exhausting all retry attempts.
 // This error type includes the last error encountered and the number of retries attempted.
 type RetryError struct {
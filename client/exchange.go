@@ -0,0 +1,149 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// redactedValue replaces secrets in captured exchanges.
+const redactedValue = "REDACTED"
+
+// sensitiveHeaders lists headers whose values are never kept in a captured exchange.
+var sensitiveHeaders = []string{"X-Api-Key", "Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// sensitiveBodyKeys lists JSON keys whose values are redacted in captured bodies.
+// Keys are matched case-insensitively and by substring, so "api_key" also covers "x_api_key".
+var sensitiveBodyKeys = []string{"password", "secret", "token", "api_key", "apikey", "private_key", "credential"}
+
+// Exchange is the last request sent by the client and the response it received.
+// Headers and JSON bodies have secrets redacted.
+type Exchange struct {
+	Request ExchangeRequest
+	// Response is nil when the request failed before a response was received.
+	Response *ExchangeResponse
+}
+
+// ExchangeRequest is the request half of an Exchange.
+type ExchangeRequest struct {
+	Method string
+	URL    string
+	// PathTemplate is the request's path template, see RequestInfo.
+	PathTemplate string
+	Header       http.Header
+	Body         []byte
+}
+
+// ExchangeResponse is the response half of an Exchange.
+type ExchangeResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// exchangeRecorder holds the most recent exchange. It is shared by copies of Config.
+type exchangeRecorder struct {
+	mu   sync.Mutex
+	last *Exchange
+}
+
+// RecordExchange stores a redacted copy of the exchange as the last one when
+// CaptureLastExchange is enabled. It is called by the request executor.
+func (c *Config) RecordExchange(req *http.Request, reqBody []byte, resp *http.Response, respBody []byte) {
+	if c.exchanges == nil {
+		return
+	}
+
+	exchange := &Exchange{
+		Request: ExchangeRequest{
+			Method:       req.Method,
+			URL:          req.URL.String(),
+			PathTemplate: PathTemplate(req.Context()),
+			Header:       redactHeader(req.Header),
+			Body:         redactBody(reqBody),
+		},
+	}
+	if resp != nil {
+		exchange.Response = &ExchangeResponse{
+			StatusCode: resp.StatusCode,
+			Header:     redactHeader(resp.Header),
+			Body:       redactBody(respBody),
+		}
+	}
+
+	c.exchanges.mu.Lock()
+	c.exchanges.last = exchange
+	c.exchanges.mu.Unlock()
+}
+
+// LastExchange returns the last request/response captured by the client, or nil when
+// nothing was captured yet or CaptureLastExchange is disabled.
+func (c *CoreClient) LastExchange() *Exchange {
+	if c.config.exchanges == nil {
+		return nil
+	}
+
+	c.config.exchanges.mu.Lock()
+	defer c.config.exchanges.mu.Unlock()
+	return c.config.exchanges.last
+}
+
+func redactHeader(h http.Header) http.Header {
+	redacted := h.Clone()
+	for _, name := range sensitiveHeaders {
+		if redacted.Get(name) != "" {
+			redacted.Set(name, redactedValue)
+		}
+	}
+	return redacted
+}
+
+// redactBody redacts sensitive values in JSON bodies. Other bodies are returned as-is.
+func redactBody(body []byte) []byte {
+	if len(body) == 0 {
+		return nil
+	}
+
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return append([]byte(nil), body...)
+	}
+
+	redacted, err := json.Marshal(redactJSONValue(v))
+	if err != nil {
+		return append([]byte(nil), body...)
+	}
+	return redacted
+}
+
+func redactJSONValue(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, inner := range val {
+			if isSensitiveKey(k) {
+				val[k] = redactedValue
+				continue
+			}
+			val[k] = redactJSONValue(inner)
+		}
+		return val
+	case []any:
+		for i, inner := range val {
+			val[i] = redactJSONValue(inner)
+		}
+		return val
+	default:
+		return v
+	}
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, sensitive := range sensitiveBodyKeys {
+		if strings.Contains(key, sensitive) {
+			return true
+		}
+	}
+	return false
+}
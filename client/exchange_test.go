@@ -0,0 +1,86 @@
+package client
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestConfig_RecordExchange(t *testing.T) {
+	core := NewMgcClient("secret-api-key", WithCaptureLastExchange(true))
+
+	if core.LastExchange() != nil {
+		t.Fatal("expected no exchange before any request")
+	}
+
+	req := &http.Request{
+		Method: http.MethodPost,
+		URL:    &url.URL{Scheme: "https", Host: "api.example.com", Path: "/v0/credentials"},
+		Header: http.Header{
+			"X-Api-Key":    []string{"secret-api-key"},
+			"Content-Type": []string{"application/json"},
+		},
+	}
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Set-Cookie": []string{"session=abc"}},
+	}
+
+	core.GetConfig().RecordExchange(req,
+		[]byte(`{"name":"pool","nested":{"password":"hunter2"}}`),
+		resp,
+		[]byte(`{"username":"user","password":"p4ss","items":[{"token":"t"}]}`))
+
+	exchange := core.LastExchange()
+	if exchange == nil {
+		t.Fatal("expected exchange to be captured")
+	}
+	if exchange.Request.Method != http.MethodPost || exchange.Request.URL != "https://api.example.com/v0/credentials" {
+		t.Errorf("unexpected request %s %s", exchange.Request.Method, exchange.Request.URL)
+	}
+	if got := exchange.Request.Header.Get("X-Api-Key"); got != redactedValue {
+		t.Errorf("expected api key to be redacted, got %q", got)
+	}
+	if got := exchange.Request.Header.Get("Content-Type"); got != "application/json" {
+		t.Errorf("expected Content-Type to be kept, got %q", got)
+	}
+	if req.Header.Get("X-Api-Key") != "secret-api-key" {
+		t.Error("expected original request headers to be left untouched")
+	}
+	if got := exchange.Response.Header.Get("Set-Cookie"); got != redactedValue {
+		t.Errorf("expected cookie to be redacted, got %q", got)
+	}
+
+	for _, body := range []string{string(exchange.Request.Body), string(exchange.Response.Body)} {
+		for _, secret := range []string{"hunter2", "p4ss", `"t"`} {
+			if strings.Contains(body, secret) {
+				t.Errorf("expected %s to be redacted from %s", secret, body)
+			}
+		}
+	}
+	if !strings.Contains(string(exchange.Response.Body), `"username":"user"`) {
+		t.Errorf("expected non-sensitive fields to be kept, got %s", exchange.Response.Body)
+	}
+}
+
+func TestConfig_RecordExchange_Disabled(t *testing.T) {
+	core := NewMgcClient("secret-api-key")
+
+	req := &http.Request{Method: http.MethodGet, URL: &url.URL{Path: "/"}, Header: http.Header{}}
+	core.GetConfig().RecordExchange(req, nil, nil, nil)
+
+	if core.LastExchange() != nil {
+		t.Error("expected no exchange when capture is disabled")
+	}
+}
+
+func TestRedactBody_NonJSON(t *testing.T) {
+	body := []byte("plain text")
+	if got := redactBody(body); string(got) != "plain text" {
+		t.Errorf("expected non-JSON body to be kept, got %q", got)
+	}
+	if got := redactBody(nil); got != nil {
+		t.Errorf("expected nil body, got %q", got)
+	}
+}
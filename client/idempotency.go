@@ -0,0 +1,32 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// IdempotencyKeyHeader carries the idempotency key of a request.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// idempotencyKey is the context key holding the idempotency key of a request.
+type idempotencyKey struct{}
+
+// WithIdempotencyKey returns a context whose requests send key in the Idempotency-Key header,
+// so the API can recognize a create repeated by retries and apply it only once.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKey{}, key)
+}
+
+// IdempotencyKey returns the key stored in ctx by WithIdempotencyKey, or "".
+func IdempotencyKey(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKey{}).(string)
+	return key
+}
+
+// NewIdempotencyKey returns a random key suitable for WithIdempotencyKey.
+func NewIdempotencyKey() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
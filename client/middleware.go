@@ -0,0 +1,260 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/MagaluCloud/mgc-sdk-go/helpers"
+	"github.com/MagaluCloud/mgc-sdk-go/internal/retry"
+	"github.com/MagaluCloud/mgc-sdk-go/internal/utils"
+)
+
+// Middleware wraps the Doer that sends a request with cross-cutting behaviour such as
+// logging, metrics, tracing or retries.
+type Middleware func(next Doer) Doer
+
+// WithMiddlewares adds middlewares to the chain requests go through, in order: the first
+// middleware added, by this or an earlier WithMiddlewares or WithDoer option, is the outermost
+// and sees each request first.
+//
+// The executor at the end of the chain retries every request as RetryMiddleware does with the
+// client's RetryConfig and RetryPolicy, so middlewares see each request once. Place
+// RetryMiddleware in the chain to retry at that point instead, e.g. so that a RateLimitMiddleware
+// after it counts every attempt; the executor then sends a single attempt.
+//
+// Example:
+//
+//	client.WithMiddlewares(
+//	    client.LoggingMiddleware(logger),
+//	    client.RetryMiddleware(client.RetryConfig{
+//	        MaxAttempts: 3, InitialInterval: time.Second, MaxInterval: 10 * time.Second, BackoffFactor: 2,
+//	    }, nil),
+//	    client.RateLimitMiddleware(10),
+//	)
+func WithMiddlewares(middlewares ...Middleware) Option {
+	return func(c *Config) {
+		c.Middlewares = append(c.Middlewares, middlewares...)
+	}
+}
+
+// Chain returns the Doer requests are sent with: base, which sends a single attempt, wrapped
+// by the built-in retries and then by the middlewares. It is called by the request executor.
+func (c *Config) Chain(base Doer) Doer {
+	chain := c.chain
+	if chain == nil {
+		chain = chainMiddlewares(c.Middlewares)
+	}
+	return chain(retryMiddleware(c.RetryConfig, c.RetryPolicy, true)(base))
+}
+
+// chainMiddlewares composes middlewares into one, the first being the outermost.
+func chainMiddlewares(middlewares []Middleware) Middleware {
+	middlewares = append([]Middleware{}, middlewares...)
+	return func(next Doer) Doer {
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			next = middlewares[i](next)
+		}
+		return next
+	}
+}
+
+// retryingKey is the context key marking a request already retried by a RetryMiddleware.
+type retryingKey struct{}
+
+// RetryMiddleware retries requests: network errors and 5xx and 429 responses are sent again
+// with the exponential backoff of cfg, up to cfg.MaxAttempts attempts in total, while statuses
+// with a rule in policy follow that rule and honour Retry-After, see RetryPolicy. Once the
+// attempts are used up a *RetryError is returned; other errors are returned as is.
+// WithoutRetry still limits a request to one attempt. policy may be nil.
+func RetryMiddleware(cfg RetryConfig, policy RetryPolicy) Middleware {
+	return retryMiddleware(cfg, policy, false)
+}
+
+// retryMiddleware implements RetryMiddleware. The builtin one, used by Chain, steps aside for
+// requests a RetryMiddleware further out in the chain already retries.
+func retryMiddleware(cfg RetryConfig, policy RetryPolicy, builtin bool) Middleware {
+	backoff := helpers.Backoff{Initial: cfg.InitialInterval, Max: cfg.MaxInterval, Factor: cfg.BackoffFactor}
+
+	return func(next Doer) Doer {
+		return DoerFunc(func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			if builtin && ctx.Value(retryingKey{}) != nil {
+				return next.Do(ctx, req)
+			}
+			ctx = context.WithValue(ctx, retryingKey{}, true)
+
+			var body []byte
+			if req.Body != nil {
+				var err error
+				body, err = io.ReadAll(req.Body)
+				req.Body.Close()
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			maxAttempts := max(cfg.MaxAttempts, 1)
+			retryDisabled := RetryDisabled(ctx)
+			if retryDisabled {
+				maxAttempts = 1
+			}
+
+			clock := utils.ClockFrom(ctx)
+			var lastError error
+			var delay time.Duration
+			attempt := 0
+			for ; ; attempt++ {
+				if attempt > 0 {
+					if err := clock.Sleep(ctx, delay); err != nil {
+						return nil, err
+					}
+				}
+
+				attemptCtx := utils.WithAttempt(ctx, attempt+1)
+				attemptReq := req.Clone(attemptCtx)
+				if body != nil {
+					attemptReq.Body = io.NopCloser(bytes.NewReader(body))
+				}
+				resp, err := next.Do(attemptCtx, attemptReq)
+				if err == nil {
+					return resp, nil
+				}
+
+				limit := maxAttempts
+				var httpErr *HTTPError
+				if errors.As(err, &httpErr) && ctx.Err() == nil {
+					var retryable bool
+					limit, delay, retryable = retryPlan(cfg, policy, httpErr, attempt, backoff, clock.Now())
+					if !retryable {
+						return nil, err
+					}
+					if retryDisabled {
+						limit = 1
+					}
+				} else if !retryableError(ctx, err) {
+					return nil, err
+				} else {
+					delay = backoff.NextDelay(attempt)
+				}
+
+				lastError = err
+				if attempt+1 >= limit {
+					break
+				}
+			}
+			return nil, &RetryError{LastError: lastError, Retries: attempt + 1}
+		})
+	}
+}
+
+// retryPlan decides how a failed response is retried after attempt, counted from zero. It
+// returns the total attempts allowed for the response's status, the delay before the next
+// attempt, and whether the status is retried at all. Statuses with a rule in policy follow it
+// and honour Retry-After, read relative to now; the others follow cfg.
+func retryPlan(cfg RetryConfig, policy RetryPolicy, httpErr *HTTPError, attempt int, backoff helpers.Backoff, now time.Time) (int, time.Duration, bool) {
+	rule, ok := policy[httpErr.StatusCode]
+	if !ok {
+		return cfg.MaxAttempts, backoff.NextDelay(attempt), retry.ShouldRetry(httpErr.StatusCode)
+	}
+	if rule.MaxAttempts <= 1 {
+		return 0, 0, false
+	}
+
+	var header http.Header
+	if httpErr.Response != nil {
+		header = httpErr.Response.Header
+	}
+	retryAfter, hasRetryAfter := retry.RetryAfter(header.Get("Retry-After"), now)
+	if rule.RequireRetryAfter && !hasRetryAfter {
+		return 0, 0, false
+	}
+	if hasRetryAfter {
+		return rule.MaxAttempts, retryAfter, true
+	}
+	if rule.Backoff.Initial > 0 {
+		backoff = rule.Backoff
+	}
+	return rule.MaxAttempts, backoff.NextDelay(attempt), true
+}
+
+// retryableError reports whether an error other than a *HTTPError is worth another attempt.
+func retryableError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, ErrCircuitOpen) {
+		return false
+	}
+	var validationErr *ValidationError
+	return !errors.As(err, &validationErr)
+}
+
+// RateLimitMiddleware spaces the requests going through it so that at most perSecond start
+// every second, across every goroutine sharing the client. Requests wait for their turn or
+// for their context to be done. Zero or a negative perSecond disables the limit.
+// Unlike WithMaxConcurrentRequests, it bounds the request rate rather than the requests in flight.
+func RateLimitMiddleware(perSecond float64) Middleware {
+	if perSecond <= 0 {
+		return func(next Doer) Doer { return next }
+	}
+	interval := time.Duration(float64(time.Second) / perSecond)
+
+	var mu sync.Mutex
+	var nextStart time.Time
+	return func(next Doer) Doer {
+		return DoerFunc(func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			clock := utils.ClockFrom(ctx)
+			mu.Lock()
+			now := clock.Now()
+			start := now
+			if nextStart.After(start) {
+				start = nextStart
+			}
+			nextStart = start.Add(interval)
+			mu.Unlock()
+
+			if wait := start.Sub(now); wait > 0 {
+				if err := clock.Sleep(ctx, wait); err != nil {
+					return nil, err
+				}
+			}
+			return next.Do(ctx, req)
+		})
+	}
+}
+
+// LoggingMiddleware logs every request going through it once it completes, with its method,
+// URL, path template, status and duration, at Info level, or at Warn level when it fails.
+// A nil logger uses slog.Default.
+func LoggingMiddleware(logger *slog.Logger) Middleware {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return func(next Doer) Doer {
+		return DoerFunc(func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			clock := utils.ClockFrom(ctx)
+			start := clock.Now()
+			resp, err := next.Do(ctx, req)
+
+			attrs := []any{
+				"method", req.Method,
+				"url", req.URL.String(),
+				"pathTemplate", PathTemplate(ctx),
+				"duration", clock.Now().Sub(start),
+			}
+			if err != nil {
+				var httpErr *HTTPError
+				if errors.As(err, &httpErr) {
+					attrs = append(attrs, "status", httpErr.StatusCode)
+				}
+				logger.Warn("request failed", append(attrs, "error", err)...)
+			} else {
+				logger.Info("request completed", append(attrs, "status", resp.StatusCode)...)
+			}
+			return resp, err
+		})
+	}
+}
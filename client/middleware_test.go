@@ -0,0 +1,202 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestChain_Order(t *testing.T) {
+	var calls []string
+	record := func(name string) Middleware {
+		return func(next Doer) Doer {
+			return DoerFunc(func(ctx context.Context, req *http.Request) (*http.Response, error) {
+				calls = append(calls, name+" before")
+				resp, err := next.Do(ctx, req)
+				calls = append(calls, name+" after")
+				return resp, err
+			})
+		}
+	}
+
+	core := NewMgcClient("test-api", WithMiddlewares(record("first"), record("second")), WithDoer(func(next Doer) Doer {
+		return record("wrap")(next)
+	}))
+	base := DoerFunc(func(context.Context, *http.Request) (*http.Response, error) {
+		calls = append(calls, "base")
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	if _, err := core.GetConfig().Chain(base).Do(context.Background(), req); err != nil {
+		t.Fatalf("Do() unexpected error: %v", err)
+	}
+
+	want := "first before,second before,wrap before,base,wrap after,second after,first after"
+	if got := strings.Join(calls, ","); got != want {
+		t.Errorf("expected calls %s, got %s", want, got)
+	}
+}
+
+func TestRetryMiddleware(t *testing.T) {
+	retryConfig := RetryConfig{MaxAttempts: 3, InitialInterval: time.Millisecond, MaxInterval: time.Millisecond, BackoffFactor: 1}
+
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		policy       RetryPolicy
+		errs         []error
+		wantAttempts int
+		wantRetryErr bool
+	}{
+		{
+			name:         "retries server errors until success",
+			ctx:          context.Background(),
+			errs:         []error{&HTTPError{StatusCode: http.StatusServiceUnavailable}, errors.New("connection reset"), nil},
+			wantAttempts: 3,
+		},
+		{
+			name:         "gives up after max attempts",
+			ctx:          context.Background(),
+			errs:         []error{&HTTPError{StatusCode: http.StatusBadGateway}, &HTTPError{StatusCode: http.StatusBadGateway}, &HTTPError{StatusCode: http.StatusBadGateway}},
+			wantAttempts: 3,
+			wantRetryErr: true,
+		},
+		{
+			name:         "client errors are not retried",
+			ctx:          context.Background(),
+			errs:         []error{&HTTPError{StatusCode: http.StatusNotFound}},
+			wantAttempts: 1,
+		},
+		{
+			name:         "open circuit is not retried",
+			ctx:          context.Background(),
+			errs:         []error{ErrCircuitOpen},
+			wantAttempts: 1,
+		},
+		{
+			name:         "policy retries a status RetryConfig does not",
+			ctx:          context.Background(),
+			policy:       RetryPolicy{http.StatusConflict: {MaxAttempts: 2}},
+			errs:         []error{&HTTPError{StatusCode: http.StatusConflict}, nil},
+			wantAttempts: 2,
+		},
+		{
+			name:   "policy requiring Retry-After",
+			ctx:    context.Background(),
+			policy: RetryPolicy{http.StatusTooManyRequests: {MaxAttempts: 3, RequireRetryAfter: true}},
+			errs: []error{
+				&HTTPError{StatusCode: http.StatusTooManyRequests, Response: &http.Response{Header: http.Header{"Retry-After": {"0"}}}},
+				&HTTPError{StatusCode: http.StatusTooManyRequests},
+			},
+			wantAttempts: 2,
+		},
+		{
+			name:         "WithoutRetry sends once",
+			ctx:          WithoutRetry(context.Background()),
+			errs:         []error{&HTTPError{StatusCode: http.StatusServiceUnavailable}},
+			wantAttempts: 1,
+			wantRetryErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var bodies []string
+			base := DoerFunc(func(_ context.Context, req *http.Request) (*http.Response, error) {
+				body, _ := io.ReadAll(req.Body)
+				bodies = append(bodies, string(body))
+				if err := tt.errs[len(bodies)-1]; err != nil {
+					return nil, err
+				}
+				return &http.Response{StatusCode: http.StatusOK}, nil
+			})
+
+			req, _ := http.NewRequest(http.MethodPost, "http://example.com", strings.NewReader(`{"name":"vm"}`))
+			_, err := RetryMiddleware(retryConfig, tt.policy)(base).Do(tt.ctx, req)
+
+			if len(bodies) != tt.wantAttempts {
+				t.Fatalf("expected %d attempts, got %d", tt.wantAttempts, len(bodies))
+			}
+			for _, body := range bodies {
+				if body != `{"name":"vm"}` {
+					t.Errorf("expected the body on every attempt, got %q", body)
+				}
+			}
+			var retryErr *RetryError
+			if errors.As(err, &retryErr) != tt.wantRetryErr {
+				t.Errorf("expected RetryError %v, got %v", tt.wantRetryErr, err)
+			}
+			if tt.errs[len(tt.errs)-1] == nil && err != nil {
+				t.Errorf("Do() unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	base := DoerFunc(func(context.Context, *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})
+	doer := RateLimitMiddleware(50)(base)
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+
+	start := time.Now()
+	for range 3 {
+		if _, err := doer.Do(context.Background(), req); err != nil {
+			t.Fatalf("Do() unexpected error: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("expected 3 requests at 50/s to take at least 40ms, took %v", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	doer = RateLimitMiddleware(0.001)(base)
+	doer.Do(context.Background(), req)
+	if _, err := doer.Do(ctx, req); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected a cancelled wait to return context.Canceled, got %v", err)
+	}
+}
+
+func TestLoggingMiddleware(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	responses := []error{nil, &HTTPError{StatusCode: http.StatusConflict}}
+	var calls int
+	doer := LoggingMiddleware(logger)(DoerFunc(func(context.Context, *http.Request) (*http.Response, error) {
+		err := responses[calls]
+		calls++
+		if err != nil {
+			return nil, err
+		}
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	}))
+
+	req, _ := http.NewRequest(http.MethodDelete, "http://example.com/v1/things/1", nil)
+	doer.Do(context.Background(), req)
+	doer.Do(context.Background(), req)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 log lines, got %q", buf.String())
+	}
+	for _, want := range []string{"level=INFO", `msg="request completed"`, "method=DELETE", "url=http://example.com/v1/things/1", "status=200"} {
+		if !strings.Contains(lines[0], want) {
+			t.Errorf("expected %q in %s", want, lines[0])
+		}
+	}
+	for _, want := range []string{"level=WARN", `msg="request failed"`, "status=409"} {
+		if !strings.Contains(lines[1], want) {
+			t.Errorf("expected %q in %s", want, lines[1])
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package client
+
+import "context"
+
+// noRetryKey is the context key marking a request as single-shot.
+type noRetryKey struct{}
+
+// WithoutRetry returns a context whose requests are sent once, ignoring RetryConfig.MaxAttempts,
+// e.g. to probe a flaky endpoint without the client's retries and backoff. A failed attempt
+// is still reported as a *RetryError, with Retries set to 1.
+func WithoutRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRetryKey{}, true)
+}
+
+// RetryDisabled reports whether ctx was marked by WithoutRetry.
+func RetryDisabled(ctx context.Context) bool {
+	disabled, _ := ctx.Value(noRetryKey{}).(bool)
+	return disabled
+}
@@ -0,0 +1,53 @@
+package client
+
+import "context"
+
+// PaginationMeta describes the position of a Page within an offset-paginated listing.
+type PaginationMeta struct {
+	// Limit is the number of items requested for the page.
+	Limit int `json:"limit"`
+	// Offset is the offset of the first item of the page.
+	Offset int `json:"offset"`
+	// Count is the number of items in the page.
+	Count int `json:"count"`
+	// Total is the total number of items, or 0 when the API does not report it.
+	Total int `json:"total"`
+}
+
+// PageFetcher fetches up to limit items starting at offset.
+type PageFetcher[T any] func(ctx context.Context, offset, limit int) (*Page[T], error)
+
+// Page is one page of a listing. It keeps the fetch used to load it, so Next can load
+// the following page without the caller tracking offsets.
+type Page[T any] struct {
+	Items []T
+	Meta  PaginationMeta
+
+	fetch PageFetcher[T]
+}
+
+// NewPage returns a page holding items. fetch loads further pages; a nil fetch makes
+// the page the last one.
+func NewPage[T any](items []T, meta PaginationMeta, fetch PageFetcher[T]) *Page[T] {
+	return &Page[T]{Items: items, Meta: meta, fetch: fetch}
+}
+
+// HasNext reports whether another page may follow. When the API reports a total, it is
+// compared against the items seen so far; otherwise a full page means there may be more.
+func (p *Page[T]) HasNext() bool {
+	if p == nil || p.fetch == nil || p.Meta.Count == 0 {
+		return false
+	}
+	if p.Meta.Total > 0 {
+		return p.Meta.Offset+p.Meta.Count < p.Meta.Total
+	}
+	return p.Meta.Count >= p.Meta.Limit
+}
+
+// Next fetches the page following p. It returns nil, nil once the listing is exhausted.
+func (p *Page[T]) Next(ctx context.Context) (*Page[T], error) {
+	if !p.HasNext() {
+		return nil, nil
+	}
+	return p.fetch(ctx, p.Meta.Offset+p.Meta.Count, p.Meta.Limit)
+}
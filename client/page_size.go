@@ -0,0 +1,25 @@
+package client
+
+// WithDefaultPageSize sets the page size list calls use when they do not set a Limit: the
+// single-page List methods send it as their limit, and helpers that walk every page (ListPage,
+// ListIter, ListByTag and the like) fetch pages of this size. An explicit Limit always wins.
+// Zero (the default) leaves single-page calls to the endpoint's own default and walks pages
+// of 50 items. Larger pages mean fewer round trips at the cost of more memory per page.
+func WithDefaultPageSize(n int) Option {
+	return func(c *Config) {
+		c.DefaultPageSize = n
+	}
+}
+
+// PageLimit returns the limit a list call should send: limit when set, otherwise
+// DefaultPageSize when configured, otherwise nil.
+func (c *Config) PageLimit(limit *int) *int {
+	if limit != nil {
+		return limit
+	}
+	if c.DefaultPageSize > 0 {
+		size := c.DefaultPageSize
+		return &size
+	}
+	return nil
+}
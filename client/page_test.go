@@ -0,0 +1,102 @@
+package client
+
+import (
+	"context"
+	"testing"
+)
+
+func TestPage_HasNext(t *testing.T) {
+	fetch := func(ctx context.Context, offset, limit int) (*Page[int], error) { return nil, nil }
+
+	tests := []struct {
+		name string
+		page *Page[int]
+		want bool
+	}{
+		{
+			name: "full page without total",
+			page: NewPage([]int{1, 2}, PaginationMeta{Limit: 2, Count: 2}, fetch),
+			want: true,
+		},
+		{
+			name: "short page without total",
+			page: NewPage([]int{1}, PaginationMeta{Limit: 2, Count: 1}, fetch),
+			want: false,
+		},
+		{
+			name: "empty page",
+			page: NewPage([]int{}, PaginationMeta{Limit: 2}, fetch),
+			want: false,
+		},
+		{
+			name: "full page reaching total",
+			page: NewPage([]int{3, 4}, PaginationMeta{Limit: 2, Offset: 2, Count: 2, Total: 4}, fetch),
+			want: false,
+		},
+		{
+			name: "page before total",
+			page: NewPage([]int{1}, PaginationMeta{Limit: 2, Count: 1, Total: 4}, fetch),
+			want: true,
+		},
+		{
+			name: "no fetch",
+			page: NewPage([]int{1, 2}, PaginationMeta{Limit: 2, Count: 2}, nil),
+			want: false,
+		},
+		{
+			name: "nil page",
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.page.HasNext(); got != tt.want {
+				t.Errorf("HasNext() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPage_Next(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+	var fetch PageFetcher[int]
+	fetch = func(ctx context.Context, offset, limit int) (*Page[int], error) {
+		end := min(offset+limit, len(items))
+		page := items[offset:end]
+		return NewPage(page, PaginationMeta{Limit: limit, Offset: offset, Count: len(page)}, fetch), nil
+	}
+
+	page, _ := fetch(context.Background(), 0, 2)
+	var got []int
+	var calls int
+	for page != nil {
+		calls++
+		got = append(got, page.Items...)
+		var err error
+		page, err = page.Next(context.Background())
+		if err != nil {
+			t.Fatalf("Next() unexpected error: %v", err)
+		}
+	}
+
+	if len(got) != len(items) || calls != 3 {
+		t.Errorf("expected %v over 3 pages, got %v over %d", items, got, calls)
+	}
+}
+
+func TestConfig_PageLimit(t *testing.T) {
+	explicit := 10
+
+	if got := NewMgcClient("key").GetConfig().PageLimit(nil); got != nil {
+		t.Errorf("expected no limit without a default page size, got %d", *got)
+	}
+
+	cfg := NewMgcClient("key", WithDefaultPageSize(200)).GetConfig()
+	if got := cfg.PageLimit(nil); got == nil || *got != 200 {
+		t.Errorf("expected the default page size 200, got %v", got)
+	}
+	if got := cfg.PageLimit(&explicit); got != &explicit {
+		t.Errorf("expected the explicit limit to win, got %v", got)
+	}
+}
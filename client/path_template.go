@@ -0,0 +1,47 @@
+package client
+
+import (
+	"context"
+	"time"
+)
+
+// pathTemplateKey is the context key holding the path template of a request.
+type pathTemplateKey struct{}
+
+// WithPathTemplate returns a context whose requests report template (e.g.
+// "/compute/v1/snapshots/{id}") as their path template. Service clients set it for the
+// requests they build; set it yourself for calls made through client/raw.
+func WithPathTemplate(ctx context.Context, template string) context.Context {
+	return context.WithValue(ctx, pathTemplateKey{}, template)
+}
+
+// PathTemplate returns the path template stored in ctx by WithPathTemplate, or "".
+func PathTemplate(ctx context.Context) string {
+	template, _ := ctx.Value(pathTemplateKey{}).(string)
+	return template
+}
+
+// RequestInfo describes one HTTP attempt made by the client. Use PathTemplate rather than
+// URL as a metrics label, since URL contains resource IDs.
+type RequestInfo struct {
+	Method string
+	URL    string
+	// PathTemplate is the path with resource IDs left as placeholders, or "" when the
+	// request was not built from a template.
+	PathTemplate string
+	// Attempt is 1 for the first try and grows with each retry.
+	Attempt int
+	// StatusCode is zero when no response was received.
+	StatusCode int
+	Duration   time.Duration
+	// Err is the transport error, nil whenever a response was received.
+	Err error
+}
+
+// WithRequestHook registers a hook called after every HTTP attempt, including retries.
+// The hook runs synchronously in the request goroutine and should not block.
+func WithRequestHook(hook func(RequestInfo)) Option {
+	return func(c *Config) {
+		c.OnRequest = hook
+	}
+}
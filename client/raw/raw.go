@@ -0,0 +1,41 @@
+// Package raw provides an escape hatch for calling MagaluCloud API endpoints that the SDK
+// does not model yet.
+//
+// Requests made through this package go through the same plumbing as the typed services:
+// authentication headers, custom headers, request IDs, timeouts, retries and error typing
+// (*client.HTTPError, *client.RetryError). The function signatures in this package are stable,
+// but the endpoint paths you pass are not validated by the SDK and may change on the API side
+// without notice. Prefer the typed services whenever an operation is available there.
+package raw
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+	mgc_http "github.com/MagaluCloud/mgc-sdk-go/internal/http"
+)
+
+// Do sends a request to path, relative to the client's base URL, and decodes the response into T.
+// The path must include the product prefix (e.g. "/compute/v1/instances").
+// body is JSON-encoded when non-nil and query is appended to the URL when non-nil.
+// A response without content (204) yields a nil result and a nil error.
+func Do[T any](ctx context.Context, core *client.CoreClient, method, path string, body any, query url.Values) (*T, error) {
+	return mgc_http.ExecuteSimpleRequestWithRespBody[T](ctx, newRequestFunc(core), core.GetConfig(), method, path, body, query)
+}
+
+// DoNoContent sends a request to path like Do but discards any response body.
+func DoNoContent(ctx context.Context, core *client.CoreClient, method, path string, body any, query url.Values) error {
+	return mgc_http.ExecuteSimpleRequest(ctx, newRequestFunc(core), core.GetConfig(), method, path, body, query)
+}
+
+// newRequestFunc adapts the core client configuration to the internal request builder
+func newRequestFunc(core *client.CoreClient) mgc_http.NewRequestFunc {
+	return func(ctx context.Context, method, path string, body any) (*http.Request, error) {
+		if body == nil {
+			return mgc_http.NewRequest[any](core.GetConfig(), ctx, method, path, nil)
+		}
+		return mgc_http.NewRequest(core.GetConfig(), ctx, method, path, &body)
+	}
+}
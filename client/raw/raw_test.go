@@ -0,0 +1,145 @@
+package raw
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+)
+
+type widget struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func testCore(baseURL string) *client.CoreClient {
+	return client.NewMgcClient("test-api-key",
+		client.WithBaseURL(client.MgcUrl(baseURL)),
+		client.WithRetryConfig(2, time.Millisecond, time.Millisecond, 1))
+}
+
+func TestDo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/product/v1/widgets" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected method %s", r.Method)
+		}
+		if r.Header.Get("X-API-Key") != "test-api-key" {
+			t.Error("expected X-API-Key header")
+		}
+		if r.URL.Query().Get("dry_run") != "true" {
+			t.Error("expected dry_run query parameter")
+		}
+
+		var in widget
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			t.Errorf("failed to decode body: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(widget{ID: "w-1", Name: in.Name})
+	}))
+	defer server.Close()
+
+	got, err := Do[widget](context.Background(), testCore(server.URL), http.MethodPost,
+		"/product/v1/widgets", widget{Name: "gear"}, url.Values{"dry_run": {"true"}})
+	if err != nil {
+		t.Fatalf("Do() unexpected error: %v", err)
+	}
+	if got.ID != "w-1" || got.Name != "gear" {
+		t.Errorf("Do() got = %+v", got)
+	}
+}
+
+func TestDo_TypedErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		check      func(error) bool
+	}{
+		{
+			name:       "client error is an HTTPError",
+			statusCode: http.StatusNotFound,
+			check: func(err error) bool {
+				var httpErr *client.HTTPError
+				return errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound
+			},
+		},
+		{
+			name:       "server error exhausts retries",
+			statusCode: http.StatusServiceUnavailable,
+			check: func(err error) bool {
+				var retryErr *client.RetryError
+				return errors.As(err, &retryErr)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer server.Close()
+
+			_, err := Do[widget](context.Background(), testCore(server.URL), http.MethodGet, "/product/v1/widgets/w-1", nil, nil)
+			if !tt.check(err) {
+				t.Errorf("Do() unexpected error type: %T %v", err, err)
+			}
+		})
+	}
+}
+
+func TestDoNoContent(t *testing.T) {
+	var called bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		if r.Method != http.MethodDelete || r.URL.Path != "/product/v1/widgets/w-1" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	if err := DoNoContent(context.Background(), testCore(server.URL), http.MethodDelete, "/product/v1/widgets/w-1", nil, nil); err != nil {
+		t.Fatalf("DoNoContent() unexpected error: %v", err)
+	}
+	if !called {
+		t.Error("expected request to be sent")
+	}
+}
+
+func TestDo_FormBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/x-www-form-urlencoded" {
+			t.Errorf("unexpected Content-Type %q", ct)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("ParseForm() error: %v", err)
+		}
+		if r.PostForm.Get("grant_type") != "client_credentials" || r.PostForm.Get("scope") != "a b" {
+			t.Errorf("unexpected form %v", r.PostForm)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": "token-1"}`))
+	}))
+	defer server.Close()
+
+	form := client.FormBody{"grant_type": {"client_credentials"}, "scope": {"a b"}}
+	got, err := Do[widget](context.Background(), testCore(server.URL), http.MethodPost, "/oauth/token", form, nil)
+	if err != nil {
+		t.Fatalf("Do() unexpected error: %v", err)
+	}
+	if got.ID != "token-1" {
+		t.Errorf("unexpected response %+v", got)
+	}
+}
@@ -0,0 +1,20 @@
+package client
+
+import "context"
+
+// requestBaseURLKey is the context key holding the base URL override of a request.
+type requestBaseURLKey struct{}
+
+// WithRequestBaseURL returns a context whose requests go to baseURL instead of the client's
+// base URL, e.g. to reach another region or a fixture server for a single call without
+// building a new client. The service path (such as /compute) is still appended. baseURL must
+// be an absolute http or https URL; otherwise requests fail with a *ValidationError.
+func WithRequestBaseURL(ctx context.Context, baseURL MgcUrl) context.Context {
+	return context.WithValue(ctx, requestBaseURLKey{}, baseURL)
+}
+
+// RequestBaseURL returns the base URL stored in ctx by WithRequestBaseURL, or "".
+func RequestBaseURL(ctx context.Context) MgcUrl {
+	baseURL, _ := ctx.Value(requestBaseURLKey{}).(MgcUrl)
+	return baseURL
+}
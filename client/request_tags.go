@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"strings"
+)
+
+// requestTagsKey is the context key holding request tags.
+type requestTagsKey struct{}
+
+// reservedTagHeaders are set by the SDK itself and cannot be overridden by request tags.
+var reservedTagHeaders = []string{"X-Api-Key", "Authorization", "User-Agent", "Content-Type", "Content-Encoding", "X-Request-Id", "Idempotency-Key"}
+
+// WithRequestTags returns a context whose requests carry tags as extra HTTP headers,
+// e.g. {"X-Cost-Center": "team-a"} for usage attribution. Tags already in ctx are kept
+// unless overridden. Keys must be valid header names and values must not contain control
+// characters; invalid tags make requests fail with a *ValidationError.
+func WithRequestTags(ctx context.Context, tags map[string]string) context.Context {
+	merged := make(map[string]string, len(tags))
+	maps.Copy(merged, RequestTags(ctx))
+	maps.Copy(merged, tags)
+	return context.WithValue(ctx, requestTagsKey{}, merged)
+}
+
+// RequestTags returns the request tags stored in ctx by WithRequestTags.
+func RequestTags(ctx context.Context) map[string]string {
+	tags, _ := ctx.Value(requestTagsKey{}).(map[string]string)
+	return tags
+}
+
+// ValidateRequestTag checks that a request tag can be sent as an HTTP header.
+func ValidateRequestTag(key, value string) error {
+	if key == "" {
+		return &ValidationError{Field: "requestTags", Message: "header name cannot be empty"}
+	}
+	for _, r := range key {
+		if !isTokenChar(r) {
+			return &ValidationError{Field: "requestTags", Message: fmt.Sprintf("invalid header name %q", key)}
+		}
+	}
+	for _, reserved := range reservedTagHeaders {
+		if strings.EqualFold(http.CanonicalHeaderKey(key), reserved) {
+			return &ValidationError{Field: "requestTags", Message: fmt.Sprintf("header %q is reserved", key)}
+		}
+	}
+	for _, r := range value {
+		if (r < ' ' && r != '\t') || r == 0x7f {
+			return &ValidationError{Field: "requestTags", Message: fmt.Sprintf("invalid value for header %q", key)}
+		}
+	}
+	return nil
+}
+
+// isTokenChar reports whether r is allowed in an HTTP header name (RFC 7230 token)
+func isTokenChar(r rune) bool {
+	if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+		return true
+	}
+	return strings.ContainsRune("!#$%&'*+-.^_`|~", r)
+}
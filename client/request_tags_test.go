@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestWithRequestTags(t *testing.T) {
+	ctx := WithRequestTags(context.Background(), map[string]string{"X-Cost-Center": "team-a", "X-Tenant": "t1"})
+	ctx = WithRequestTags(ctx, map[string]string{"X-Tenant": "t2"})
+
+	tags := RequestTags(ctx)
+	if tags["X-Cost-Center"] != "team-a" || tags["X-Tenant"] != "t2" || len(tags) != 2 {
+		t.Errorf("unexpected tags %v", tags)
+	}
+
+	if RequestTags(context.Background()) != nil {
+		t.Error("expected no tags in an empty context")
+	}
+}
+
+func TestValidateRequestTag(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		value   string
+		wantErr bool
+	}{
+		{name: "valid", key: "X-Cost-Center", value: "team a"},
+		{name: "empty key", key: "", value: "v", wantErr: true},
+		{name: "space in key", key: "X Cost", value: "v", wantErr: true},
+		{name: "newline in value", key: "X-Tenant", value: "a\r\nX-Injected: 1", wantErr: true},
+		{name: "reserved header", key: "x-api-key", value: "other", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRequestTag(tt.key, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateRequestTag() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var validationErr *ValidationError
+			if err != nil && !errors.As(err, &validationErr) {
+				t.Errorf("expected *ValidationError, got %T", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package client
+
+import "github.com/MagaluCloud/mgc-sdk-go/helpers"
+
+// RetryRule controls how responses with one HTTP status code are retried.
+type RetryRule struct {
+	// MaxAttempts is the total number of attempts, including the first, after which a request
+	// answered with this status gives up. 1 or less never retries the status.
+	MaxAttempts int
+	// Backoff spaces the retries. A Backoff with no Initial delay uses RetryConfig's intervals.
+	Backoff helpers.Backoff
+	// RequireRetryAfter retries the status only when the response carries a Retry-After header.
+	RequireRetryAfter bool
+}
+
+// RetryPolicy maps HTTP status codes to the rule used to retry them.
+//
+// Statuses without a rule fall back to RetryConfig: 5xx and 429 responses and network errors
+// are retried up to RetryConfig.MaxAttempts, other statuses fail at once, and Retry-After is
+// ignored. For statuses with a rule, a Retry-After header takes precedence over Backoff: the
+// retry waits as long as the server asked, even beyond Backoff.Max, so bound the request
+// with a context deadline if that matters.
+//
+// Attempts are counted per request, so a request answered with 503 then 429 has made two
+// attempts when the 429 rule is consulted. WithoutRetry still limits a request to one attempt.
+type RetryPolicy map[int]RetryRule
+
+// WithRetryPolicy sets per-status retry rules that take precedence over RetryConfig.
+//
+// Example:
+//
+//	client.WithRetryPolicy(client.RetryPolicy{
+//	    http.StatusServiceUnavailable: {MaxAttempts: 6, Backoff: helpers.Backoff{Initial: 200 * time.Millisecond, Factor: 2}},
+//	    http.StatusTooManyRequests:    {MaxAttempts: 3, RequireRetryAfter: true},
+//	})
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Config) {
+		c.RetryPolicy = policy
+	}
+}
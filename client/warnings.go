@@ -0,0 +1,36 @@
+package client
+
+import "time"
+
+// Warning is a non-fatal notice returned by the API, such as a deprecated field or a
+// quota close to its limit. Warnings come from Warning response headers or a "warnings"
+// field in JSON response bodies, and from the Deprecation and Sunset headers of
+// endpoints that are being phased out.
+type Warning struct {
+	// Code is the warn-code of a Warning header (e.g. 299). It is zero for body warnings.
+	Code int
+	// Agent is the warn-agent of a Warning header, "-" when unknown. It is empty for body warnings.
+	Agent string
+	// Text is the warning message.
+	Text string
+	// Method and URL identify the request that produced the warning.
+	Method string
+	URL    string
+	// PathTemplate is the request's path template, see RequestInfo.
+	PathTemplate string
+	// Deprecated is set when the response carried a Deprecation or Sunset header.
+	Deprecated bool
+	// DeprecatedAt is the date of the Deprecation header, zero when the header has no date.
+	DeprecatedAt time.Time
+	// Sunset is the date of the Sunset header after which the endpoint may stop responding,
+	// zero when absent.
+	Sunset time.Time
+}
+
+// WithWarningHandler registers a hook called for every warning returned by the API.
+// The hook runs synchronously in the request goroutine and should not block.
+func WithWarningHandler(handler func(Warning)) Option {
+	return func(c *Config) {
+		c.OnWarning = handler
+	}
+}
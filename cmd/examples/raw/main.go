@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+	"github.com/MagaluCloud/mgc-sdk-go/client/raw"
+)
+
+// quota models only the fields this example needs from an endpoint the SDK does not wrap yet
+type quota struct {
+	Name  string `json:"name"`
+	Used  int    `json:"used"`
+	Limit int    `json:"limit"`
+}
+
+func main() {
+	ExampleCallUnmodeledEndpoint()
+}
+
+func ExampleCallUnmodeledEndpoint() {
+	apiToken := os.Getenv("MGC_API_TOKEN")
+	if apiToken == "" {
+		log.Fatal("MGC_API_TOKEN environment variable is not set")
+	}
+	c := client.NewMgcClient(apiToken)
+
+	query := url.Values{}
+	query.Set("_limit", "10")
+
+	result, err := raw.Do[struct {
+		Results []quota `json:"results"`
+	}](context.Background(), c, http.MethodGet, "/compute/v1/quotas", nil, query)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, q := range result.Results {
+		fmt.Printf("%s: %d/%d\n", q.Name, q.Used, q.Limit)
+	}
+}
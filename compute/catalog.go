@@ -0,0 +1,97 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+	"github.com/MagaluCloud/mgc-sdk-go/internal/cache"
+)
+
+// DefaultCatalogTTL is how long Catalog keeps a region's images and machine types when no TTL is given.
+const DefaultCatalogTTL = 10 * time.Minute
+
+// Catalog caches the images and machine types of each region, so validating many
+// resources does not list them every time. It is safe for concurrent use.
+type Catalog struct {
+	clients      map[string]*VirtualMachineClient
+	images       *cache.TTL[string, []Image]
+	machineTypes *cache.TTL[string, []InstanceType]
+}
+
+// NewCatalog returns a catalog reading from clients, keyed by region name
+// (e.g. "br-se1" mapped to compute.New(regionCore)). Entries expire after ttl,
+// or DefaultCatalogTTL when ttl is not positive.
+func NewCatalog(ttl time.Duration, clients map[string]*VirtualMachineClient) *Catalog {
+	if ttl <= 0 {
+		ttl = DefaultCatalogTTL
+	}
+	return &Catalog{
+		clients:      clients,
+		images:       cache.New[string, []Image](ttl),
+		machineTypes: cache.New[string, []InstanceType](ttl),
+	}
+}
+
+// Images returns the images available in region, from the cache when fresh.
+func (c *Catalog) Images(ctx context.Context, region string) ([]Image, error) {
+	vm, err := c.client(region)
+	if err != nil {
+		return nil, err
+	}
+
+	images, err := c.images.Get(ctx, region, func(ctx context.Context) ([]Image, error) {
+		images, err := vm.Images().List(ctx, ImageListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("list images in %s: %w", region, err)
+		}
+		return images, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return slices.Clone(images), nil
+}
+
+// MachineTypes returns the machine types available in region, from the cache when fresh.
+func (c *Catalog) MachineTypes(ctx context.Context, region string) ([]InstanceType, error) {
+	vm, err := c.client(region)
+	if err != nil {
+		return nil, err
+	}
+
+	machineTypes, err := c.machineTypes.Get(ctx, region, func(ctx context.Context) ([]InstanceType, error) {
+		machineTypes, err := vm.InstanceTypes().List(ctx, InstanceTypeListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("list machine types in %s: %w", region, err)
+		}
+		return machineTypes, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return slices.Clone(machineTypes), nil
+}
+
+// Invalidate drops the cached images and machine types of region.
+func (c *Catalog) Invalidate(region string) {
+	c.images.Invalidate(region)
+	c.machineTypes.Invalidate(region)
+}
+
+// InvalidateAll drops the cached images and machine types of every region.
+func (c *Catalog) InvalidateAll() {
+	c.images.InvalidateAll()
+	c.machineTypes.InvalidateAll()
+}
+
+// client returns the compute client registered for region.
+func (c *Catalog) client(region string) (*VirtualMachineClient, error) {
+	vm, ok := c.clients[region]
+	if !ok {
+		return nil, &client.ValidationError{Field: "region", Message: fmt.Sprintf("no compute client for region %q", region)}
+	}
+	return vm, nil
+}
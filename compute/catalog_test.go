@@ -0,0 +1,63 @@
+package compute
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+	"github.com/MagaluCloud/mgc-sdk-go/internal/utils"
+)
+
+func TestCatalog(t *testing.T) {
+	calls := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls[r.URL.Path]++
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/compute/v1/images":
+			w.Write([]byte(`{"images": [{"id": "img-1", "name": "ubuntu-24.04"}]}`))
+		case "/compute/v1/instance-types":
+			w.Write([]byte(`{"instance_types": [{"id": "mt-1", "name": "BV1-1-10"}]}`))
+		}
+	}))
+	defer server.Close()
+
+	catalog := NewCatalog(time.Minute, map[string]*VirtualMachineClient{"br-se1": testClient(server.URL)})
+	clock := utils.NewFakeClock(time.Now())
+	ctx := utils.WithClock(context.Background(), clock)
+
+	for range 2 {
+		images, err := catalog.Images(ctx, "br-se1")
+		if err != nil || len(images) != 1 || images[0].Name != "ubuntu-24.04" {
+			t.Fatalf("Images() = %+v, %v", images, err)
+		}
+		machineTypes, err := catalog.MachineTypes(ctx, "br-se1")
+		if err != nil || len(machineTypes) != 1 || machineTypes[0].Name != "BV1-1-10" {
+			t.Fatalf("MachineTypes() = %+v, %v", machineTypes, err)
+		}
+	}
+	if calls["/compute/v1/images"] != 1 || calls["/compute/v1/instance-types"] != 1 {
+		t.Errorf("expected cache hits after the first call, got %v", calls)
+	}
+
+	clock.Advance(time.Minute)
+	catalog.Images(ctx, "br-se1")
+	if calls["/compute/v1/images"] != 2 {
+		t.Errorf("expected expired images to be reloaded, got %v", calls)
+	}
+
+	catalog.Invalidate("br-se1")
+	catalog.MachineTypes(ctx, "br-se1")
+	if calls["/compute/v1/instance-types"] != 2 {
+		t.Errorf("expected invalidated machine types to be reloaded, got %v", calls)
+	}
+
+	var validationErr *client.ValidationError
+	if _, err := catalog.Images(ctx, "br-ne1"); !errors.As(err, &validationErr) {
+		t.Errorf("Images() error = %v, want ValidationError for unknown region", err)
+	}
+}
@@ -18,6 +18,7 @@ const (
 // It encapsulates functionality to access instances, images, instance types, and snapshots.
 type VirtualMachineClient struct {
 	*client.CoreClient
+	snapshotCreates *instanceLocks
 }
 
 // ClientOption allows customizing the virtual machine client configuration.
@@ -9,8 +9,10 @@ import (
 	"time"
 
 	"github.com/MagaluCloud/mgc-sdk-go/client"
+	"github.com/MagaluCloud/mgc-sdk-go/helpers"
 
 	mgc_http "github.com/MagaluCloud/mgc-sdk-go/internal/http"
+	"github.com/MagaluCloud/mgc-sdk-go/internal/utils"
 )
 
 // Constants for expanding related resources in instance responses.
@@ -102,10 +104,16 @@ type CreateParametersNetworkInterfaceSecurityGroupsItem struct {
 }
 
 // IDOrName represents a resource that can be identified by ID or name.
-type IDOrName struct {
-	ID   *string `json:"id,omitempty,omitzero"`
-	Name *string `json:"name,omitempty,omitzero"`
-}
+type IDOrName = helpers.IDOrName
+
+// ParseIDOrName is helpers.ParseIDOrName: UUIDs become IDs and everything else names.
+func ParseIDOrName(s string) IDOrName { return helpers.ParseIDOrName(s) }
+
+// ForceID is helpers.ForceID.
+func ForceID(s string) IDOrName { return helpers.ForceID(s) }
+
+// ForceName is helpers.ForceName.
+func ForceName(s string) IDOrName { return helpers.ForceName(s) }
 
 // UpdateNameRequest represents the request to update an instance name.
 type UpdateNameRequest struct {
@@ -197,6 +205,9 @@ type ListOptions struct {
 	Sort   *string
 	Expand []string
 	Name   *string
+	// Fields requests a partial response with only these JSON fields of each instance or
+	// snapshot, leaving the others zero. "id" is always included.
+	Fields []string
 }
 
 // List retrieves all instances.
@@ -227,6 +238,9 @@ func (s *instanceService) List(ctx context.Context, opts ListOptions) ([]Instanc
 	if opts.Name != nil {
 		q.Add("name", *opts.Name)
 	}
+	if fields := utils.FieldsQuery(opts.Fields, "id"); fields != "" {
+		q.Add(utils.FieldsQueryParam, fields)
+	}
 
 	req.URL.RawQuery = q.Encode()
 
@@ -311,7 +325,7 @@ func (s *instanceService) Delete(ctx context.Context, id string, deletePublicIP
 // Returns an error if the operation fails or if the ID is empty.
 func (s *instanceService) Rename(ctx context.Context, id string, newName string) error {
 	if id == "" {
-		return &client.ValidationError{Field: "id", Message: "cannot be empty"}
+		return &client.ValidationError{Field: "id", Message: utils.CannotBeEmpty}
 	}
 	path := fmt.Sprintf("/v1/instances/%s/rename", id)
 	return mgc_http.ExecuteSimpleRequest(
@@ -330,7 +344,7 @@ func (s *instanceService) Rename(ctx context.Context, id string, newName string)
 // The instance must be in a stopped state for this operation to succeed.
 func (s *instanceService) Retype(ctx context.Context, id string, retypeReq RetypeRequest) error {
 	if id == "" {
-		return &client.ValidationError{Field: "id", Message: "cannot be empty"}
+		return &client.ValidationError{Field: "id", Message: utils.CannotBeEmpty}
 	}
 	path := fmt.Sprintf("/v1/instances/%s/retype", id)
 	return mgc_http.ExecuteSimpleRequest(
@@ -370,7 +384,7 @@ func (s *instanceService) Suspend(ctx context.Context, id string) error {
 // This is an internal method that should not be called directly by SDK users.
 func (s *instanceService) executeInstanceAction(ctx context.Context, id string, action string) error {
 	if id == "" {
-		return &client.ValidationError{Field: "id", Message: "cannot be empty"}
+		return &client.ValidationError{Field: "id", Message: utils.CannotBeEmpty}
 	}
 	path := fmt.Sprintf("/v1/instances/%s/%s", id, action)
 	return mgc_http.ExecuteSimpleRequest(
@@ -389,7 +403,7 @@ func (s *instanceService) executeInstanceAction(ctx context.Context, id string,
 // that were created with Windows images.
 func (s *instanceService) GetFirstWindowsPassword(ctx context.Context, id string) (*WindowsPasswordResponse, error) {
 	if id == "" {
-		return nil, &client.ValidationError{Field: "id", Message: "cannot be empty"}
+		return nil, &client.ValidationError{Field: "id", Message: utils.CannotBeEmpty}
 	}
 	path := fmt.Sprintf("/v1/instances/config/%s/first-windows-password", id)
 	result, err := mgc_http.ExecuteSimpleRequestWithRespBody[WindowsPasswordResponse](
@@ -439,7 +453,7 @@ func (s *instanceService) DetachNetworkInterface(ctx context.Context, req NICReq
 // This method makes an HTTP request to get the initialization logs for an instance.
 func (s *instanceService) InitLog(ctx context.Context, id string, maxLines *int) (*InitLogResponse, error) {
 	if id == "" {
-		return nil, &client.ValidationError{Field: "id", Message: "cannot be empty"}
+		return nil, &client.ValidationError{Field: "id", Message: utils.CannotBeEmpty}
 	}
 
 	req, err := s.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("/v1/instances/%s/init-logs", id), nil)
@@ -2,6 +2,7 @@ package compute
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -11,6 +12,7 @@ import (
 	"time"
 
 	"github.com/MagaluCloud/mgc-sdk-go/client"
+	"github.com/MagaluCloud/mgc-sdk-go/helpers"
 )
 
 func TestInstanceService_List(t *testing.T) {
@@ -771,6 +773,25 @@ func TestInstanceService_ListWithExpand(t *testing.T) {
 	}
 }
 
+func TestInstanceService_List_Fields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("_fields"); got != "id,name" {
+			t.Errorf("expected _fields=id,name, got %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"instances": [{"id": "inst1", "name": "test1"}]}`))
+	}))
+	defer server.Close()
+
+	instances, err := testClient(server.URL).Instances().List(context.Background(), ListOptions{Fields: []string{"name"}})
+	if err != nil {
+		t.Fatalf("List() unexpected error: %v", err)
+	}
+	if len(instances) != 1 || instances[0].ID != "inst1" || instances[0].Name == nil || *instances[0].Name != "test1" {
+		t.Errorf("unexpected instances %+v", instances)
+	}
+}
+
 func TestInstanceService_GetWithExpand(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -1271,3 +1292,30 @@ func TestInstanceService_InitLog(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateParametersNetworkInterface_SecurityGroups(t *testing.T) {
+	tests := []struct {
+		name string
+		nic  CreateParametersNetworkInterface
+		want string
+	}{
+		{name: "nil is omitted", want: `{}`},
+		{
+			name: "empty is sent as []",
+			nic:  CreateParametersNetworkInterface{SecurityGroups: helpers.SlicePtr[CreateParametersNetworkInterfaceSecurityGroupsItem](nil)},
+			want: `{"security_groups":[]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.nic)
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("got %s, want %s", data, tt.want)
+			}
+		})
+	}
+}
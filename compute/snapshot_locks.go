@@ -0,0 +1,86 @@
+package compute
+
+import (
+	"context"
+	"sync"
+)
+
+// WithSerializedSnapshotCreates makes SnapshotService.Create send at most one create request
+// at a time per instance, across every goroutine sharing the client, while creates for
+// different instances still run in parallel. It guards backup fan-outs that would otherwise
+// fire several snapshot creates at one instance. Callers wait for their turn or for their
+// context to be done.
+//
+// Instances are told apart by the ID or name in CreateSnapshotRequest.Instance as given, so
+// requests naming one instance by ID and another by name are not serialized. Only the create
+// request is serialized, not the snapshot's completion, which CreateAndWait waits for after
+// releasing the instance.
+//
+// Example:
+//
+//	vmClient := compute.New(core, compute.WithSerializedSnapshotCreates())
+func WithSerializedSnapshotCreates() ClientOption {
+	return func(c *VirtualMachineClient) {
+		c.snapshotCreates = &instanceLocks{}
+	}
+}
+
+// instanceLocks is a set of mutexes keyed by instance that honour context cancellation.
+// Entries are removed once nobody holds or waits for them.
+type instanceLocks struct {
+	mu    sync.Mutex
+	locks map[string]*instanceLock
+}
+
+type instanceLock struct {
+	held chan struct{}
+	refs int
+}
+
+// lock waits until key is free or ctx is done, and returns the function that frees it.
+func (l *instanceLocks) lock(ctx context.Context, key string) (func(), error) {
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = make(map[string]*instanceLock)
+	}
+	entry, ok := l.locks[key]
+	if !ok {
+		entry = &instanceLock{held: make(chan struct{}, 1)}
+		l.locks[key] = entry
+	}
+	entry.refs++
+	l.mu.Unlock()
+
+	select {
+	case entry.held <- struct{}{}:
+		return func() {
+			<-entry.held
+			l.release(key, entry)
+		}, nil
+	case <-ctx.Done():
+		l.release(key, entry)
+		return nil, ctx.Err()
+	}
+}
+
+// release drops a reference to entry, forgetting it when it was the last one.
+func (l *instanceLocks) release(key string, entry *instanceLock) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entry.refs--
+	if entry.refs == 0 {
+		delete(l.locks, key)
+	}
+}
+
+// snapshotCreateKey identifies the instance of a snapshot create request.
+func snapshotCreateKey(instance IDOrName) string {
+	switch {
+	case instance.ID != nil:
+		return "id:" + *instance.ID
+	case instance.Name != nil:
+		return "name:" + *instance.Name
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,92 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+)
+
+// NameLookup returns the display name of the resource with the given ID, or "" when unknown.
+type NameLookup func(ctx context.Context, id string) (string, error)
+
+// SnapshotNameResolver looks up the names of the image and machine type a snapshot's
+// instance used. A nil lookup leaves that field as the API returned it.
+type SnapshotNameResolver struct {
+	Image       NameLookup
+	MachineType NameLookup
+}
+
+// ResolveSnapshotNames fills in the Name of each snapshot's Instance.Image and
+// Instance.MachineType where the API returned only the ID. It is opt-in post-processing
+// for the result of List or Get: each distinct ID is looked up once, and names already
+// present are kept. The names are set on the shared *SnapshotInstance, so a snapshot from Get
+// can be passed as []Snapshot{*snapshot}. A lookup error stops the resolution and is returned.
+func ResolveSnapshotNames(ctx context.Context, snapshots []Snapshot, resolver SnapshotNameResolver) error {
+	images := map[string]string{}
+	machineTypes := map[string]string{}
+
+	for _, snapshot := range snapshots {
+		if snapshot.Instance == nil {
+			continue
+		}
+		if err := resolveName(ctx, snapshot.Instance.Image, resolver.Image, images); err != nil {
+			return fmt.Errorf("resolve image of snapshot %s: %w", snapshot.ID, err)
+		}
+		if err := resolveName(ctx, snapshot.Instance.MachineType, resolver.MachineType, machineTypes); err != nil {
+			return fmt.Errorf("resolve machine type of snapshot %s: %w", snapshot.ID, err)
+		}
+	}
+	return nil
+}
+
+// resolveName sets ref.Name from lookup, remembering results in seen.
+func resolveName(ctx context.Context, ref *IDOrName, lookup NameLookup, seen map[string]string) error {
+	if ref == nil || lookup == nil || ref.ID == nil || *ref.ID == "" || (ref.Name != nil && *ref.Name != "") {
+		return nil
+	}
+
+	name, ok := seen[*ref.ID]
+	if !ok {
+		var err error
+		name, err = lookup(ctx, *ref.ID)
+		if err != nil {
+			return err
+		}
+		seen[*ref.ID] = name
+	}
+
+	if name != "" {
+		ref.Name = &name
+	}
+	return nil
+}
+
+// SnapshotNameResolver returns a resolver that names images and machine types of region
+// from the catalog, so resolving many snapshots costs at most one listing of each.
+func (c *Catalog) SnapshotNameResolver(region string) SnapshotNameResolver {
+	return SnapshotNameResolver{
+		Image: func(ctx context.Context, id string) (string, error) {
+			images, err := c.Images(ctx, region)
+			if err != nil {
+				return "", err
+			}
+			for _, image := range images {
+				if image.ID == id {
+					return image.Name, nil
+				}
+			}
+			return "", nil
+		},
+		MachineType: func(ctx context.Context, id string) (string, error) {
+			machineTypes, err := c.MachineTypes(ctx, region)
+			if err != nil {
+				return "", err
+			}
+			for _, machineType := range machineTypes {
+				if machineType.ID == id {
+					return machineType.Name, nil
+				}
+			}
+			return "", nil
+		},
+	}
+}
@@ -0,0 +1,95 @@
+package compute
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestResolveSnapshotNames(t *testing.T) {
+	lookups := map[string]int{}
+	resolver := SnapshotNameResolver{
+		Image: func(ctx context.Context, id string) (string, error) {
+			lookups[id]++
+			return map[string]string{"img-1": "ubuntu-24.04"}[id], nil
+		},
+		MachineType: func(ctx context.Context, id string) (string, error) {
+			lookups[id]++
+			return map[string]string{"mt-1": "BV1-1-10"}[id], nil
+		},
+	}
+
+	snapshots := []Snapshot{
+		{ID: "s1", Instance: &SnapshotInstance{Image: &IDOrName{ID: strPtr("img-1")}, MachineType: &IDOrName{ID: strPtr("mt-1")}}},
+		{ID: "s2", Instance: &SnapshotInstance{Image: &IDOrName{ID: strPtr("img-1")}}},
+		{ID: "s3", Instance: &SnapshotInstance{Image: &IDOrName{ID: strPtr("img-9")}}},
+		{ID: "s4", Instance: &SnapshotInstance{MachineType: &IDOrName{ID: strPtr("mt-1"), Name: strPtr("kept")}}},
+		{ID: "s5"},
+	}
+
+	if err := ResolveSnapshotNames(context.Background(), snapshots, resolver); err != nil {
+		t.Fatalf("ResolveSnapshotNames() unexpected error: %v", err)
+	}
+
+	if name := snapshots[0].Instance.Image.Name; name == nil || *name != "ubuntu-24.04" {
+		t.Errorf("expected s1 image name ubuntu-24.04, got %v", name)
+	}
+	if name := snapshots[0].Instance.MachineType.Name; name == nil || *name != "BV1-1-10" {
+		t.Errorf("expected s1 machine type name BV1-1-10, got %v", name)
+	}
+	if name := snapshots[1].Instance.Image.Name; name == nil || *name != "ubuntu-24.04" {
+		t.Errorf("expected s2 image name ubuntu-24.04, got %v", name)
+	}
+	if snapshots[2].Instance.Image.Name != nil {
+		t.Errorf("expected unknown image to stay unnamed, got %q", *snapshots[2].Instance.Image.Name)
+	}
+	if name := snapshots[3].Instance.MachineType.Name; *name != "kept" {
+		t.Errorf("expected existing name to be kept, got %q", *name)
+	}
+	if lookups["img-1"] != 1 || lookups["mt-1"] != 1 {
+		t.Errorf("expected each ID to be looked up once, got %v", lookups)
+	}
+
+	wantErr := errors.New("boom")
+	resolver.Image = func(ctx context.Context, id string) (string, error) { return "", wantErr }
+	err := ResolveSnapshotNames(context.Background(), []Snapshot{{ID: "s6", Instance: &SnapshotInstance{Image: &IDOrName{ID: strPtr("img-2")}}}}, resolver)
+	if !errors.Is(err, wantErr) {
+		t.Errorf("expected the lookup error, got %v", err)
+	}
+}
+
+func TestCatalog_SnapshotNameResolver(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/compute/v1/images":
+			w.Write([]byte(`{"images": [{"id": "img-1", "name": "ubuntu-24.04"}]}`))
+		case "/compute/v1/instance-types":
+			w.Write([]byte(`{"instance_types": [{"id": "mt-1", "name": "BV1-1-10"}]}`))
+		case "/compute/v1/snapshots/snap-1":
+			w.Write([]byte(`{"id": "snap-1", "instance": {"id": "vm-1", "image": {"id": "img-1"}, "machine_type": {"id": "mt-1"}}}`))
+		}
+	}))
+	defer server.Close()
+
+	vm := testClient(server.URL)
+	catalog := NewCatalog(time.Minute, map[string]*VirtualMachineClient{"br-se1": vm})
+
+	snapshot, err := vm.Snapshots().Get(context.Background(), "snap-1", []string{"instance"})
+	if err != nil {
+		t.Fatalf("Get() unexpected error: %v", err)
+	}
+	if err := ResolveSnapshotNames(context.Background(), []Snapshot{*snapshot}, catalog.SnapshotNameResolver("br-se1")); err != nil {
+		t.Fatalf("ResolveSnapshotNames() unexpected error: %v", err)
+	}
+
+	if name := snapshot.Instance.Image.Name; name == nil || *name != "ubuntu-24.04" {
+		t.Errorf("expected image name ubuntu-24.04, got %v", name)
+	}
+	if name := snapshot.Instance.MachineType.Name; name == nil || *name != "BV1-1-10" {
+		t.Errorf("expected machine type name BV1-1-10, got %v", name)
+	}
+}
@@ -2,15 +2,43 @@ package compute
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+	"github.com/MagaluCloud/mgc-sdk-go/helpers"
 	mgc_http "github.com/MagaluCloud/mgc-sdk-go/internal/http"
+	"github.com/MagaluCloud/mgc-sdk-go/internal/pagination"
+	"github.com/MagaluCloud/mgc-sdk-go/internal/utils"
 )
 
+// ErrSnapshotNotFound is returned by GetByName when no snapshot has the requested name.
+var ErrSnapshotNotFound = errors.New("snapshot not found")
+
+// ErrSnapshotLocked is returned by Delete when the snapshot carries SnapshotLockLabel or the
+// API refuses to delete it because it is locked.
+var ErrSnapshotLocked = errors.New("snapshot is locked")
+
+// SnapshotLockLabel is the tag that protects a snapshot against deletion. The API has no
+// native snapshot locks, so the guard is client-side: Delete refuses to remove a snapshot
+// carrying this label unless DeleteSnapshotOptions.Force is set. Add or remove the label
+// with UpdateMetadata.
+const SnapshotLockLabel = "locked=true"
+
+// ErrModifiedSince is returned by DeleteIfUnchanged when the snapshot changed after it was seen.
+var ErrModifiedSince = errors.New("snapshot was modified since it was seen")
+
+// ErrSourceInstanceGone is returned by ResolveInstance when the instance a snapshot was taken
+// from no longer exists.
+var ErrSourceInstanceGone = errors.New("source instance of the snapshot no longer exists")
+
 // Constants for expanding related resources in snapshot responses.
 const (
 	// SnapshotImageExpand is used to include image information in snapshot responses
@@ -19,6 +47,18 @@ const (
 	SnapshotMachineTypeExpand = "machine-type"
 )
 
+// Snapshot statuses used when waiting for a snapshot.
+const (
+	// SnapshotStatusCompleted is the status of a snapshot that is ready to be used
+	SnapshotStatusCompleted = "completed"
+	// DefaultSnapshotWaitInterval is the default interval between polls in WaitUntilCompleted
+	DefaultSnapshotWaitInterval = 5 * time.Second
+	// snapshotCleanupTimeout bounds the delete CreateAndWait sends after an abandoned wait
+	snapshotCleanupTimeout = 30 * time.Second
+	// maxConcurrentSnapshotCopies bounds the copy requests CopyToRegions sends at once
+	maxConcurrentSnapshotCopies = 4
+)
+
 // ListSnapshotsResponse represents the response from listing snapshots.
 // This structure encapsulates the API response format for snapshots.
 type ListSnapshotsResponse struct {
@@ -36,6 +76,46 @@ type Snapshot struct {
 	UpdatedAt *time.Time        `json:"updated_at,omitempty"`
 	Size      int               `json:"size"`
 	Instance  *SnapshotInstance `json:"instance"`
+	// Progress is the completion percentage (0-100) reported by the API while the snapshot is being created.
+	// It is nil when the API only reports a coarse status.
+	Progress *int `json:"progress,omitempty"`
+	// Labels are the snapshot's tags, by convention "key=value" strings such as "env=prod".
+	Labels *[]string `json:"labels,omitempty"`
+	// Description is the free-form description set with UpdateMetadata, nil when unset.
+	Description *string `json:"description,omitempty"`
+	// AvailabilityZones are the zones the snapshot can be restored into, nil when the API
+	// does not report them. See AvailableZones.
+	AvailabilityZones *[]string `json:"availability_zones,omitempty"`
+	// RawExtra holds response fields the SDK does not model yet, keyed by their JSON name.
+	RawExtra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler, keeping unknown fields in RawExtra.
+// Timestamps are read as helpers.APITime, so values without a zone are taken as UTC.
+func (s *Snapshot) UnmarshalJSON(data []byte) error {
+	type snapshot Snapshot
+	var decoded struct {
+		snapshot
+		CreatedAt helpers.APITime  `json:"created_at"`
+		UpdatedAt *helpers.APITime `json:"updated_at,omitempty"`
+	}
+	extra, err := utils.UnmarshalWithExtra(data, &decoded)
+	if err != nil {
+		return err
+	}
+	*s = Snapshot(decoded.snapshot)
+	s.CreatedAt = decoded.CreatedAt.Time()
+	if decoded.UpdatedAt != nil {
+		updatedAt := decoded.UpdatedAt.Time()
+		s.UpdatedAt = &updatedAt
+	}
+	s.RawExtra = extra
+	return nil
+}
+
+// IsLocked reports whether the snapshot carries SnapshotLockLabel.
+func (s *Snapshot) IsLocked() bool {
+	return s.Labels != nil && slices.Contains(*s.Labels, SnapshotLockLabel)
 }
 
 // SnapshotInstance represents information about the instance that was snapshotted.
@@ -45,10 +125,52 @@ type SnapshotInstance struct {
 	MachineType *IDOrName `json:"machine_type,omitempty"`
 }
 
+// SnapshotConsistency is the consistency guarantee a snapshot is taken with.
+type SnapshotConsistency string
+
+const (
+	// ConsistencyCrash captures the disks as they are, like after a power loss.
+	// It needs nothing from the guest and is what the API does when no consistency is sent.
+	ConsistencyCrash SnapshotConsistency = "crash"
+	// ConsistencyApplication freezes the guest file systems before capturing the disks,
+	// so application data is flushed. The instance must be running with the guest agent
+	// installed and responsive, otherwise the snapshot fails.
+	ConsistencyApplication SnapshotConsistency = "application"
+)
+
 // CreateSnapshotRequest represents the request to create a new snapshot.
 type CreateSnapshotRequest struct {
 	Name     string   `json:"name"`
 	Instance IDOrName `json:"instance"`
+	// Consistency selects crash- or application-consistent snapshots; empty leaves the API default.
+	Consistency SnapshotConsistency `json:"consistency,omitempty"`
+	// Disks are the IDs of the block storage volumes to capture, for instances with data disks
+	// attached. Empty captures the root disk only, as the API does by default; to capture the
+	// whole instance, list the root disk and every attached volume. The SDK cannot see which
+	// volumes are attached, so a disk not attached to the instance is rejected by the API.
+	Disks []string `json:"disks,omitempty"`
+}
+
+// validate checks the fields the API would otherwise reject after the request is sent.
+func (r CreateSnapshotRequest) validate() error {
+	switch r.Consistency {
+	case "", ConsistencyCrash, ConsistencyApplication:
+	default:
+		return &client.ValidationError{
+			Field:   "consistency",
+			Message: fmt.Sprintf("unknown consistency %q", r.Consistency),
+		}
+	}
+
+	for i, disk := range r.Disks {
+		if disk == "" {
+			return &client.ValidationError{Field: fmt.Sprintf("disks[%d]", i), Message: utils.CannotBeEmpty}
+		}
+		if slices.Index(r.Disks, disk) < i {
+			return &client.ValidationError{Field: fmt.Sprintf("disks[%d]", i), Message: fmt.Sprintf("duplicate disk %q", disk)}
+		}
+	}
+	return nil
 }
 
 // RestoreSnapshotRequest represents the request to restore an instance from a snapshot.
@@ -59,24 +181,126 @@ type RestoreSnapshotRequest struct {
 	AvailabilityZone *string                  `json:"availability_zone,omitempty"`
 	Network          *CreateParametersNetwork `json:"network,omitempty"`
 	UserData         *string                  `json:"user_data,omitempty"`
+	// DiskSize is the root disk size of the restored instance in GB, the unit of Snapshot.Size.
+	// It can grow the disk beyond the snapshot's size but not shrink it; unset keeps the
+	// snapshot's size.
+	DiskSize *int `json:"disk_size,omitempty"`
+}
+
+// RestoreResult describes the instance created by restoring a snapshot.
+type RestoreResult struct {
+	// InstanceID is the ID of the new instance; poll it with Instances().Get to follow provisioning.
+	InstanceID string `json:"id"`
+}
+
+// UpdateSnapshotMetadataRequest changes the metadata of a snapshot with PATCH semantics:
+// only the fields that are set are sent, and the others are left unchanged.
+type UpdateSnapshotMetadataRequest struct {
+	// Name renames the snapshot; it cannot be blank when set.
+	Name *string `json:"name,omitempty"`
+	// Labels replaces the snapshot's tags ("key=value" strings, see Snapshot.Labels).
+	// A pointer to an empty slice clears them, see helpers.SlicePtr.
+	Labels *[]string `json:"labels,omitempty"`
+	// Description replaces the snapshot's description; an empty string clears it.
+	Description *string `json:"description,omitempty"`
+}
+
+// DeleteSnapshotOptions controls DeleteWithOptions.
+type DeleteSnapshotOptions struct {
+	// Force deletes the snapshot even if it carries SnapshotLockLabel.
+	Force bool
 }
 
 // CopySnapshotRequest represents the request to copy a snapshot to another region.
 type CopySnapshotRequest struct {
 	// DestinationRegion is the region where the snapshot should be copied
 	DestinationRegion string `json:"destination_region"`
+	// DestinationProject is the project (tenant) that receives the copy; nil copies within
+	// the snapshot's own project. Cross-project copies, e.g. to keep backups in an isolated
+	// account, require the API key to be allowed to create snapshots in the destination project.
+	DestinationProject *string `json:"destination_project,omitempty"`
+}
+
+// validate checks the fields the API would otherwise reject after the request is sent.
+func (r CopySnapshotRequest) validate() error {
+	if strings.TrimSpace(r.DestinationRegion) == "" {
+		return &client.ValidationError{Field: "destination_region", Message: utils.CannotBeEmpty}
+	}
+	if r.DestinationProject != nil && strings.TrimSpace(*r.DestinationProject) == "" {
+		return &client.ValidationError{Field: "destination_project", Message: utils.CannotBeEmpty}
+	}
+	return nil
+}
+
+// CopyResult is the outcome of copying a snapshot to one region in CopyToRegions.
+type CopyResult struct {
+	Region string
+	// Err is nil when the API accepted the copy request for Region
+	Err error
+}
+
+// SnapshotSizeSummary is the storage used by a set of snapshots, in the unit of Snapshot.Size.
+type SnapshotSizeSummary struct {
+	Total int64
+	Count int
+	// ByInstance holds subtotals keyed by the ID of the snapshotted instance.
+	// Snapshots without instance information are counted under the empty key.
+	ByInstance map[string]int64
+}
+
+// SnapshotWaitOptions configures how WaitUntilCompleted polls a snapshot.
+type SnapshotWaitOptions struct {
+	// Interval is the time between polls. Defaults to DefaultSnapshotWaitInterval.
+	Interval time.Duration
+	// OnProgress, when set, is called with the snapshot returned by every poll,
+	// so callers can report Status and Progress while waiting.
+	OnProgress func(snapshot *Snapshot)
+	// Backoff, when set, spaces the polls instead of the fixed Interval,
+	// e.g. to poll often at first and back off on long-running snapshots.
+	// A non-positive Initial starts from the Interval.
+	Backoff *helpers.Backoff
+}
+
+// SnapshotCreateOptions configures CreateAndWait.
+type SnapshotCreateOptions struct {
+	Wait SnapshotWaitOptions
+	// Timeout bounds the wait for the snapshot to complete. Zero leaves it to ctx.
+	Timeout time.Duration
+	// CleanupOnTimeout deletes the snapshot when the wait times out or ctx is cancelled,
+	// so an abandoned create does not leave a half-made snapshot behind.
+	CleanupOnTimeout bool
 }
 
 // SnapshotService provides operations for managing snapshots.
 // This interface allows creating, listing, retrieving, and managing instance snapshots.
+// The API has no snapshot event subscriptions; use WaitUntilCompleted to follow a snapshot.
 type SnapshotService interface {
 	List(ctx context.Context, opts ListOptions) ([]Snapshot, error)
+	ListPage(ctx context.Context, opts ListOptions) (*client.Page[Snapshot], error)
+	ListByTag(ctx context.Context, key, value string, opts ListOptions) ([]Snapshot, error)
+	TotalSnapshotSize(ctx context.Context, opts ListOptions) (int64, error)
+	SnapshotSizeByInstance(ctx context.Context, opts ListOptions) (*SnapshotSizeSummary, error)
 	Create(ctx context.Context, req CreateSnapshotRequest) (string, error)
+	CreateAndGet(ctx context.Context, req CreateSnapshotRequest, expand []string) (*Snapshot, error)
+	CreateAndWait(ctx context.Context, req CreateSnapshotRequest, opts SnapshotCreateOptions) (*Snapshot, error)
 	Get(ctx context.Context, id string, expand []string) (*Snapshot, error)
+	GetByName(ctx context.Context, name string) (*Snapshot, error)
+	EnsureSnapshot(ctx context.Context, name string, instance IDOrName) (*Snapshot, error)
 	Delete(ctx context.Context, id string) error
+	DeleteWithOptions(ctx context.Context, id string, opts DeleteSnapshotOptions) error
+	DeleteIfUnchanged(ctx context.Context, id string, seen time.Time) error
 	Rename(ctx context.Context, id string, newName string) error
+	UpdateMetadata(ctx context.Context, id string, req UpdateSnapshotMetadataRequest) error
 	Restore(ctx context.Context, id string, req RestoreSnapshotRequest) (string, error)
+	RestoreWithResult(ctx context.Context, id string, req RestoreSnapshotRequest) (*RestoreResult, error)
+	ValidateRestore(ctx context.Context, id string, req RestoreSnapshotRequest) error
+	AvailableZones(ctx context.Context, id string) ([]string, error)
+	ResolveInstance(ctx context.Context, snap *Snapshot) (*Instance, error)
+	RestoreToZone(ctx context.Context, id string, zone string, req RestoreSnapshotRequest) (string, error)
 	Copy(ctx context.Context, id string, req CopySnapshotRequest) error
+	CopyToRegions(ctx context.Context, id string, regions []string) ([]CopyResult, error)
+	CopyToRegionsInProject(ctx context.Context, id, project string, regions []string) ([]CopyResult, error)
+	WaitUntilCompleted(ctx context.Context, id string, opts SnapshotWaitOptions) (*Snapshot, error)
 }
 
 // snapshotService implements the SnapshotService interface.
@@ -89,14 +313,22 @@ type snapshotService struct {
 // This method makes an HTTP request to get the list of snapshots
 // and applies the filters specified in the options.
 func (s *snapshotService) List(ctx context.Context, opts ListOptions) ([]Snapshot, error) {
-	req, err := s.client.newRequest(ctx, http.MethodGet, "/v1/snapshots", nil)
+	return s.list(ctx, opts, "id")
+}
+
+// list fetches one page of snapshots like List. When opts.Fields selects a partial
+// response, the required fields are always added to it, so helpers that read a field
+// get it whatever the caller selected.
+func (s *snapshotService) list(ctx context.Context, opts ListOptions, required ...string) ([]Snapshot, error) {
+	ctx, path := mgc_http.ExpandPathTemplate(ctx, DefaultBasePath, "/v1/snapshots")
+	req, err := s.client.newRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	q := req.URL.Query()
-	if opts.Limit != nil {
-		q.Add("_limit", strconv.Itoa(*opts.Limit))
+	if limit := s.client.GetConfig().PageLimit(opts.Limit); limit != nil {
+		q.Add("_limit", strconv.Itoa(*limit))
 	}
 	if opts.Offset != nil {
 		q.Add("_offset", strconv.Itoa(*opts.Offset))
@@ -107,6 +339,9 @@ func (s *snapshotService) List(ctx context.Context, opts ListOptions) ([]Snapsho
 	if len(opts.Expand) > 0 {
 		q.Add("expand", strings.Join(opts.Expand, ","))
 	}
+	if fields := utils.FieldsQuery(opts.Fields, required...); fields != "" {
+		q.Add(utils.FieldsQueryParam, fields)
+	}
 	req.URL.RawQuery = q.Encode()
 
 	var response ListSnapshotsResponse
@@ -114,19 +349,153 @@ func (s *snapshotService) List(ctx context.Context, opts ListOptions) ([]Snapsho
 	if err != nil {
 		return nil, err
 	}
+	if resp.Snapshots == nil {
+		resp.Snapshots = []Snapshot{}
+	}
 
 	return resp.Snapshots, nil
 }
 
+// ListPage returns the page of snapshots selected by opts.Offset and opts.Limit, whose Next
+// method fetches the following page with the same options. Limit defaults to the client's
+// DefaultPageSize, or 50.
+func (s *snapshotService) ListPage(ctx context.Context, opts ListOptions) (*client.Page[Snapshot], error) {
+	var fetch client.PageFetcher[Snapshot]
+	fetch = func(ctx context.Context, offset, limit int) (*client.Page[Snapshot], error) {
+		pageOpts := opts
+		pageOpts.Offset = &offset
+		pageOpts.Limit = &limit
+		items, err := s.List(ctx, pageOpts)
+		if err != nil {
+			return nil, err
+		}
+		meta := client.PaginationMeta{Limit: limit, Offset: offset, Count: len(items)}
+		return client.NewPage(items, meta, fetch), nil
+	}
+
+	offset, limit := 0, pagination.DefaultPageSize
+	if opts.Offset != nil {
+		offset = *opts.Offset
+	}
+	if l := s.client.GetConfig().PageLimit(opts.Limit); l != nil && *l > 0 {
+		limit = *l
+	}
+	return fetch(ctx, offset, limit)
+}
+
+// ListByTag returns the snapshots tagged key=value, or tagged with the bare key when value
+// is empty. The snapshots API has no tag filter, so every page is fetched starting at
+// opts.Offset, opts.Limit items per page (the client's DefaultPageSize or 50 by default),
+// and filtered client-side; Sort and Expand apply to each page, and "labels" is added to
+// opts.Fields when it selects a partial response. An empty slice is returned when nothing
+// matches.
+func (s *snapshotService) ListByTag(ctx context.Context, key, value string, opts ListOptions) ([]Snapshot, error) {
+	if key == "" {
+		return nil, &client.ValidationError{Field: "key", Message: utils.CannotBeEmpty}
+	}
+	tag := key
+	if value != "" {
+		tag = key + "=" + value
+	}
+
+	p := pagination.Paginator[Snapshot]{
+		Fetch: func(ctx context.Context, offset, limit int) ([]Snapshot, error) {
+			pageOpts := opts
+			pageOpts.Offset = &offset
+			pageOpts.Limit = &limit
+			return s.list(ctx, pageOpts, "id", "labels")
+		},
+	}
+	if limit := s.client.GetConfig().PageLimit(opts.Limit); limit != nil {
+		p.PageSize = *limit
+	}
+	if opts.Offset != nil {
+		p.Offset = *opts.Offset
+	}
+
+	matches := []Snapshot{}
+	for snapshot, err := range p.All(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		if snapshot.Labels != nil && slices.Contains(*snapshot.Labels, tag) {
+			matches = append(matches, snapshot)
+		}
+	}
+	return matches, nil
+}
+
+// TotalSnapshotSize sums the Size of every snapshot matching opts, fetching all pages
+// of opts.Limit snapshots (the client's DefaultPageSize or 50 by default) starting at opts.Offset.
+func (s *snapshotService) TotalSnapshotSize(ctx context.Context, opts ListOptions) (int64, error) {
+	summary, err := s.SnapshotSizeByInstance(ctx, opts)
+	if err != nil {
+		return 0, err
+	}
+	return summary.Total, nil
+}
+
+// SnapshotSizeByInstance sums the Size of every snapshot matching opts like TotalSnapshotSize
+// and also reports per-instance subtotals. When opts.Fields selects a partial response,
+// "size" and "instance" are added to it so the sums stay correct.
+func (s *snapshotService) SnapshotSizeByInstance(ctx context.Context, opts ListOptions) (*SnapshotSizeSummary, error) {
+	p := pagination.Paginator[Snapshot]{
+		Fetch: func(ctx context.Context, offset, limit int) ([]Snapshot, error) {
+			pageOpts := opts
+			pageOpts.Offset = &offset
+			pageOpts.Limit = &limit
+			return s.list(ctx, pageOpts, "id", "size", "instance")
+		},
+	}
+	if limit := s.client.GetConfig().PageLimit(opts.Limit); limit != nil {
+		p.PageSize = *limit
+	}
+	if opts.Offset != nil {
+		p.Offset = *opts.Offset
+	}
+
+	summary := &SnapshotSizeSummary{ByInstance: make(map[string]int64)}
+	for snapshot, err := range p.All(ctx) {
+		if err != nil {
+			return nil, err
+		}
+
+		instanceID := ""
+		if snapshot.Instance != nil {
+			instanceID = snapshot.Instance.ID
+		}
+		summary.Total += int64(snapshot.Size)
+		summary.Count++
+		summary.ByInstance[instanceID] += int64(snapshot.Size)
+	}
+	return summary, nil
+}
+
 // Create creates a new snapshot from an instance.
 // This method makes an HTTP request to create a new snapshot
 // and returns the ID of the created snapshot.
+// With WithSerializedSnapshotCreates, it first waits for other creates of the same instance.
 func (s *snapshotService) Create(ctx context.Context, createReq CreateSnapshotRequest) (string, error) {
+	if err := createReq.validate(); err != nil {
+		return "", err
+	}
+
+	if locks := s.client.snapshotCreates; locks != nil {
+		if key := snapshotCreateKey(createReq.Instance); key != "" {
+			unlock, err := locks.lock(ctx, key)
+			if err != nil {
+				return "", err
+			}
+			defer unlock()
+		}
+	}
+
 	var result struct {
 		ID string `json:"id"`
 	}
 
-	req, err := s.client.newRequest(ctx, http.MethodPost, "/v1/snapshots", createReq)
+	ctx, path := mgc_http.ExpandPathTemplate(ctx, DefaultBasePath, "/v1/snapshots")
+	req, err := s.client.newRequest(ctx, http.MethodPost, path, createReq)
 	if err != nil {
 		return "", err
 	}
@@ -138,11 +507,59 @@ func (s *snapshotService) Create(ctx context.Context, createReq CreateSnapshotRe
 	return resp.ID, nil
 }
 
+// CreateAndGet creates a new snapshot from an instance and returns the full snapshot.
+// This method calls Create and then Get with the requested expand options,
+// so the caller can inspect the snapshot status without an extra round trip of its own.
+func (s *snapshotService) CreateAndGet(ctx context.Context, createReq CreateSnapshotRequest, expand []string) (*Snapshot, error) {
+	id, err := s.Create(ctx, createReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Get(ctx, id, expand)
+}
+
+// CreateAndWait creates a snapshot and waits until it is completed.
+// With opts.CleanupOnTimeout, a wait ended by opts.Timeout or ctx triggers a best-effort
+// delete of the snapshot; a failed cleanup is reported along with the wait error.
+func (s *snapshotService) CreateAndWait(ctx context.Context, createReq CreateSnapshotRequest, opts SnapshotCreateOptions) (*Snapshot, error) {
+	id, err := s.Create(ctx, createReq)
+	if err != nil {
+		return nil, err
+	}
+
+	waitCtx := ctx
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	snapshot, err := s.WaitUntilCompleted(waitCtx, id, opts.Wait)
+	if err == nil {
+		return snapshot, nil
+	}
+
+	err = fmt.Errorf("wait for snapshot %s: %w", id, err)
+	if !opts.CleanupOnTimeout || waitCtx.Err() == nil {
+		return nil, err
+	}
+
+	// ctx is already done, so the cleanup runs on a context of its own
+	cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), snapshotCleanupTimeout)
+	defer cancel()
+	if cleanupErr := s.delete(cleanupCtx, id); cleanupErr != nil {
+		return nil, errors.Join(err, fmt.Errorf("clean up snapshot %s: %w", id, cleanupErr))
+	}
+	return nil, err
+}
+
 // Get retrieves a specific snapshot.
 // This method makes an HTTP request to get detailed information about a snapshot
 // and optionally expands related resources.
 func (s *snapshotService) Get(ctx context.Context, id string, expand []string) (*Snapshot, error) {
-	req, err := s.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("/v1/snapshots/%s", id), nil)
+	ctx, path := mgc_http.ExpandPathTemplate(ctx, DefaultBasePath, "/v1/snapshots/{id}", id)
+	req, err := s.client.newRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -161,27 +578,154 @@ func (s *snapshotService) Get(ctx context.Context, id string, expand []string) (
 	return resp, nil
 }
 
+// GetByName returns the snapshot with the given name, listing every page of snapshots.
+// It returns ErrSnapshotNotFound when there is none and an error when several share the name.
+func (s *snapshotService) GetByName(ctx context.Context, name string) (*Snapshot, error) {
+	if name == "" {
+		return nil, &client.ValidationError{Field: "name", Message: utils.CannotBeEmpty}
+	}
+
+	p := pagination.Paginator[Snapshot]{
+		Fetch: func(ctx context.Context, offset, limit int) ([]Snapshot, error) {
+			return s.List(ctx, ListOptions{Offset: &offset, Limit: &limit})
+		},
+	}
+	if limit := s.client.GetConfig().PageLimit(nil); limit != nil {
+		p.PageSize = *limit
+	}
+
+	var found *Snapshot
+	for snapshot, err := range p.All(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		if snapshot.Name != name {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("snapshot name %q is ambiguous: used by %s and %s", name, found.ID, snapshot.ID)
+		}
+		found = &snapshot
+	}
+
+	if found == nil {
+		return nil, fmt.Errorf("%w: %q", ErrSnapshotNotFound, name)
+	}
+	return found, nil
+}
+
+// EnsureSnapshot returns the completed snapshot named name, creating it from instance when it
+// does not exist yet. An existing snapshot that is still being created is waited for. The create
+// is sent with an idempotency key, so retries of it do not produce duplicate snapshots.
+// It fails if a snapshot with that name exists for another instance.
+func (s *snapshotService) EnsureSnapshot(ctx context.Context, name string, instance IDOrName) (*Snapshot, error) {
+	existing, err := s.GetByName(ctx, name)
+	switch {
+	case err == nil:
+		if instance.ID != nil && existing.Instance != nil && existing.Instance.ID != *instance.ID {
+			return nil, fmt.Errorf("snapshot %q already exists for instance %s", name, existing.Instance.ID)
+		}
+		if existing.Status == SnapshotStatusCompleted {
+			return existing, nil
+		}
+		return s.WaitUntilCompleted(ctx, existing.ID, SnapshotWaitOptions{})
+	case !errors.Is(err, ErrSnapshotNotFound):
+		return nil, err
+	}
+
+	createCtx := client.WithIdempotencyKey(ctx, client.NewIdempotencyKey())
+	id, err := s.Create(createCtx, CreateSnapshotRequest{Name: name, Instance: instance})
+	if err != nil {
+		return nil, err
+	}
+	return s.WaitUntilCompleted(ctx, id, SnapshotWaitOptions{})
+}
+
 // Delete removes a snapshot.
-// This method makes an HTTP request to delete a snapshot permanently.
+// It is DeleteWithOptions with the zero DeleteSnapshotOptions, so snapshots carrying
+// SnapshotLockLabel are refused with ErrSnapshotLocked.
 func (s *snapshotService) Delete(ctx context.Context, id string) error {
-	req, err := s.client.newRequest(ctx, http.MethodDelete, fmt.Sprintf("/v1/snapshots/%s", id), nil)
+	return s.DeleteWithOptions(ctx, id, DeleteSnapshotOptions{})
+}
+
+// DeleteWithOptions removes a snapshot. Unless opts.Force is set, the snapshot is fetched
+// first and an error wrapping ErrSnapshotLocked is returned if it carries SnapshotLockLabel.
+// A label added between the check and the delete is not detected.
+func (s *snapshotService) DeleteWithOptions(ctx context.Context, id string, opts DeleteSnapshotOptions) error {
+	if !opts.Force {
+		snapshot, err := s.Get(ctx, id, nil)
+		if err != nil {
+			return err
+		}
+		if err := checkNotLocked(snapshot); err != nil {
+			return err
+		}
+	}
+	return s.delete(ctx, id)
+}
+
+// checkNotLocked returns an error wrapping ErrSnapshotLocked if snapshot carries SnapshotLockLabel.
+func checkNotLocked(snapshot *Snapshot) error {
+	if snapshot.IsLocked() {
+		return fmt.Errorf("%w: snapshot %s is tagged %q; remove the label or set Force", ErrSnapshotLocked, snapshot.ID, SnapshotLockLabel)
+	}
+	return nil
+}
+
+// delete makes the HTTP request that removes a snapshot permanently, without the lock guard.
+// A 423 response is returned as an error wrapping ErrSnapshotLocked.
+func (s *snapshotService) delete(ctx context.Context, id string) error {
+	ctx, path := mgc_http.ExpandPathTemplate(ctx, DefaultBasePath, "/v1/snapshots/{id}", id)
+	req, err := s.client.newRequest(ctx, http.MethodDelete, path, nil)
 	if err != nil {
 		return err
 	}
 
 	_, err = mgc_http.Do[any](s.client.GetConfig(), ctx, req, nil)
 	if err != nil {
+		var httpErr *client.HTTPError
+		if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusLocked {
+			return fmt.Errorf("%w: %w", ErrSnapshotLocked, err)
+		}
 		return err
 	}
 	return nil
 }
 
+// DeleteIfUnchanged deletes a snapshot only if it was not modified after seen, which
+// should be the UpdatedAt (or CreatedAt, for snapshots never updated) of the copy the
+// caller holds. The snapshot is fetched first and ErrModifiedSince is returned if its
+// timestamp differs by more than the client's ClockSkewTolerance, so a seen value taken
+// from a drifting local clock or stored with less precision can still match. Passing a
+// server timestamp avoids depending on the local clock altogether. Snapshots carrying
+// SnapshotLockLabel are refused as by Delete. A change between the check and the delete
+// is not detected.
+func (s *snapshotService) DeleteIfUnchanged(ctx context.Context, id string, seen time.Time) error {
+	snapshot, err := s.Get(ctx, id, nil)
+	if err != nil {
+		return err
+	}
+
+	modified := snapshot.CreatedAt
+	if snapshot.UpdatedAt != nil {
+		modified = *snapshot.UpdatedAt
+	}
+	if !s.client.GetConfig().SameInstant(modified, seen) {
+		return fmt.Errorf("%w: snapshot %s updated at %s, seen %s", ErrModifiedSince, id,
+			modified.Format(time.RFC3339Nano), seen.Format(time.RFC3339Nano))
+	}
+	if err := checkNotLocked(snapshot); err != nil {
+		return err
+	}
+
+	return s.delete(ctx, id)
+}
+
 // Rename changes the name of a snapshot.
 // This method makes an HTTP request to rename an existing snapshot.
 func (s *snapshotService) Rename(ctx context.Context, id string, newName string) error {
-	req, err := s.client.newRequest(ctx, http.MethodPatch,
-		fmt.Sprintf("/v1/snapshots/%s/rename", id),
-		UpdateNameRequest{Name: newName})
+	ctx, path := mgc_http.ExpandPathTemplate(ctx, DefaultBasePath, "/v1/snapshots/{id}/rename", id)
+	req, err := s.client.newRequest(ctx, http.MethodPatch, path, UpdateNameRequest{Name: newName})
 	if err != nil {
 		return err
 	}
@@ -193,34 +737,228 @@ func (s *snapshotService) Rename(ctx context.Context, id string, newName string)
 	return nil
 }
 
-// Restore creates a new instance from a snapshot.
+// UpdateMetadata changes the name, tags (Labels) or description of a snapshot in a single
+// request, sending only the fields set in req. It generalizes Rename.
+func (s *snapshotService) UpdateMetadata(ctx context.Context, id string, updateReq UpdateSnapshotMetadataRequest) error {
+	if updateReq.Name != nil && strings.TrimSpace(*updateReq.Name) == "" {
+		return &client.ValidationError{Field: "name", Message: utils.CannotBeEmpty}
+	}
+
+	ctx, path := mgc_http.ExpandPathTemplate(ctx, DefaultBasePath, "/v1/snapshots/{id}", id)
+	req, err := s.client.newRequest(ctx, http.MethodPatch, path, updateReq)
+	if err != nil {
+		return err
+	}
+
+	_, err = mgc_http.Do[any](s.client.GetConfig(), ctx, req, nil)
+	return err
+}
+
+// Restore creates a new instance from a snapshot and returns its ID.
+// It is RestoreWithResult for callers that only need the instance ID.
+func (s *snapshotService) Restore(ctx context.Context, id string, restoreReq RestoreSnapshotRequest) (string, error) {
+	result, err := s.RestoreWithResult(ctx, id, restoreReq)
+	if err != nil {
+		return "", err
+	}
+	return result.InstanceID, nil
+}
+
+// RestoreWithResult creates a new instance from a snapshot.
 // This method makes an HTTP request to restore an instance from a snapshot
 // and returns the ID of the created instance.
-func (s *snapshotService) Restore(ctx context.Context, id string, restoreReq RestoreSnapshotRequest) (string, error) {
-	var result struct {
-		ID string `json:"id"`
+// When AvailabilityZone is set it must not be blank, and errors returned by the API
+// are annotated with the requested zone. When DiskSize is set the snapshot is fetched first,
+// and a size below the snapshot's returns a *client.ValidationError without restoring.
+func (s *snapshotService) RestoreWithResult(ctx context.Context, id string, restoreReq RestoreSnapshotRequest) (*RestoreResult, error) {
+	if restoreReq.AvailabilityZone != nil && strings.TrimSpace(*restoreReq.AvailabilityZone) == "" {
+		return nil, &client.ValidationError{Field: "availability_zone", Message: utils.CannotBeEmpty}
+	}
+
+	if restoreReq.DiskSize != nil {
+		if err := s.validateRestoreDiskSize(ctx, id, *restoreReq.DiskSize); err != nil {
+			return nil, err
+		}
 	}
 
-	req, err := s.client.newRequest(ctx, http.MethodPost,
-		fmt.Sprintf("/v1/snapshots/%s", id),
-		restoreReq)
+	ctx, path := mgc_http.ExpandPathTemplate(ctx, DefaultBasePath, "/v1/snapshots/{id}", id)
+	req, err := s.client.newRequest(ctx, http.MethodPost, path, restoreReq)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
+	var result RestoreResult
 	resp, err := mgc_http.Do(s.client.GetConfig(), ctx, req, &result)
 	if err != nil {
-		return "", err
+		if restoreReq.AvailabilityZone != nil {
+			return nil, fmt.Errorf("restore snapshot %s to availability zone %q with machine type %s: %w",
+				id, *restoreReq.AvailabilityZone, describeIDOrName(restoreReq.MachineType), err)
+		}
+		return nil, err
 	}
-	return resp.ID, nil
+	return resp, nil
 }
 
-// Copy copies a snapshot to another region.
+// validateRestoreDiskSize checks that diskSize does not shrink the disk below the snapshot's size.
+func (s *snapshotService) validateRestoreDiskSize(ctx context.Context, id string, diskSize int) error {
+	if diskSize <= 0 {
+		return &client.ValidationError{Field: "disk_size", Message: "must be greater than zero"}
+	}
+
+	snapshot, err := s.Get(ctx, id, nil)
+	if err != nil {
+		return fmt.Errorf("get snapshot %s to check disk size: %w", id, err)
+	}
+	return checkRestoreDiskSize(diskSize, snapshot.Size)
+}
+
+// checkRestoreDiskSize rejects a disk size that would shrink the disk below snapshotSize.
+func checkRestoreDiskSize(diskSize, snapshotSize int) error {
+	if diskSize < snapshotSize {
+		return &client.ValidationError{
+			Field:   "disk_size",
+			Message: fmt.Sprintf("%d GB is smaller than the snapshot size of %d GB; disks cannot be shrunk", diskSize, snapshotSize),
+		}
+	}
+	return nil
+}
+
+// ValidateRestore checks a restore request without creating an instance, e.g. to rehearse
+// disaster recovery. The compute API has no dry-run mode, so the checks run in the SDK:
+// the request needs a name and a machine type, AvailabilityZone and DiskSize must not be
+// blank or shrink the disk, the snapshot must exist and be completed, and AvailabilityZone
+// must be one of its AvailableZones. Every problem found
+// is returned as a *client.ValidationError, joined with errors.Join together with any failure
+// to fetch the snapshot. A nil error does not guarantee the restore succeeds: quotas,
+// capacity, and whether the machine type, network and SSH key exist are only checked by the
+// API when the restore is sent.
+func (s *snapshotService) ValidateRestore(ctx context.Context, id string, restoreReq RestoreSnapshotRequest) error {
+	if id == "" {
+		return &client.ValidationError{Field: "id", Message: utils.CannotBeEmpty}
+	}
+
+	var errs []error
+	invalid := func(field, message string) {
+		errs = append(errs, &client.ValidationError{Field: field, Message: message})
+	}
+
+	if strings.TrimSpace(restoreReq.Name) == "" {
+		invalid("name", utils.CannotBeEmpty)
+	}
+	if (restoreReq.MachineType.ID == nil || *restoreReq.MachineType.ID == "") &&
+		(restoreReq.MachineType.Name == nil || *restoreReq.MachineType.Name == "") {
+		invalid("machine_type", "requires an ID or a name")
+	}
+	if restoreReq.AvailabilityZone != nil && strings.TrimSpace(*restoreReq.AvailabilityZone) == "" {
+		invalid("availability_zone", utils.CannotBeEmpty)
+	}
+	if restoreReq.DiskSize != nil && *restoreReq.DiskSize <= 0 {
+		invalid("disk_size", "must be greater than zero")
+	}
+
+	snapshot, err := s.Get(ctx, id, nil)
+	if err != nil {
+		return errors.Join(append(errs, fmt.Errorf("get snapshot %s: %w", id, err))...)
+	}
+	if snapshot.Status != SnapshotStatusCompleted {
+		invalid("id", fmt.Sprintf("snapshot %s is %s, only completed snapshots can be restored", id, snapshot.Status))
+	}
+	if restoreReq.DiskSize != nil && *restoreReq.DiskSize > 0 {
+		if err := checkRestoreDiskSize(*restoreReq.DiskSize, snapshot.Size); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if restoreReq.AvailabilityZone != nil && strings.TrimSpace(*restoreReq.AvailabilityZone) != "" {
+		zones, err := s.restoreZones(ctx, snapshot)
+		switch {
+		case err != nil:
+			errs = append(errs, fmt.Errorf("list availability zones of snapshot %s: %w", id, err))
+		case !slices.Contains(zones, *restoreReq.AvailabilityZone):
+			invalid("availability_zone", fmt.Sprintf("snapshot %s cannot be restored into %q, available zones are %s",
+				id, *restoreReq.AvailabilityZone, strings.Join(zones, ", ")))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// AvailableZones returns the availability zones a snapshot can be restored into, to pick a
+// zone before calling RestoreToZone. The zones the API reports for the snapshot are used
+// when present; otherwise every unblocked zone of the region the client's requests go to is
+// returned, as listed by the availability zones service. That fallback needs a regional base
+// URL such as client.BrSe1, and zones it lists may still be refused by the restore.
+func (s *snapshotService) AvailableZones(ctx context.Context, id string) ([]string, error) {
+	if id == "" {
+		return nil, &client.ValidationError{Field: "id", Message: utils.CannotBeEmpty}
+	}
+
+	snapshot, err := s.Get(ctx, id, nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.restoreZones(ctx, snapshot)
+}
+
+// restoreZones returns the zones snapshot can be restored into, see AvailableZones.
+func (s *snapshotService) restoreZones(ctx context.Context, snapshot *Snapshot) ([]string, error) {
+	if snapshot.AvailabilityZones != nil {
+		return *snapshot.AvailabilityZones, nil
+	}
+	return s.client.regionZones(ctx)
+}
+
+// RestoreToZone restores a snapshot into the given availability zone.
+// It is a shortcut for Restore with req.AvailabilityZone set to zone. The zone is not checked
+// before the request is sent; use AvailableZones or ValidateRestore for that.
+func (s *snapshotService) RestoreToZone(ctx context.Context, id string, zone string, req RestoreSnapshotRequest) (string, error) {
+	req.AvailabilityZone = &zone
+	return s.Restore(ctx, id, req)
+}
+
+// ResolveInstance returns the current details of the instance snap was taken from, for
+// snapshots whose Instance only carries an ID. It fetches the instance with the same client
+// and returns ErrSourceInstanceGone, wrapping the API's 404, when it has been deleted.
+func (s *snapshotService) ResolveInstance(ctx context.Context, snap *Snapshot) (*Instance, error) {
+	if snap == nil {
+		return nil, &client.ValidationError{Field: "snapshot", Message: "cannot be nil"}
+	}
+	if snap.Instance == nil || snap.Instance.ID == "" {
+		return nil, &client.ValidationError{Field: "instance", Message: fmt.Sprintf("snapshot %s does not reference its source instance", snap.ID)}
+	}
+
+	instance, err := s.client.Instances().Get(ctx, snap.Instance.ID, nil)
+	var httpErr *client.HTTPError
+	if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: instance %s of snapshot %s: %w", ErrSourceInstanceGone, snap.Instance.ID, snap.ID, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get source instance %s of snapshot %s: %w", snap.Instance.ID, snap.ID, err)
+	}
+	return instance, nil
+}
+
+// describeIDOrName returns the name or ID of a reference for error messages.
+func describeIDOrName(ref IDOrName) string {
+	switch {
+	case ref.Name != nil:
+		return *ref.Name
+	case ref.ID != nil:
+		return *ref.ID
+	default:
+		return "<unset>"
+	}
+}
+
+// Copy copies a snapshot to another region, and to another project when
+// DestinationProject is set.
 // This method makes an HTTP request to copy a snapshot to a different region.
 func (s *snapshotService) Copy(ctx context.Context, id string, copyReq CopySnapshotRequest) error {
-	req, err := s.client.newRequest(ctx, http.MethodPost,
-		fmt.Sprintf("/v1/snapshots/%s/copy", id),
-		copyReq)
+	if err := copyReq.validate(); err != nil {
+		return err
+	}
+
+	ctx, path := mgc_http.ExpandPathTemplate(ctx, DefaultBasePath, "/v1/snapshots/{id}/copy", id)
+	req, err := s.client.newRequest(ctx, http.MethodPost, path, copyReq)
 	if err != nil {
 		return err
 	}
@@ -231,3 +969,119 @@ func (s *snapshotService) Copy(ctx context.Context, id string, copyReq CopySnaps
 	}
 	return nil
 }
+
+// CopyToRegions copies a snapshot to each region, sending at most four copy requests at once.
+// Regions must be non-empty, unique and not blank. One result per region is returned in the
+// order given; if any copy failed the returned error joins the failures. Under a deadline on
+// ctx, each copy gets a share of the time left, and copies not started when the deadline is
+// near, or after ctx is done, are not sent; their Err reports that they never started and
+// wraps context.DeadlineExceeded or ctx.Err().
+// The copy endpoint does not return the IDs of the new snapshots, so completion of the copies
+// in the destination regions cannot be awaited here.
+func (s *snapshotService) CopyToRegions(ctx context.Context, id string, regions []string) ([]CopyResult, error) {
+	return s.copyToRegions(ctx, id, nil, regions)
+}
+
+// CopyToRegionsInProject is CopyToRegions for copies that land in another project, see
+// CopySnapshotRequest.DestinationProject for the permissions it needs.
+func (s *snapshotService) CopyToRegionsInProject(ctx context.Context, id, project string, regions []string) ([]CopyResult, error) {
+	return s.copyToRegions(ctx, id, &project, regions)
+}
+
+// copyToRegions implements CopyToRegions; a nil project copies within the snapshot's project.
+func (s *snapshotService) copyToRegions(ctx context.Context, id string, project *string, regions []string) ([]CopyResult, error) {
+	if project != nil && strings.TrimSpace(*project) == "" {
+		return nil, &client.ValidationError{Field: "project", Message: utils.CannotBeEmpty}
+	}
+	if len(regions) == 0 {
+		return nil, &client.ValidationError{Field: "regions", Message: utils.CannotBeEmpty}
+	}
+	seen := make(map[string]bool, len(regions))
+	for i, region := range regions {
+		if strings.TrimSpace(region) == "" {
+			return nil, &client.ValidationError{Field: fmt.Sprintf("regions[%d]", i), Message: utils.CannotBeEmpty}
+		}
+		if seen[region] {
+			return nil, &client.ValidationError{Field: fmt.Sprintf("regions[%d]", i), Message: fmt.Sprintf("duplicate region %q", region)}
+		}
+		seen[region] = true
+	}
+
+	results := make([]CopyResult, len(regions))
+	sem := make(chan struct{}, maxConcurrentSnapshotCopies)
+	budget := utils.NewBatchBudget(len(regions), maxConcurrentSnapshotCopies)
+	var wg sync.WaitGroup
+	for i, region := range regions {
+		results[i].Region = region
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			itemCtx, cancel, err := budget.Start(ctx)
+			if err != nil {
+				results[i].Err = err
+				return
+			}
+			defer cancel()
+
+			results[i].Err = s.Copy(itemCtx, id, CopySnapshotRequest{DestinationRegion: region, DestinationProject: project})
+		}()
+	}
+	wg.Wait()
+
+	var errs []error
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("copy snapshot %s to %s: %w", id, result.Region, result.Err))
+		}
+	}
+	return results, errors.Join(errs...)
+}
+
+// WaitUntilCompleted polls a snapshot until its status is "completed".
+// It returns an error as soon as the snapshot reports an error status, or when ctx is done;
+// use a context with a deadline to bound the wait.
+func (s *snapshotService) WaitUntilCompleted(ctx context.Context, id string, opts SnapshotWaitOptions) (*Snapshot, error) {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = DefaultSnapshotWaitInterval
+	}
+
+	backoff := helpers.Backoff{Initial: interval}
+	if opts.Backoff != nil {
+		backoff = *opts.Backoff
+		if backoff.Initial <= 0 {
+			backoff.Initial = interval
+		}
+	}
+
+	clock := utils.ClockFrom(ctx)
+	for attempt := 0; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		snapshot, err := s.Get(ctx, id, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if opts.OnProgress != nil {
+			opts.OnProgress(snapshot)
+		}
+
+		if snapshot.Status == SnapshotStatusCompleted {
+			return snapshot, nil
+		}
+		if strings.HasSuffix(snapshot.Status, "error") {
+			return snapshot, fmt.Errorf("snapshot %s failed with status %s", id, snapshot.Status)
+		}
+
+		if err := clock.Sleep(ctx, backoff.NextDelay(attempt)); err != nil {
+			return nil, err
+		}
+	}
+}
@@ -2,10 +2,21 @@ package compute
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 	"time"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+	"github.com/MagaluCloud/mgc-sdk-go/helpers"
+	"github.com/MagaluCloud/mgc-sdk-go/internal/utils"
 )
 
 func TestSnapshotService_List(t *testing.T) {
@@ -169,6 +180,189 @@ func TestSnapshotService_Create(t *testing.T) {
 	}
 }
 
+func TestCreateSnapshotRequest_Consistency(t *testing.T) {
+	tests := []struct {
+		name        string
+		consistency SnapshotConsistency
+		want        string
+	}{
+		{name: "omitted", want: `{"name":"snap","instance":{"id":"inst1"}}`},
+		{name: "crash", consistency: ConsistencyCrash, want: `{"name":"snap","instance":{"id":"inst1"},"consistency":"crash"}`},
+		{name: "application", consistency: ConsistencyApplication, want: `{"name":"snap","instance":{"id":"inst1"},"consistency":"application"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				raw, _ := io.ReadAll(r.Body)
+				body = strings.TrimSpace(string(raw))
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"id": "snap1"}`))
+			}))
+			defer server.Close()
+
+			req := CreateSnapshotRequest{Name: "snap", Instance: IDOrName{ID: strPtr("inst1")}, Consistency: tt.consistency}
+			if _, err := testClient(server.URL).Snapshots().Create(context.Background(), req); err != nil {
+				t.Fatalf("Create() unexpected error: %v", err)
+			}
+			if body != tt.want {
+				t.Errorf("expected body %s, got %s", tt.want, body)
+			}
+		})
+	}
+
+	t.Run("unknown", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Error("unexpected request for an invalid consistency")
+		}))
+		defer server.Close()
+
+		req := CreateSnapshotRequest{Name: "snap", Instance: IDOrName{ID: strPtr("inst1")}, Consistency: "filesystem"}
+		_, err := testClient(server.URL).Snapshots().Create(context.Background(), req)
+		var validationErr *client.ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Field != "consistency" {
+			t.Errorf("expected a ValidationError on consistency, got %v", err)
+		}
+	})
+}
+
+func TestCreateSnapshotRequest_Disks(t *testing.T) {
+	tests := []struct {
+		name      string
+		disks     []string
+		want      string
+		wantField string
+	}{
+		{name: "root disk by default", want: `{"name":"snap","instance":{"id":"inst1"}}`},
+		{name: "single disk", disks: []string{"vol-data"}, want: `{"name":"snap","instance":{"id":"inst1"},"disks":["vol-data"]}`},
+		{name: "all disks", disks: []string{"vol-root", "vol-data", "vol-logs"}, want: `{"name":"snap","instance":{"id":"inst1"},"disks":["vol-root","vol-data","vol-logs"]}`},
+		{name: "empty disk ID", disks: []string{"vol-root", ""}, wantField: "disks[1]"},
+		{name: "duplicate disk", disks: []string{"vol-root", "vol-data", "vol-root"}, wantField: "disks[2]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				raw, _ := io.ReadAll(r.Body)
+				body = strings.TrimSpace(string(raw))
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"id": "snap1"}`))
+			}))
+			defer server.Close()
+
+			req := CreateSnapshotRequest{Name: "snap", Instance: IDOrName{ID: strPtr("inst1")}, Disks: tt.disks}
+			_, err := testClient(server.URL).Snapshots().Create(context.Background(), req)
+
+			if tt.wantField != "" {
+				var validationErr *client.ValidationError
+				if !errors.As(err, &validationErr) || validationErr.Field != tt.wantField {
+					t.Errorf("expected a ValidationError on %s, got %v", tt.wantField, err)
+				}
+				if body != "" {
+					t.Errorf("expected no request for an invalid disk list, got %s", body)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Create() unexpected error: %v", err)
+			}
+			if body != tt.want {
+				t.Errorf("expected body %s, got %s", tt.want, body)
+			}
+		})
+	}
+}
+
+func TestSnapshotService_CreateAndGet(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name             string
+		expand           []string
+		createStatusCode int
+		getStatusCode    int
+		wantExpand       string
+		wantGet          bool
+		wantErr          bool
+	}{
+		{
+			name:             "creates and fetches with expand",
+			expand:           []string{SnapshotImageExpand, SnapshotMachineTypeExpand},
+			createStatusCode: http.StatusOK,
+			getStatusCode:    http.StatusOK,
+			wantExpand:       SnapshotImageExpand + "," + SnapshotMachineTypeExpand,
+			wantGet:          true,
+		},
+		{
+			name:             "creates and fetches without expand",
+			createStatusCode: http.StatusOK,
+			getStatusCode:    http.StatusOK,
+			wantGet:          true,
+		},
+		{
+			name:             "create fails",
+			createStatusCode: http.StatusBadRequest,
+			wantErr:          true,
+		},
+		{
+			name:             "get fails",
+			createStatusCode: http.StatusOK,
+			getStatusCode:    http.StatusNotFound,
+			wantGet:          true,
+			wantErr:          true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotGet bool
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch r.Method {
+				case http.MethodPost:
+					w.WriteHeader(tt.createStatusCode)
+					w.Write([]byte(`{"id": "snap1"}`))
+				case http.MethodGet:
+					gotGet = true
+					if r.URL.Path != "/compute/v1/snapshots/snap1" {
+						t.Errorf("unexpected path %s", r.URL.Path)
+					}
+					if got := r.URL.Query().Get("expand"); got != tt.wantExpand {
+						t.Errorf("expand = %q, want %q", got, tt.wantExpand)
+					}
+					w.WriteHeader(tt.getStatusCode)
+					w.Write([]byte(`{
+						"id": "snap1",
+						"name": "test-snapshot",
+						"status": "creating",
+						"created_at": "` + now.Format(time.RFC3339) + `",
+						"instance": {"id": "inst1", "image": {"id": "img1"}, "machine_type": {"id": "mt1"}}
+					}`))
+				}
+			}))
+			defer server.Close()
+
+			client := testClient(server.URL)
+			got, err := client.Snapshots().CreateAndGet(context.Background(), CreateSnapshotRequest{
+				Name:     "test-snapshot",
+				Instance: IDOrName{ID: strPtr("inst1")},
+			}, tt.expand)
+
+			if gotGet != tt.wantGet {
+				t.Errorf("CreateAndGet() issued get = %v, want %v", gotGet, tt.wantGet)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateAndGet() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && (got.ID != "snap1" || got.Status != "creating") {
+				t.Errorf("CreateAndGet() got = %+v", got)
+			}
+		})
+	}
+}
+
 func TestSnapshotService_Get(t *testing.T) {
 	now := time.Now()
 	tests := []struct {
@@ -243,15 +437,20 @@ func TestSnapshotService_Delete(t *testing.T) {
 	tests := []struct {
 		name       string
 		id         string
+		labels     string
+		force      bool
 		response   string
 		statusCode int
 		wantErr    bool
+		wantLocked bool
+		wantDelete bool
 	}{
 		{
 			name:       "successful delete",
 			id:         "snap1",
 			statusCode: http.StatusNoContent,
 			wantErr:    false,
+			wantDelete: true,
 		},
 		{
 			name:       "not found",
@@ -259,6 +458,7 @@ func TestSnapshotService_Delete(t *testing.T) {
 			response:   `{"error": "snapshot not found"}`,
 			statusCode: http.StatusNotFound,
 			wantErr:    true,
+			wantDelete: true,
 		},
 		{
 			name:       "in use",
@@ -266,12 +466,57 @@ func TestSnapshotService_Delete(t *testing.T) {
 			response:   `{"error": "snapshot in use"}`,
 			statusCode: http.StatusConflict,
 			wantErr:    true,
+			wantDelete: true,
+		},
+		{
+			name:       "locked by the API",
+			id:         "locked",
+			response:   `{"error": "snapshot is locked"}`,
+			statusCode: http.StatusLocked,
+			wantErr:    true,
+			wantLocked: true,
+			wantDelete: true,
+		},
+		{
+			name:       "tagged as locked",
+			id:         "tagged",
+			labels:     `["env=prod", "locked=true"]`,
+			statusCode: http.StatusNoContent,
+			wantErr:    true,
+			wantLocked: true,
+		},
+		{
+			name:       "tagged as locked with force",
+			id:         "tagged",
+			labels:     `["locked=true"]`,
+			force:      true,
+			statusCode: http.StatusNoContent,
+			wantDelete: true,
+		},
+		{
+			name:       "other labels",
+			id:         "labelled",
+			labels:     `["locked=false", "env=prod"]`,
+			statusCode: http.StatusNoContent,
+			wantDelete: true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			deleted, fetched := false, false
 			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					fetched = true
+					labels := tt.labels
+					if labels == "" {
+						labels = "[]"
+					}
+					w.Header().Set("Content-Type", "application/json")
+					w.Write([]byte(`{"id": "` + tt.id + `", "labels": ` + labels + `}`))
+					return
+				}
+				deleted = true
 				w.WriteHeader(tt.statusCode)
 				if tt.response != "" {
 					w.Write([]byte(tt.response))
@@ -280,15 +525,47 @@ func TestSnapshotService_Delete(t *testing.T) {
 			defer server.Close()
 
 			client := testClient(server.URL)
-			err := client.Snapshots().Delete(context.Background(), tt.id)
+			err := client.Snapshots().DeleteWithOptions(context.Background(), tt.id, DeleteSnapshotOptions{Force: tt.force})
 
 			if (err != nil) != tt.wantErr {
-				t.Errorf("Delete() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("DeleteWithOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if errors.Is(err, ErrSnapshotLocked) != tt.wantLocked {
+				t.Errorf("DeleteWithOptions() error = %v, want ErrSnapshotLocked %v", err, tt.wantLocked)
+			}
+			if deleted != tt.wantDelete {
+				t.Errorf("expected delete sent = %v, got %v", tt.wantDelete, deleted)
+			}
+			if fetched == tt.force {
+				t.Errorf("expected snapshot fetched = %v, got %v", !tt.force, fetched)
 			}
 		})
 	}
 }
 
+func TestSnapshotService_Delete_Locked(t *testing.T) {
+	deleted := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			deleted = true
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": "snap1", "labels": ["locked=true"]}`))
+	}))
+	defer server.Close()
+
+	client := testClient(server.URL)
+	err := client.Snapshots().Delete(context.Background(), "snap1")
+	if !errors.Is(err, ErrSnapshotLocked) {
+		t.Errorf("Delete() error = %v, want ErrSnapshotLocked", err)
+	}
+	if deleted {
+		t.Error("expected no delete request for a locked snapshot")
+	}
+}
+
 func TestSnapshotService_Rename(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -335,6 +612,69 @@ func TestSnapshotService_Rename(t *testing.T) {
 	}
 }
 
+func TestSnapshotService_UpdateMetadata(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      UpdateSnapshotMetadataRequest
+		wantBody string
+		wantErr  bool
+	}{
+		{
+			name:     "only description",
+			req:      UpdateSnapshotMetadataRequest{Description: strPtr("nightly backup")},
+			wantBody: `{"description":"nightly backup"}`,
+		},
+		{
+			name:     "name and labels",
+			req:      UpdateSnapshotMetadataRequest{Name: strPtr("db-2024"), Labels: &[]string{"env=prod"}},
+			wantBody: `{"name":"db-2024","labels":["env=prod"]}`,
+		},
+		{
+			name:     "clear labels",
+			req:      UpdateSnapshotMetadataRequest{Labels: &[]string{}},
+			wantBody: `{"labels":[]}`,
+		},
+		{
+			name:    "blank name",
+			req:     UpdateSnapshotMetadataRequest{Name: strPtr("  ")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotBody string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPatch || r.URL.Path != "/compute/v1/snapshots/snap1" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				body, _ := io.ReadAll(r.Body)
+				gotBody = strings.TrimSpace(string(body))
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			err := testClient(server.URL).Snapshots().UpdateMetadata(context.Background(), "snap1", tt.req)
+			if tt.wantErr {
+				var validationErr *client.ValidationError
+				if !errors.As(err, &validationErr) || validationErr.Field != "name" {
+					t.Errorf("expected a name ValidationError, got %v", err)
+				}
+				if gotBody != "" {
+					t.Errorf("expected no request to be sent, got %s", gotBody)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UpdateMetadata() unexpected error: %v", err)
+			}
+			if gotBody != tt.wantBody {
+				t.Errorf("body = %s, want %s", gotBody, tt.wantBody)
+			}
+		})
+	}
+}
+
 func TestSnapshotService_Restore(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -393,51 +733,1521 @@ func TestSnapshotService_Restore(t *testing.T) {
 	}
 }
 
-func TestSnapshotService_Copy(t *testing.T) {
+func TestSnapshotService_RestoreWithResult(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": "inst1"}`))
+	}))
+	defer server.Close()
+
+	result, err := testClient(server.URL).Snapshots().RestoreWithResult(context.Background(), "snap1", RestoreSnapshotRequest{
+		Name:        "restored-instance",
+		MachineType: IDOrName{ID: strPtr("mt1")},
+	})
+	if err != nil {
+		t.Fatalf("RestoreWithResult() unexpected error: %v", err)
+	}
+	if result.InstanceID != "inst1" {
+		t.Errorf("InstanceID = %q, want inst1", result.InstanceID)
+	}
+}
+
+func TestSnapshotService_Restore_DiskSize(t *testing.T) {
+	tests := []struct {
+		name         string
+		diskSize     *int
+		wantGet      bool
+		wantRestore  bool
+		wantErrField string
+	}{
+		{
+			name:        "grows the disk",
+			diskSize:    intPtr(100),
+			wantGet:     true,
+			wantRestore: true,
+		},
+		{
+			name:        "keeps the snapshot size",
+			diskSize:    intPtr(40),
+			wantGet:     true,
+			wantRestore: true,
+		},
+		{
+			name:         "shrinking is rejected",
+			diskSize:     intPtr(20),
+			wantGet:      true,
+			wantErrField: "disk_size",
+		},
+		{
+			name:         "non-positive size is rejected",
+			diskSize:     intPtr(0),
+			wantErrField: "disk_size",
+		},
+		{
+			name:        "unset skips the check",
+			wantRestore: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotGet, gotRestore bool
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if r.Method == http.MethodGet {
+					gotGet = true
+					w.Write([]byte(`{"id": "snap1", "size": 40}`))
+					return
+				}
+
+				gotRestore = true
+				var body map[string]any
+				json.NewDecoder(r.Body).Decode(&body)
+				diskSize, sent := body["disk_size"]
+				if tt.diskSize == nil && sent {
+					t.Errorf("expected disk_size to be omitted, got %v", diskSize)
+				}
+				if tt.diskSize != nil && diskSize != float64(*tt.diskSize) {
+					t.Errorf("expected disk_size %d, got %v", *tt.diskSize, diskSize)
+				}
+				w.Write([]byte(`{"id": "inst1"}`))
+			}))
+			defer server.Close()
+
+			_, err := testClient(server.URL).Snapshots().Restore(context.Background(), "snap1", RestoreSnapshotRequest{
+				Name:        "restored-instance",
+				MachineType: IDOrName{Name: strPtr("BV1-1-40")},
+				DiskSize:    tt.diskSize,
+			})
+
+			if tt.wantErrField != "" {
+				var validationErr *client.ValidationError
+				if !errors.As(err, &validationErr) || validationErr.Field != tt.wantErrField {
+					t.Errorf("expected a ValidationError on %s, got %v", tt.wantErrField, err)
+				}
+			} else if err != nil {
+				t.Fatalf("Restore() unexpected error: %v", err)
+			}
+			if gotGet != tt.wantGet || gotRestore != tt.wantRestore {
+				t.Errorf("expected get=%v restore=%v, got get=%v restore=%v", tt.wantGet, tt.wantRestore, gotGet, gotRestore)
+			}
+		})
+	}
+}
+
+func TestSnapshotService_ValidateRestore(t *testing.T) {
+	valid := RestoreSnapshotRequest{Name: "restored", MachineType: IDOrName{Name: strPtr("BV1-1-10")}, DiskSize: intPtr(60)}
+
 	tests := []struct {
 		name       string
-		id         string
-		req        CopySnapshotRequest
-		response   string
+		req        RestoreSnapshotRequest
+		snapshot   string
+		wantFields []string
+	}{
+		{
+			name:     "valid request",
+			req:      valid,
+			snapshot: `{"id": "snap1", "status": "completed", "size": 40}`,
+		},
+		{
+			name:       "missing name and machine type",
+			req:        RestoreSnapshotRequest{AvailabilityZone: strPtr(" ")},
+			snapshot:   `{"id": "snap1", "status": "completed", "size": 40}`,
+			wantFields: []string{"name", "machine_type", "availability_zone"},
+		},
+		{
+			name:       "snapshot not completed and disk too small",
+			req:        RestoreSnapshotRequest{Name: "restored", MachineType: IDOrName{ID: strPtr("mt-1")}, DiskSize: intPtr(20)},
+			snapshot:   `{"id": "snap1", "status": "creating", "size": 40}`,
+			wantFields: []string{"id", "disk_size"},
+		},
+		{
+			name:       "zone not available for the snapshot",
+			req:        RestoreSnapshotRequest{Name: "restored", MachineType: IDOrName{ID: strPtr("mt-1")}, AvailabilityZone: strPtr("br-se1-c")},
+			snapshot:   `{"id": "snap1", "status": "completed", "size": 40, "availability_zones": ["br-se1-a", "br-se1-b"]}`,
+			wantFields: []string{"availability_zone"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet {
+					t.Errorf("expected only reads, got %s %s", r.Method, r.URL.Path)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(tt.snapshot))
+			}))
+			defer server.Close()
+
+			err := testClient(server.URL).Snapshots().ValidateRestore(context.Background(), "snap1", tt.req)
+
+			var fields []string
+			if joined, ok := err.(interface{ Unwrap() []error }); ok {
+				for _, e := range joined.Unwrap() {
+					var validationErr *client.ValidationError
+					if !errors.As(e, &validationErr) {
+						t.Fatalf("expected only ValidationErrors, got %v", e)
+					}
+					fields = append(fields, validationErr.Field)
+				}
+			} else if err != nil {
+				t.Fatalf("ValidateRestore() unexpected error: %v", err)
+			}
+			if strings.Join(fields, ",") != strings.Join(tt.wantFields, ",") {
+				t.Errorf("expected problems with %v, got %v", tt.wantFields, fields)
+			}
+		})
+	}
+
+	t.Run("snapshot not found", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		err := testClient(server.URL).Snapshots().ValidateRestore(context.Background(), "snap1", valid)
+		var httpErr *client.HTTPError
+		if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusNotFound {
+			t.Errorf("expected the 404 to be reported, got %v", err)
+		}
+	})
+}
+
+func TestSnapshotService_Create_SerializedPerInstance(t *testing.T) {
+	var mu sync.Mutex
+	inFlight := map[string]int{}
+	maxPerInstance, total, maxTotal := 0, 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body CreateSnapshotRequest
+		json.NewDecoder(r.Body).Decode(&body)
+		instance := *body.Instance.ID
+
+		mu.Lock()
+		inFlight[instance]++
+		total++
+		maxPerInstance = max(maxPerInstance, inFlight[instance])
+		maxTotal = max(maxTotal, total)
+		mu.Unlock()
+
+		time.Sleep(50 * time.Millisecond)
+
+		mu.Lock()
+		inFlight[instance]--
+		total--
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": "snap-` + instance + `"}`))
+	}))
+	defer server.Close()
+
+	core := client.NewMgcClient("test-api", client.WithBaseURL(client.MgcUrl(server.URL)))
+	snapshots := New(core, WithSerializedSnapshotCreates()).Snapshots()
+
+	var wg sync.WaitGroup
+	for _, instance := range []string{"inst-a", "inst-a", "inst-a", "inst-b"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := snapshots.Create(context.Background(), CreateSnapshotRequest{Name: "backup", Instance: IDOrName{ID: &instance}}); err != nil {
+				t.Errorf("Create() unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if maxPerInstance != 1 {
+		t.Errorf("expected creates for one instance not to overlap, got %d at once", maxPerInstance)
+	}
+	if maxTotal < 2 {
+		t.Errorf("expected creates for different instances to overlap, got at most %d at once", maxTotal)
+	}
+
+	t.Run("waiting create honours its context", func(t *testing.T) {
+		vmClient := New(core, WithSerializedSnapshotCreates())
+		unlock, _ := vmClient.snapshotCreates.lock(context.Background(), "id:inst-a")
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		_, err := vmClient.Snapshots().Create(ctx, CreateSnapshotRequest{Name: "backup", Instance: IDOrName{ID: strPtr("inst-a")}})
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected the wait to end with the context, got %v", err)
+		}
+
+		unlock()
+		if len(vmClient.snapshotCreates.locks) != 0 {
+			t.Errorf("expected released locks to be forgotten, got %v", vmClient.snapshotCreates.locks)
+		}
+	})
+}
+
+func TestSnapshotService_ResolveInstance(t *testing.T) {
+	tests := []struct {
+		name       string
+		snapshot   *Snapshot
 		statusCode int
+		response   string
+		wantName   string
+		wantGone   bool
 		wantErr    bool
 	}{
 		{
-			name: "successful copy",
-			id:   "snap1",
-			req: CopySnapshotRequest{
-				DestinationRegion: "region2",
-			},
+			name:       "existing instance",
+			snapshot:   &Snapshot{ID: "snap1", Instance: &SnapshotInstance{ID: "inst1"}},
 			statusCode: http.StatusOK,
-			wantErr:    false,
+			response:   `{"id": "inst1", "name": "web-1", "status": "completed", "state": "running"}`,
+			wantName:   "web-1",
 		},
 		{
-			name: "invalid region",
-			id:   "snap1",
-			req: CopySnapshotRequest{
-				DestinationRegion: "invalid",
-			},
-			response:   `{"error": "invalid region"}`,
+			name:       "deleted instance",
+			snapshot:   &Snapshot{ID: "snap1", Instance: &SnapshotInstance{ID: "inst1"}},
+			statusCode: http.StatusNotFound,
+			response:   `{"error": "instance not found"}`,
+			wantGone:   true,
+			wantErr:    true,
+		},
+		{
+			name:       "server error is not reported as gone",
+			snapshot:   &Snapshot{ID: "snap1", Instance: &SnapshotInstance{ID: "inst1"}},
 			statusCode: http.StatusBadRequest,
+			response:   `{"error": "bad request"}`,
 			wantErr:    true,
 		},
+		{
+			name:     "snapshot without instance",
+			snapshot: &Snapshot{ID: "snap1"},
+			wantErr:  true,
+		},
+		{
+			name:    "nil snapshot",
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				w.WriteHeader(tt.statusCode)
-				if tt.response != "" {
-					w.Write([]byte(tt.response))
+				if r.URL.Path != "/compute/v1/instances/inst1" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
 				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.statusCode)
+				w.Write([]byte(tt.response))
 			}))
 			defer server.Close()
 
-			client := testClient(server.URL)
-			err := client.Snapshots().Copy(context.Background(), tt.id, tt.req)
+			instance, err := testClient(server.URL).Snapshots().ResolveInstance(context.Background(), tt.snapshot)
 
 			if (err != nil) != tt.wantErr {
-				t.Errorf("Copy() error = %v, wantErr %v", err, tt.wantErr)
+				t.Fatalf("ResolveInstance() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if errors.Is(err, ErrSourceInstanceGone) != tt.wantGone {
+				t.Errorf("expected ErrSourceInstanceGone %v, got %v", tt.wantGone, err)
+			}
+			if tt.wantName != "" && (instance == nil || instance.Name == nil || *instance.Name != tt.wantName) {
+				t.Errorf("expected instance %q, got %+v", tt.wantName, instance)
+			}
+		})
+	}
+}
+
+func TestSnapshotService_AvailableZones(t *testing.T) {
+	zonesResponse := `{"results": [
+		{"region_id": "br-ne1", "availability_zones": [{"az_id": "br-ne1-a", "block_type": "none"}]},
+		{"region_id": "br-se1", "availability_zones": [{"az_id": "br-se1-a", "block_type": "none"}, {"az_id": "br-se1-b", "block_type": "none"}]}
+	]}`
+
+	tests := []struct {
+		name     string
+		snapshot string
+		region   string
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name:     "zones reported for the snapshot",
+			snapshot: `{"id": "snap1", "status": "completed", "availability_zones": ["br-se1-b"]}`,
+			region:   "/br-se1",
+			want:     []string{"br-se1-b"},
+		},
+		{
+			name:     "falls back to the region's zones",
+			snapshot: `{"id": "snap1", "status": "completed"}`,
+			region:   "/br-se1",
+			want:     []string{"br-se1-a", "br-se1-b"},
+		},
+		{
+			name:     "fallback needs a regional base URL",
+			snapshot: `{"id": "snap1", "status": "completed"}`,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch r.URL.Path {
+				case tt.region + "/compute/v1/snapshots/snap1":
+					w.Write([]byte(tt.snapshot))
+				case "/profile/v0/availability-zones":
+					if got := r.URL.Query().Get("show_is_blocked"); got != "false" {
+						t.Errorf("expected blocked zones to be hidden, got show_is_blocked=%q", got)
+					}
+					w.Write([]byte(zonesResponse))
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			got, err := testClient(server.URL+tt.region).Snapshots().AvailableZones(context.Background(), "snap1")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AvailableZones() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("AvailableZones() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSnapshotService_RestoreToZone(t *testing.T) {
+	tests := []struct {
+		name        string
+		zone        string
+		statusCode  int
+		response    string
+		wantID      string
+		wantErr     bool
+		wantErrText string
+		wantRequest bool
+	}{
+		{
+			name:        "restores to zone",
+			zone:        "br-se1-b",
+			statusCode:  http.StatusOK,
+			response:    `{"id": "inst1"}`,
+			wantID:      "inst1",
+			wantRequest: true,
+		},
+		{
+			name:    "blank zone is rejected",
+			zone:    "  ",
+			wantErr: true,
+		},
+		{
+			name:        "server error mentions zone and machine type",
+			zone:        "br-se1-z",
+			statusCode:  http.StatusBadRequest,
+			response:    `{"error": "machine type not available in zone"}`,
+			wantErr:     true,
+			wantErrText: `availability zone "br-se1-z" with machine type BV1-1-40`,
+			wantRequest: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requested := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requested = true
+				var body map[string]any
+				json.NewDecoder(r.Body).Decode(&body)
+				if body["availability_zone"] != tt.zone {
+					t.Errorf("expected availability_zone %q, got %v", tt.zone, body["availability_zone"])
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.statusCode)
+				w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			client := testClient(server.URL)
+			gotID, err := client.Snapshots().RestoreToZone(context.Background(), "snap1", tt.zone, RestoreSnapshotRequest{
+				Name:        "restored-instance",
+				MachineType: IDOrName{Name: strPtr("BV1-1-40")},
+			})
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RestoreToZone() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErrText != "" && !strings.Contains(err.Error(), tt.wantErrText) {
+				t.Errorf("RestoreToZone() error = %v, want it to contain %q", err, tt.wantErrText)
+			}
+			if gotID != tt.wantID {
+				t.Errorf("RestoreToZone() got = %v, want %v", gotID, tt.wantID)
+			}
+			if requested != tt.wantRequest {
+				t.Errorf("expected request sent = %v, got %v", tt.wantRequest, requested)
+			}
+		})
+	}
+}
+
+func TestSnapshotService_Copy(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         string
+		req        CopySnapshotRequest
+		response   string
+		statusCode int
+		wantErr    bool
+	}{
+		{
+			name: "successful copy",
+			id:   "snap1",
+			req: CopySnapshotRequest{
+				DestinationRegion: "region2",
+			},
+			statusCode: http.StatusOK,
+			wantErr:    false,
+		},
+		{
+			name: "invalid region",
+			id:   "snap1",
+			req: CopySnapshotRequest{
+				DestinationRegion: "invalid",
+			},
+			response:   `{"error": "invalid region"}`,
+			statusCode: http.StatusBadRequest,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statusCode)
+				if tt.response != "" {
+					w.Write([]byte(tt.response))
+				}
+			}))
+			defer server.Close()
+
+			client := testClient(server.URL)
+			err := client.Snapshots().Copy(context.Background(), tt.id, tt.req)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Copy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSnapshotService_Copy_DestinationProject(t *testing.T) {
+	tests := []struct {
+		name      string
+		req       CopySnapshotRequest
+		want      string
+		wantField string
+	}{
+		{
+			name: "same project",
+			req:  CopySnapshotRequest{DestinationRegion: "br-ne1"},
+			want: `{"destination_region":"br-ne1"}`,
+		},
+		{
+			name: "another project",
+			req:  CopySnapshotRequest{DestinationRegion: "br-ne1", DestinationProject: strPtr("backups")},
+			want: `{"destination_region":"br-ne1","destination_project":"backups"}`,
+		},
+		{
+			name:      "project without region",
+			req:       CopySnapshotRequest{DestinationProject: strPtr("backups")},
+			wantField: "destination_region",
+		},
+		{
+			name:      "blank project",
+			req:       CopySnapshotRequest{DestinationRegion: "br-ne1", DestinationProject: strPtr(" ")},
+			wantField: "destination_project",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				raw, _ := io.ReadAll(r.Body)
+				body = strings.TrimSpace(string(raw))
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			err := testClient(server.URL).Snapshots().Copy(context.Background(), "snap1", tt.req)
+
+			if tt.wantField != "" {
+				var validationErr *client.ValidationError
+				if !errors.As(err, &validationErr) || validationErr.Field != tt.wantField {
+					t.Errorf("expected a ValidationError on %s, got %v", tt.wantField, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Copy() unexpected error: %v", err)
+			}
+			if body != tt.want {
+				t.Errorf("expected body %s, got %s", tt.want, body)
+			}
+		})
+	}
+}
+
+func TestSnapshotService_CopyToRegionsInProject(t *testing.T) {
+	var mu sync.Mutex
+	projects := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req CopySnapshotRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		if req.DestinationProject != nil {
+			projects[req.DestinationRegion] = *req.DestinationProject
+		}
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	svc := testClient(server.URL).Snapshots()
+	if _, err := svc.CopyToRegionsInProject(context.Background(), "snap1", "backups", []string{"br-ne1", "br-se1"}); err != nil {
+		t.Fatalf("CopyToRegionsInProject() unexpected error: %v", err)
+	}
+	if len(projects) != 2 || projects["br-ne1"] != "backups" || projects["br-se1"] != "backups" {
+		t.Errorf("expected both copies to target project backups, got %v", projects)
+	}
+
+	_, err := svc.CopyToRegionsInProject(context.Background(), "snap1", "", []string{"br-ne1"})
+	var validationErr *client.ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "project" {
+		t.Errorf("expected a ValidationError on project, got %v", err)
+	}
+}
+
+func TestSnapshotService_WaitUntilCompleted(t *testing.T) {
+	t.Run("reports increasing progress until completed", func(t *testing.T) {
+		responses := []string{
+			`{"id": "snap1", "status": "creating", "progress": 10}`,
+			`{"id": "snap1", "status": "creating", "progress": 55}`,
+			`{"id": "snap1", "status": "completed", "progress": 100}`,
+		}
+		calls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(responses[min(calls, len(responses)-1)]))
+			calls++
+		}))
+		defer server.Close()
+
+		var progress []int
+		client := testClient(server.URL)
+		got, err := client.Snapshots().WaitUntilCompleted(context.Background(), "snap1", SnapshotWaitOptions{
+			Interval: time.Millisecond,
+			OnProgress: func(snapshot *Snapshot) {
+				if snapshot.Progress != nil {
+					progress = append(progress, *snapshot.Progress)
+				}
+			},
+		})
+		if err != nil {
+			t.Fatalf("WaitUntilCompleted() unexpected error: %v", err)
+		}
+		if got.Status != SnapshotStatusCompleted {
+			t.Errorf("WaitUntilCompleted() status = %s, want %s", got.Status, SnapshotStatusCompleted)
+		}
+		if len(progress) != 3 || progress[0] != 10 || progress[1] != 55 || progress[2] != 100 {
+			t.Errorf("WaitUntilCompleted() progress = %v, want [10 55 100]", progress)
+		}
+	})
+
+	t.Run("backoff spaces the polls", func(t *testing.T) {
+		calls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			status := "creating"
+			if calls == 3 {
+				status = "completed"
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id": "snap1", "status": "` + status + `"}`))
+		}))
+		defer server.Close()
+
+		var waits int
+		backoff := &helpers.Backoff{
+			Initial: time.Millisecond,
+			Factor:  2,
+			Jitter:  helpers.FullJitter,
+			Rand:    func() float64 { waits++; return 0.5 },
+		}
+		_, err := testClient(server.URL).Snapshots().WaitUntilCompleted(context.Background(), "snap1", SnapshotWaitOptions{Backoff: backoff})
+		if err != nil {
+			t.Fatalf("WaitUntilCompleted() unexpected error: %v", err)
+		}
+		if calls != 3 || waits != 2 {
+			t.Errorf("expected 3 polls spaced by 2 backoff delays, got %d polls and %d delays", calls, waits)
+		}
+	})
+
+	t.Run("backoff without an initial delay starts from the interval", func(t *testing.T) {
+		calls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			status := "creating"
+			if calls == 3 {
+				status = "completed"
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id": "snap1", "status": "` + status + `"}`))
+		}))
+		defer server.Close()
+
+		clock := utils.NewFakeClock(time.Now())
+		ctx := utils.WithClock(context.Background(), clock)
+		_, err := testClient(server.URL).Snapshots().WaitUntilCompleted(ctx, "snap1",
+			SnapshotWaitOptions{Interval: time.Second, Backoff: &helpers.Backoff{Factor: 2}})
+		if err != nil {
+			t.Fatalf("WaitUntilCompleted() unexpected error: %v", err)
+		}
+		want := []time.Duration{time.Second, 2 * time.Second}
+		if got := clock.Sleeps(); !slices.Equal(got, want) {
+			t.Errorf("expected sleeps %v, got %v", want, got)
+		}
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"id": "snap1", "status": "creating_error"}`))
+		}))
+		defer server.Close()
+
+		client := testClient(server.URL)
+		_, err := client.Snapshots().WaitUntilCompleted(context.Background(), "snap1", SnapshotWaitOptions{Interval: time.Millisecond})
+		if err == nil {
+			t.Error("WaitUntilCompleted() expected error for error status")
+		}
+	})
+
+	t.Run("context deadline", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"id": "snap1", "status": "creating"}`))
+		}))
+		defer server.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		client := testClient(server.URL)
+		_, err := client.Snapshots().WaitUntilCompleted(ctx, "snap1", SnapshotWaitOptions{Interval: time.Millisecond})
+		if err == nil {
+			t.Error("WaitUntilCompleted() expected error when context expires")
+		}
+	})
+
+	t.Run("times out on a fake clock", func(t *testing.T) {
+		polls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			polls++
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id": "snap1", "status": "creating"}`))
+		}))
+		defer server.Close()
+
+		clock := utils.NewFakeClock(time.Now())
+		ctx, cancel := context.WithDeadline(utils.WithClock(context.Background(), clock), clock.Now().Add(time.Minute))
+		defer cancel()
+
+		_, err := testClient(server.URL).Snapshots().WaitUntilCompleted(ctx, "snap1", SnapshotWaitOptions{Interval: 10 * time.Second})
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("WaitUntilCompleted() error = %v, want context.DeadlineExceeded", err)
+		}
+		// polls at 0s, 10s, ..., 50s; the sleep after the last one reaches the deadline
+		if polls != 6 {
+			t.Errorf("expected 6 polls, got %d", polls)
+		}
+		want := slices.Repeat([]time.Duration{10 * time.Second}, 6)
+		if got := clock.Sleeps(); !slices.Equal(got, want) {
+			t.Errorf("expected sleeps %v, got %v", want, got)
+		}
+	})
+
+	t.Run("cancelled between polls returns promptly", func(t *testing.T) {
+		polls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			polls++
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id": "snap1", "status": "creating"}`))
+		}))
+		defer server.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+
+		start := time.Now()
+		_, err := testClient(server.URL).Snapshots().WaitUntilCompleted(ctx, "snap1", SnapshotWaitOptions{Interval: time.Hour})
+		if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+			t.Errorf("WaitUntilCompleted() returned after %v, want under 500ms", elapsed)
+		}
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("WaitUntilCompleted() error = %v, want context.Canceled", err)
+		}
+		if polls != 1 {
+			t.Errorf("expected 1 poll, got %d", polls)
+		}
+	})
+}
+
+func TestSnapshotService_DeleteIfUnchanged(t *testing.T) {
+	updatedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	createdAt := updatedAt.Add(-time.Hour)
+
+	tests := []struct {
+		name       string
+		response   string
+		seen       time.Time
+		wantErr    error
+		wantDelete bool
+	}{
+		{
+			name:       "unchanged snapshot is deleted",
+			response:   `{"id": "snap1", "created_at": "2024-05-01T11:00:00Z", "updated_at": "2024-05-01T12:00:00Z"}`,
+			seen:       updatedAt,
+			wantDelete: true,
+		},
+		{
+			name:     "modified snapshot is kept",
+			response: `{"id": "snap1", "created_at": "2024-05-01T11:00:00Z", "updated_at": "2024-05-01T12:30:00Z"}`,
+			seen:     updatedAt,
+			wantErr:  ErrModifiedSince,
+		},
+		{
+			name:       "never updated snapshot compares created_at",
+			response:   `{"id": "snap1", "created_at": "2024-05-01T11:00:00Z"}`,
+			seen:       createdAt,
+			wantDelete: true,
+		},
+		{
+			name:     "locked snapshot is kept",
+			response: `{"id": "snap1", "created_at": "2024-05-01T11:00:00Z", "labels": ["locked=true"]}`,
+			seen:     createdAt,
+			wantErr:  ErrSnapshotLocked,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deleted := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodDelete {
+					deleted = true
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			client := testClient(server.URL)
+			err := client.Snapshots().DeleteIfUnchanged(context.Background(), "snap1", tt.seen)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("DeleteIfUnchanged() error = %v, want %v", err, tt.wantErr)
+			}
+			if deleted != tt.wantDelete {
+				t.Errorf("expected delete sent = %v, got %v", tt.wantDelete, deleted)
+			}
+		})
+	}
+}
+
+func TestSnapshotService_DeleteIfUnchanged_ClockSkew(t *testing.T) {
+	// seen comes from a local clock running 2s ahead of the server
+	seen := time.Date(2024, 5, 1, 12, 0, 2, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		tolerance  time.Duration
+		wantErr    error
+		wantDelete bool
+	}{
+		{name: "exact comparison rejects the skewed timestamp", wantErr: ErrModifiedSince},
+		{name: "tolerance absorbs the skew", tolerance: 5 * time.Second, wantDelete: true},
+		{name: "tolerance smaller than the skew", tolerance: time.Second, wantErr: ErrModifiedSince},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deleted := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodDelete {
+					deleted = true
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"id": "snap1", "created_at": "2024-05-01T11:00:00Z", "updated_at": "2024-05-01T12:00:00Z"}`))
+			}))
+			defer server.Close()
+
+			core := client.NewMgcClient("test-api",
+				client.WithBaseURL(client.MgcUrl(server.URL)),
+				client.WithClockSkewTolerance(tt.tolerance))
+			err := New(core).Snapshots().DeleteIfUnchanged(context.Background(), "snap1", seen)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("DeleteIfUnchanged() error = %v, want %v", err, tt.wantErr)
+			}
+			if deleted != tt.wantDelete {
+				t.Errorf("expected delete sent = %v, got %v", tt.wantDelete, deleted)
+			}
+		})
+	}
+}
+
+func TestSnapshotService_CopyToRegions(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		regions  []string
+		inFlight int
+		maxSeen  int
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req CopySnapshotRequest
+		json.NewDecoder(r.Body).Decode(&req)
+
+		mu.Lock()
+		regions = append(regions, req.DestinationRegion)
+		inFlight++
+		maxSeen = max(maxSeen, inFlight)
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+
+		if req.DestinationRegion == "br-bad" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error": "invalid region"}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	targets := []string{"br-ne1", "br-se1", "br-mgl1", "br-bad", "br-x1", "br-x2"}
+	results, err := testClient(server.URL).Snapshots().CopyToRegions(context.Background(), "snap1", targets)
+
+	if err == nil || !strings.Contains(err.Error(), "br-bad") {
+		t.Errorf("expected error mentioning br-bad, got %v", err)
+	}
+	if len(results) != len(targets) {
+		t.Fatalf("expected %d results, got %d", len(targets), len(results))
+	}
+	for i, result := range results {
+		if result.Region != targets[i] {
+			t.Errorf("expected result %d for %s, got %s", i, targets[i], result.Region)
+		}
+		if (result.Err != nil) != (result.Region == "br-bad") {
+			t.Errorf("unexpected error for %s: %v", result.Region, result.Err)
+		}
+	}
+	if len(regions) != len(targets) {
+		t.Errorf("expected %d copy requests, got %d", len(targets), len(regions))
+	}
+	if maxSeen > maxConcurrentSnapshotCopies {
+		t.Errorf("expected at most %d concurrent copies, got %d", maxConcurrentSnapshotCopies, maxSeen)
+	}
+}
+
+func TestSnapshotService_CopyToRegions_Deadline(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		sent = map[string]bool{}
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req CopySnapshotRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		sent[req.DestinationRegion] = true
+		mu.Unlock()
+		// never answers in time, so each copy takes its whole share of the budget
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	var targets []string
+	for i := range 2 * maxConcurrentSnapshotCopies {
+		targets = append(targets, "br-x"+strconv.Itoa(i))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), utils.MinBatchItemBudget+utils.MinBatchItemBudget/2)
+	defer cancel()
+
+	results, err := testClient(server.URL).Snapshots().CopyToRegions(ctx, "snap1", targets)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	unattempted := 0
+	for _, result := range results {
+		if result.Err == nil {
+			t.Errorf("expected the copy to %s to fail", result.Region)
+			continue
+		}
+		if strings.Contains(result.Err.Error(), "not started") {
+			unattempted++
+			if !errors.Is(result.Err, context.DeadlineExceeded) {
+				t.Errorf("expected an unattempted copy to fail with context.DeadlineExceeded, got %v", result.Err)
+			}
+		}
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if unattempted == 0 || len(sent) == 0 {
+		t.Errorf("expected some copies to be sent and the rest left unattempted, got %d sent and %d unattempted", len(sent), unattempted)
+	}
+	if len(sent)+unattempted != len(targets) {
+		t.Errorf("expected the unattempted copies not to be sent, got %d sent and %d unattempted", len(sent), unattempted)
+	}
+}
+
+func TestSnapshotService_CopyToRegions_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		regions []string
+	}{
+		{name: "empty list", regions: nil},
+		{name: "blank region", regions: []string{"br-ne1", " "}},
+		{name: "duplicate region", regions: []string{"br-ne1", "br-se1", "br-ne1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Error("expected no request to be sent")
+			}))
+			defer server.Close()
+
+			_, err := testClient(server.URL).Snapshots().CopyToRegions(context.Background(), "snap1", tt.regions)
+			var validationErr *client.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Errorf("expected validation error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSnapshotService_SnapshotSizeByInstance(t *testing.T) {
+	var offsets []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("_limit") != "2" {
+			t.Errorf("expected _limit=2, got %q", r.URL.Query().Get("_limit"))
+		}
+		offset := r.URL.Query().Get("_offset")
+		offsets = append(offsets, offset)
+
+		w.Header().Set("Content-Type", "application/json")
+		switch offset {
+		case "0":
+			w.Write([]byte(`{"snapshots": [
+				{"id": "s1", "size": 10, "instance": {"id": "vm-1"}},
+				{"id": "s2", "size": 20, "instance": {"id": "vm-2"}}]}`))
+		case "2":
+			w.Write([]byte(`{"snapshots": [
+				{"id": "s3", "size": 30, "instance": {"id": "vm-1"}},
+				{"id": "s4", "size": 5}]}`))
+		default:
+			w.Write([]byte(`{"snapshots": []}`))
+		}
+	}))
+	defer server.Close()
+
+	client := testClient(server.URL)
+	summary, err := client.Snapshots().SnapshotSizeByInstance(context.Background(), ListOptions{Limit: intPtr(2)})
+	if err != nil {
+		t.Fatalf("SnapshotSizeByInstance() unexpected error: %v", err)
+	}
+
+	if summary.Total != 65 || summary.Count != 4 {
+		t.Errorf("expected total 65 over 4 snapshots, got %d over %d", summary.Total, summary.Count)
+	}
+	if summary.ByInstance["vm-1"] != 40 || summary.ByInstance["vm-2"] != 20 || summary.ByInstance[""] != 5 {
+		t.Errorf("unexpected per-instance subtotals %v", summary.ByInstance)
+	}
+	if strings.Join(offsets, ",") != "0,2,4" {
+		t.Errorf("expected pages at offsets 0,2,4, got %v", offsets)
+	}
+
+	offsets = nil
+	total, err := client.Snapshots().TotalSnapshotSize(context.Background(), ListOptions{Limit: intPtr(2)})
+	if err != nil || total != 65 {
+		t.Errorf("TotalSnapshotSize() = %d, %v, want 65", total, err)
+	}
+}
+
+func TestSnapshotService_ListPage(t *testing.T) {
+	var offsets []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("_limit") != "2" {
+			t.Errorf("expected _limit=2, got %q", r.URL.Query().Get("_limit"))
+		}
+		if r.URL.Query().Get("_sort") != "name:asc" {
+			t.Errorf("expected _sort to carry over to every page, got %q", r.URL.Query().Get("_sort"))
+		}
+		offset := r.URL.Query().Get("_offset")
+		offsets = append(offsets, offset)
+
+		w.Header().Set("Content-Type", "application/json")
+		switch offset {
+		case "0":
+			w.Write([]byte(`{"snapshots": [{"id": "s1"}, {"id": "s2"}]}`))
+		case "2":
+			w.Write([]byte(`{"snapshots": [{"id": "s3"}]}`))
+		default:
+			t.Errorf("unexpected page at offset %s", offset)
+		}
+	}))
+	defer server.Close()
+
+	client := testClient(server.URL)
+	page, err := client.Snapshots().ListPage(context.Background(), ListOptions{Limit: intPtr(2), Sort: strPtr("name:asc")})
+	if err != nil {
+		t.Fatalf("ListPage() unexpected error: %v", err)
+	}
+
+	var ids []string
+	for page != nil {
+		for _, snapshot := range page.Items {
+			ids = append(ids, snapshot.ID)
+		}
+		if page.Meta.Count != len(page.Items) {
+			t.Errorf("expected Meta.Count %d, got %d", len(page.Items), page.Meta.Count)
+		}
+		page, err = page.Next(context.Background())
+		if err != nil {
+			t.Fatalf("Next() unexpected error: %v", err)
+		}
+	}
+
+	if strings.Join(ids, ",") != "s1,s2,s3" {
+		t.Errorf("expected s1,s2,s3, got %v", ids)
+	}
+	if strings.Join(offsets, ",") != "0,2" {
+		t.Errorf("expected pages at offsets 0,2, got %v", offsets)
+	}
+}
+
+func TestSnapshotService_ListByTag(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		value   string
+		wantIDs []string
+	}{
+		{name: "key and value across pages", key: "env", value: "prod", wantIDs: []string{"s1", "s3"}},
+		{name: "bare key", key: "critical", wantIDs: []string{"s2"}},
+		{name: "no match", key: "env", value: "staging", wantIDs: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch r.URL.Query().Get("_offset") {
+				case "0":
+					w.Write([]byte(`{"snapshots": [
+						{"id": "s1", "labels": ["env=prod", "app=web"]},
+						{"id": "s2", "labels": ["env=dev", "critical"]}]}`))
+				case "2":
+					w.Write([]byte(`{"snapshots": [{"id": "s3", "labels": ["env=prod"]}, {"id": "s4"}]}`))
+				default:
+					w.Write([]byte(`{"snapshots": []}`))
+				}
+			}))
+			defer server.Close()
+
+			got, err := testClient(server.URL).Snapshots().ListByTag(context.Background(), tt.key, tt.value, ListOptions{Limit: intPtr(2)})
+			if err != nil {
+				t.Fatalf("ListByTag() unexpected error: %v", err)
+			}
+			if got == nil {
+				t.Fatal("ListByTag() expected a non-nil slice")
+			}
+
+			ids := []string{}
+			for _, snapshot := range got {
+				ids = append(ids, snapshot.ID)
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.wantIDs, ",") {
+				t.Errorf("ListByTag() got %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+
+	if _, err := testClient("http://dummy").Snapshots().ListByTag(context.Background(), "", "prod", ListOptions{}); err == nil {
+		t.Error("ListByTag() expected an error for an empty key")
+	}
+}
+
+func TestSnapshotService_List_Fields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("_fields"); got != "id,name,size" {
+			t.Errorf("expected _fields=id,name,size, got %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"snapshots": [{"id": "s1", "name": "nightly", "size": 40}]}`))
+	}))
+	defer server.Close()
+
+	snapshots, err := testClient(server.URL).Snapshots().List(context.Background(), ListOptions{Fields: []string{"name", "size"}})
+	if err != nil {
+		t.Fatalf("List() unexpected error: %v", err)
+	}
+	if len(snapshots) != 1 {
+		t.Fatalf("expected 1 snapshot, got %d", len(snapshots))
+	}
+
+	got := snapshots[0]
+	if got.ID != "s1" || got.Name != "nightly" || got.Size != 40 {
+		t.Errorf("unexpected selected fields %+v", got)
+	}
+	if got.Status != "" || !got.CreatedAt.IsZero() || got.Instance != nil || got.RawExtra != nil {
+		t.Errorf("expected unselected fields to stay zero, got %+v", got)
+	}
+}
+
+func TestSnapshotService_Helpers_RequiredFields(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query().Get("_fields"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"snapshots": [{"id": "s1", "name": "nightly", "size": 40,
+			"labels": ["env=prod"], "instance": {"id": "vm-1"}}]}`))
+	}))
+	defer server.Close()
+
+	svc := testClient(server.URL).Snapshots()
+	opts := ListOptions{Fields: []string{"name"}}
+
+	tagged, err := svc.ListByTag(context.Background(), "env", "prod", opts)
+	if err != nil {
+		t.Fatalf("ListByTag() unexpected error: %v", err)
+	}
+	if len(tagged) != 1 {
+		t.Errorf("expected 1 tagged snapshot, got %d", len(tagged))
+	}
+
+	summary, err := svc.SnapshotSizeByInstance(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("SnapshotSizeByInstance() unexpected error: %v", err)
+	}
+	if summary.Total != 40 || summary.ByInstance["vm-1"] != 40 {
+		t.Errorf("unexpected summary %+v", summary)
+	}
+
+	want := []string{"id,labels,name", "id,size,instance,name"}
+	if !slices.Equal(queries, want) {
+		t.Errorf("_fields = %q, want %q", queries, want)
+	}
+	if len(opts.Fields) != 1 {
+		t.Errorf("expected the caller's Fields to stay unchanged, got %v", opts.Fields)
+	}
+}
+
+func TestSnapshot_UnmarshalJSON_RawExtra(t *testing.T) {
+	var snapshot Snapshot
+	data := `{"id": "snap-1", "size": 10, "labels": ["locked=true"], "encrypted": true, "retention": {"days": 7}}`
+	if err := json.Unmarshal([]byte(data), &snapshot); err != nil {
+		t.Fatalf("Unmarshal() unexpected error: %v", err)
+	}
+
+	if snapshot.ID != "snap-1" || snapshot.Size != 10 || !snapshot.IsLocked() {
+		t.Errorf("known fields not decoded: %+v", snapshot)
+	}
+	if len(snapshot.RawExtra) != 2 {
+		t.Fatalf("expected 2 extra fields, got %v", snapshot.RawExtra)
+	}
+	if string(snapshot.RawExtra["encrypted"]) != "true" || string(snapshot.RawExtra["retention"]) != `{"days": 7}` {
+		t.Errorf("unexpected extra fields %v", snapshot.RawExtra)
+	}
+}
+
+func TestSnapshot_UnmarshalJSON_Timestamps(t *testing.T) {
+	var snapshot Snapshot
+	data := `{"id": "snap-1", "created_at": "2024-01-02T12:00:00", "updated_at": "2024-01-02T12:30:00.5-03:00"}`
+	if err := json.Unmarshal([]byte(data), &snapshot); err != nil {
+		t.Fatalf("Unmarshal() unexpected error: %v", err)
+	}
+
+	if want := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC); !snapshot.CreatedAt.Equal(want) {
+		t.Errorf("CreatedAt = %v, want %v", snapshot.CreatedAt, want)
+	}
+	if want := time.Date(2024, 1, 2, 15, 30, 0, 5e8, time.UTC); snapshot.UpdatedAt == nil || !snapshot.UpdatedAt.Equal(want) {
+		t.Errorf("UpdatedAt = %v, want %v", snapshot.UpdatedAt, want)
+	}
+	if snapshot.RawExtra != nil {
+		t.Errorf("expected no extra fields, got %v", snapshot.RawExtra)
+	}
+
+	var unset Snapshot
+	if err := json.Unmarshal([]byte(`{"id": "snap-1", "updated_at": null}`), &unset); err != nil {
+		t.Fatalf("Unmarshal() unexpected error: %v", err)
+	}
+	if !unset.CreatedAt.IsZero() || unset.UpdatedAt != nil {
+		t.Errorf("expected unset timestamps to stay zero, got %v and %v", unset.CreatedAt, unset.UpdatedAt)
+	}
+}
+
+func TestSnapshotService_PathTemplate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": "snap-123", "status": "completed"}`))
+	}))
+	defer server.Close()
+
+	var requests []client.RequestInfo
+	core := client.NewMgcClient("test-api",
+		client.WithBaseURL(client.MgcUrl(server.URL)),
+		client.WithRequestHook(func(info client.RequestInfo) { requests = append(requests, info) }))
+	svc := New(core).Snapshots()
+
+	if _, err := svc.Get(context.Background(), "snap-123", nil); err != nil {
+		t.Fatalf("Get() unexpected error: %v", err)
+	}
+	if err := svc.Rename(context.Background(), "snap-123", "renamed"); err != nil {
+		t.Fatalf("Rename() unexpected error: %v", err)
+	}
+
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(requests))
+	}
+	if got := requests[0].PathTemplate; got != "/compute/v1/snapshots/{id}" {
+		t.Errorf("Get path template = %q", got)
+	}
+	if !strings.HasSuffix(requests[0].URL, "/compute/v1/snapshots/snap-123") {
+		t.Errorf("Get URL = %q", requests[0].URL)
+	}
+	if got := requests[1].PathTemplate; got != "/compute/v1/snapshots/{id}/rename" {
+		t.Errorf("Rename path template = %q", got)
+	}
+}
+
+func TestSnapshotService_CreateAndWait(t *testing.T) {
+	tests := []struct {
+		name         string
+		cleanup      bool
+		deleteStatus int
+		wantDelete   bool
+		wantCleanErr bool
+	}{
+		{name: "cleans up after timeout", cleanup: true, deleteStatus: http.StatusNoContent, wantDelete: true},
+		{name: "reports failed cleanup", cleanup: true, deleteStatus: http.StatusConflict, wantDelete: true, wantCleanErr: true},
+		{name: "keeps snapshot without cleanup", deleteStatus: http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			deleted := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch r.Method {
+				case http.MethodPost:
+					w.Write([]byte(`{"id": "snap-1"}`))
+				case http.MethodGet:
+					w.Write([]byte(`{"id": "snap-1", "status": "creating"}`))
+				case http.MethodDelete:
+					mu.Lock()
+					deleted = true
+					mu.Unlock()
+					w.WriteHeader(tt.deleteStatus)
+					if tt.deleteStatus != http.StatusNoContent {
+						w.Write([]byte(`{"message": "snapshot busy"}`))
+					}
+				}
+			}))
+			defer server.Close()
+
+			client := testClient(server.URL)
+			snapshot, err := client.Snapshots().CreateAndWait(context.Background(),
+				CreateSnapshotRequest{Name: "backup", Instance: IDOrName{ID: strPtr("vm-1")}},
+				SnapshotCreateOptions{
+					Wait:             SnapshotWaitOptions{Interval: 5 * time.Millisecond},
+					Timeout:          30 * time.Millisecond,
+					CleanupOnTimeout: tt.cleanup,
+				})
+
+			if snapshot != nil {
+				t.Errorf("expected no snapshot, got %+v", snapshot)
+			}
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("CreateAndWait() error = %v, want context.DeadlineExceeded", err)
+			}
+			if got := strings.Contains(err.Error(), "clean up snapshot snap-1"); got != tt.wantCleanErr {
+				t.Errorf("cleanup error reported = %v, want %v (%v)", got, tt.wantCleanErr, err)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if deleted != tt.wantDelete {
+				t.Errorf("deleted = %v, want %v", deleted, tt.wantDelete)
+			}
+		})
+	}
+}
+
+func TestSnapshotService_EnsureSnapshot(t *testing.T) {
+	tests := []struct {
+		name        string
+		list        string
+		wantCreate  bool
+		wantID      string
+		wantErrText string
+	}{
+		{
+			name:   "existing snapshot is returned",
+			list:   `{"snapshots": [{"id": "other", "name": "nightly-old"}, {"id": "snap-1", "name": "nightly", "status": "completed", "instance": {"id": "vm-1"}}]}`,
+			wantID: "snap-1",
+		},
+		{
+			name:       "missing snapshot is created and waited for",
+			list:       `{"snapshots": [{"id": "other", "name": "nightly-old"}]}`,
+			wantCreate: true,
+			wantID:     "snap-new",
+		},
+		{
+			name:        "snapshot of another instance",
+			list:        `{"snapshots": [{"id": "snap-1", "name": "nightly", "status": "completed", "instance": {"id": "vm-2"}}]}`,
+			wantErrText: "already exists for instance vm-2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			created := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/compute/v1/snapshots":
+					if r.URL.Query().Get("_offset") != "0" {
+						w.Write([]byte(`{"snapshots": []}`))
+						return
+					}
+					w.Write([]byte(tt.list))
+				case r.Method == http.MethodPost:
+					created = true
+					if r.Header.Get("Idempotency-Key") == "" {
+						t.Error("expected create to carry an Idempotency-Key header")
+					}
+					var body CreateSnapshotRequest
+					json.NewDecoder(r.Body).Decode(&body)
+					if body.Name != "nightly" || body.Instance.ID == nil || *body.Instance.ID != "vm-1" {
+						t.Errorf("unexpected create body %+v", body)
+					}
+					w.Write([]byte(`{"id": "snap-new"}`))
+				case r.Method == http.MethodGet && r.URL.Path == "/compute/v1/snapshots/snap-new":
+					if r.Header.Get("Idempotency-Key") != "" {
+						t.Error("polls should not carry the idempotency key")
+					}
+					w.Write([]byte(`{"id": "snap-new", "name": "nightly", "status": "completed"}`))
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+			}))
+			defer server.Close()
+
+			snapshot, err := testClient(server.URL).Snapshots().EnsureSnapshot(context.Background(), "nightly", IDOrName{ID: strPtr("vm-1")})
+			if tt.wantErrText != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrText) {
+					t.Fatalf("EnsureSnapshot() error = %v, want %q", err, tt.wantErrText)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("EnsureSnapshot() unexpected error: %v", err)
+			}
+			if snapshot.ID != tt.wantID {
+				t.Errorf("EnsureSnapshot() ID = %s, want %s", snapshot.ID, tt.wantID)
+			}
+			if created != tt.wantCreate {
+				t.Errorf("created = %v, want %v", created, tt.wantCreate)
+			}
+		})
+	}
+}
+
+func TestSnapshotService_GetByName_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"snapshots": []}`))
+	}))
+	defer server.Close()
+
+	_, err := testClient(server.URL).Snapshots().GetByName(context.Background(), "missing")
+	if !errors.Is(err, ErrSnapshotNotFound) {
+		t.Errorf("GetByName() error = %v, want ErrSnapshotNotFound", err)
+	}
+}
+
+func TestSnapshotService_DefaultPageSize(t *testing.T) {
+	var limits []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limits = append(limits, r.URL.Query().Get("_limit"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"snapshots": [{"id": "snap1"}]}`))
+	}))
+	defer server.Close()
+
+	core := client.NewMgcClient("test-api",
+		client.WithBaseURL(client.MgcUrl(server.URL)),
+		client.WithDefaultPageSize(20))
+	snapshots := New(core).Snapshots()
+
+	if _, err := snapshots.List(context.Background(), ListOptions{}); err != nil {
+		t.Fatalf("List() unexpected error: %v", err)
+	}
+	if _, err := snapshots.ListPage(context.Background(), ListOptions{}); err != nil {
+		t.Fatalf("ListPage() unexpected error: %v", err)
+	}
+	if _, err := snapshots.TotalSnapshotSize(context.Background(), ListOptions{}); err != nil {
+		t.Fatalf("TotalSnapshotSize() unexpected error: %v", err)
+	}
+	if _, err := snapshots.GetByName(context.Background(), "nightly"); !errors.Is(err, ErrSnapshotNotFound) {
+		t.Fatalf("GetByName() error = %v, want ErrSnapshotNotFound", err)
+	}
+	if _, err := snapshots.List(context.Background(), ListOptions{Limit: intPtr(5)}); err != nil {
+		t.Fatalf("List() unexpected error: %v", err)
+	}
+
+	want := "20,20,20,20,5"
+	if got := strings.Join(limits, ","); got != want {
+		t.Errorf("expected limits %s, got %s", want, got)
+	}
+}
+
+func TestSnapshotService_List_EmptySnapshots(t *testing.T) {
+	for _, body := range []string{`{"snapshots": null}`, `{"snapshots": []}`, `{}`} {
+		t.Run(body, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(body))
+			}))
+			defer server.Close()
+
+			got, err := testClient(server.URL).Snapshots().List(context.Background(), ListOptions{})
+			if err != nil {
+				t.Fatalf("List() unexpected error: %v", err)
+			}
+			if got == nil || len(got) != 0 {
+				t.Errorf("List() = %#v, want empty non-nil slice", got)
 			}
 		})
 	}
@@ -0,0 +1,37 @@
+package compute
+
+import (
+	"context"
+
+	"github.com/MagaluCloud/mgc-sdk-go/availabilityzones"
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+	"github.com/MagaluCloud/mgc-sdk-go/internal/utils"
+)
+
+// regionZones returns the unblocked availability zones of the region requests are sent to.
+// The availability zones service is global, so it is reached on the host of the regional
+// base URL without the region code, keeping the client's own base URL untouched.
+func (c *VirtualMachineClient) regionZones(ctx context.Context) ([]string, error) {
+	baseURL := client.RequestBaseURL(ctx)
+	if baseURL == "" {
+		baseURL = c.GetConfig().BaseURL
+	}
+
+	region, globalURL, err := utils.SplitRegionURL(baseURL.String())
+	if err != nil {
+		return nil, err
+	}
+	ctx = client.WithRequestBaseURL(ctx, client.MgcUrl(globalURL))
+
+	azClient := &availabilityzones.Client{CoreClient: c.CoreClient}
+	zones, err := azClient.Regions().Zones(ctx, region)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(zones))
+	for i, zone := range zones {
+		ids[i] = zone.ID
+	}
+	return ids, nil
+}
@@ -4,6 +4,8 @@ package containerregistry
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/MagaluCloud/mgc-sdk-go/client"
@@ -17,6 +19,8 @@ const (
 // ContainerRegistryClient represents a client for the Container Registry service
 type ContainerRegistryClient struct {
 	*client.CoreClient
+
+	registryEndpoint string
 }
 
 // ClientOption is a function type for configuring ContainerRegistryClient options
@@ -60,3 +64,24 @@ func (c *ContainerRegistryClient) Repositories() RepositoriesService {
 func (c *ContainerRegistryClient) Images() ImagesService {
 	return &imagesService{client: c}
 }
+
+// Ping verifies connectivity and credentials against the Container Registry API.
+// It issues a single GET to the credentials endpoint (/container-registry/v0/credentials),
+// which is authenticated and does not modify any state.
+// A nil error means the API answered successfully. Rejected credentials (401/403) are
+// reported as client.ErrAuthentication, any other failure as client.ErrConnectivity.
+// Both can be checked with errors.Is and still wrap the underlying error.
+func (c *ContainerRegistryClient) Ping(ctx context.Context) error {
+	_, err := c.Credentials().Get(ctx)
+	if err == nil {
+		return nil
+	}
+
+	var httpErr *client.HTTPError
+	if errors.As(err, &httpErr) &&
+		(httpErr.StatusCode == http.StatusUnauthorized || httpErr.StatusCode == http.StatusForbidden) {
+		return fmt.Errorf("%w: %w", client.ErrAuthentication, err)
+	}
+
+	return fmt.Errorf("%w: %w", client.ErrConnectivity, err)
+}
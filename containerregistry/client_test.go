@@ -2,8 +2,11 @@ package containerregistry
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/MagaluCloud/mgc-sdk-go/client"
 )
@@ -198,3 +201,70 @@ func TestNew_WithOptions(t *testing.T) {
 		t.Error("expected client to not be nil")
 	}
 }
+
+func TestContainerRegistryClient_Ping(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		closed     bool
+		wantErr    error
+	}{
+		{
+			name:       "success",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "unauthorized",
+			statusCode: http.StatusUnauthorized,
+			wantErr:    client.ErrAuthentication,
+		},
+		{
+			name:       "forbidden",
+			statusCode: http.StatusForbidden,
+			wantErr:    client.ErrAuthentication,
+		},
+		{
+			name:       "server error",
+			statusCode: http.StatusInternalServerError,
+			wantErr:    client.ErrConnectivity,
+		},
+		{
+			name:    "unreachable server",
+			closed:  true,
+			wantErr: client.ErrConnectivity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/container-registry/v0/credentials" {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.statusCode)
+				w.Write([]byte(`{"username": "user", "password": "pass", "email": "user@example.com"}`))
+			}))
+			if tt.closed {
+				server.Close()
+			} else {
+				defer server.Close()
+			}
+
+			core := client.NewMgcClient("test-api",
+				client.WithBaseURL(client.MgcUrl(server.URL)),
+				client.WithRetryConfig(1, time.Millisecond, time.Millisecond, 1))
+			err := New(core).Ping(context.Background())
+
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("Ping() unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Ping() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
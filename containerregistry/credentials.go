@@ -2,8 +2,12 @@ package containerregistry
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"sync"
 
+	"github.com/MagaluCloud/mgc-sdk-go/client"
 	mgc_http "github.com/MagaluCloud/mgc-sdk-go/internal/http"
 )
 
@@ -12,6 +16,7 @@ type (
 	CredentialsService interface {
 		Get(ctx context.Context) (*CredentialsResponse, error)
 		ResetPassword(ctx context.Context) (*CredentialsResponse, error)
+		RegistryEndpoint(ctx context.Context) (string, error)
 	}
 
 	// credentialsService implements the CredentialsService interface
@@ -19,14 +24,124 @@ type (
 		client *ContainerRegistryClient
 	}
 
-	// CredentialsResponse represents the response containing registry credentials
+	// CredentialsResponse represents the response containing registry credentials.
+	// Printing it with fmt redacts Password, which stays readable through the field.
 	CredentialsResponse struct {
 		Username string `json:"username"`
 		Password string `json:"password"`
 		Email    string `json:"email"`
 	}
+
+	// CredentialsOperation is a registry operation (docker login, push, pull...) that uses
+	// the given credentials. It should return an error wrapping client.ErrAuthentication,
+	// or a *client.HTTPError with status 401, when the registry rejects the credentials.
+	CredentialsOperation func(ctx context.Context, creds *CredentialsResponse) error
+
+	// AutoRefreshCredentials caches registry credentials and refetches them once when an
+	// operation fails with 401, which happens after another process calls ResetPassword.
+	// It is safe for concurrent use.
+	AutoRefreshCredentials struct {
+		service CredentialsService
+
+		mu    sync.Mutex
+		creds *CredentialsResponse
+	}
 )
 
+// redactedPassword replaces the password when credentials are printed
+const redactedPassword = "***"
+
+// String implements fmt.Stringer, redacting the password so %v and %+v are safe to log.
+func (c CredentialsResponse) String() string {
+	return fmt.Sprintf("{Username:%q Password:%q Email:%q}", c.Username, redactedPassword, c.Email)
+}
+
+// GoString implements fmt.GoStringer, redacting the password from %#v.
+func (c CredentialsResponse) GoString() string {
+	return fmt.Sprintf("containerregistry.CredentialsResponse{Username:%q, Password:%q, Email:%q}",
+		c.Username, redactedPassword, c.Email)
+}
+
+// Zero clears the password once the caller is done with the credentials, so the response
+// no longer references it. Go strings are immutable, so the bytes themselves cannot be
+// overwritten; they are released to the garbage collector instead. Do not zero credentials
+// obtained from AutoRefreshCredentials, which keeps using them for later operations.
+func (c *CredentialsResponse) Zero() {
+	c.Password = ""
+}
+
+// NewAutoRefreshCredentials creates an AutoRefreshCredentials that fetches credentials from service
+func NewAutoRefreshCredentials(service CredentialsService) *AutoRefreshCredentials {
+	return &AutoRefreshCredentials{service: service}
+}
+
+// Credentials returns the cached credentials, fetching them on first use
+func (a *AutoRefreshCredentials) Credentials(ctx context.Context) (*CredentialsResponse, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.creds != nil {
+		return a.creds, nil
+	}
+
+	creds, err := a.service.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	a.creds = creds
+	return creds, nil
+}
+
+// Do runs op with the cached credentials. If op fails because the credentials were
+// rejected, the credentials are refetched once and op is retried a single time;
+// a second rejection is returned to the caller.
+func (a *AutoRefreshCredentials) Do(ctx context.Context, op CredentialsOperation) error {
+	creds, err := a.Credentials(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = op(ctx, creds)
+	if !isUnauthorized(err) {
+		return err
+	}
+
+	creds, err = a.refresh(ctx, creds)
+	if err != nil {
+		return err
+	}
+	return op(ctx, creds)
+}
+
+// refresh refetches the credentials unless another caller already replaced stale ones
+func (a *AutoRefreshCredentials) refresh(ctx context.Context, stale *CredentialsResponse) (*CredentialsResponse, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.creds != nil && a.creds != stale {
+		return a.creds, nil
+	}
+
+	creds, err := a.service.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	a.creds = creds
+	return creds, nil
+}
+
+// isUnauthorized reports whether err means the registry rejected the credentials
+func isUnauthorized(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, client.ErrAuthentication) {
+		return true
+	}
+	var httpErr *client.HTTPError
+	return errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusUnauthorized
+}
+
 // Get retrieves the current container registry credentials
 func (c *credentialsService) Get(ctx context.Context) (*CredentialsResponse, error) {
 	path := "/v0/credentials"
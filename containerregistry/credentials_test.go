@@ -2,9 +2,11 @@ package containerregistry
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/MagaluCloud/mgc-sdk-go/client"
@@ -334,3 +336,109 @@ func TestCredentialsService_InvalidServerResponses(t *testing.T) {
 		})
 	}
 }
+
+func TestAutoRefreshCredentials_Do(t *testing.T) {
+	tests := []struct {
+		name         string
+		rejections   int
+		wantErr      error
+		wantGetCalls int
+		wantOpCalls  int
+		wantLastPass string
+	}{
+		{name: "success without refresh", rejections: 0, wantGetCalls: 1, wantOpCalls: 1, wantLastPass: "pass-1"},
+		{name: "401 then success", rejections: 1, wantGetCalls: 2, wantOpCalls: 2, wantLastPass: "pass-2"},
+		{name: "refreshes only once", rejections: 2, wantErr: client.ErrAuthentication, wantGetCalls: 2, wantOpCalls: 2, wantLastPass: "pass-2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getCalls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				getCalls++
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				fmt.Fprintf(w, `{"username": "user", "password": "pass-%d"}`, getCalls)
+			}))
+			defer server.Close()
+
+			refresher := NewAutoRefreshCredentials(testClient(server.URL).Credentials())
+
+			opCalls := 0
+			lastPass := ""
+			err := refresher.Do(context.Background(), func(ctx context.Context, creds *CredentialsResponse) error {
+				opCalls++
+				lastPass = creds.Password
+				if opCalls <= tt.rejections {
+					return fmt.Errorf("registry login: %w", client.ErrAuthentication)
+				}
+				return nil
+			})
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Do() error = %v, want %v", err, tt.wantErr)
+			}
+			if getCalls != tt.wantGetCalls {
+				t.Errorf("expected %d credential fetches, got %d", tt.wantGetCalls, getCalls)
+			}
+			if opCalls != tt.wantOpCalls {
+				t.Errorf("expected %d operation calls, got %d", tt.wantOpCalls, opCalls)
+			}
+			if lastPass != tt.wantLastPass {
+				t.Errorf("expected last password %q, got %q", tt.wantLastPass, lastPass)
+			}
+		})
+	}
+}
+
+func TestAutoRefreshCredentials_HTTPUnauthorized(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"username": "user", "password": "pass"}`))
+	}))
+	defer server.Close()
+
+	refresher := NewAutoRefreshCredentials(testClient(server.URL).Credentials())
+
+	opCalls := 0
+	err := refresher.Do(context.Background(), func(ctx context.Context, creds *CredentialsResponse) error {
+		opCalls++
+		if opCalls == 1 {
+			return &client.HTTPError{StatusCode: http.StatusUnauthorized, Status: "401 Unauthorized"}
+		}
+		return nil
+	})
+
+	if err != nil {
+		t.Errorf("Do() unexpected error: %v", err)
+	}
+	if opCalls != 2 {
+		t.Errorf("expected 2 operation calls, got %d", opCalls)
+	}
+}
+
+func TestCredentialsResponse_Redaction(t *testing.T) {
+	creds := &CredentialsResponse{Username: "user", Password: "s3cret", Email: "user@example.com"}
+
+	for _, format := range []string{"%v", "%+v", "%#v", "%s"} {
+		for _, value := range []any{creds, *creds} {
+			got := fmt.Sprintf(format, value)
+			if strings.Contains(got, "s3cret") {
+				t.Errorf("Sprintf(%q) leaked the password: %s", format, got)
+			}
+			if !strings.Contains(got, `"user"`) || !strings.Contains(got, `"***"`) {
+				t.Errorf("Sprintf(%q) = %s, want the username and a redacted password", format, got)
+			}
+		}
+	}
+
+	if creds.Password != "s3cret" {
+		t.Errorf("expected the password to stay readable, got %q", creds.Password)
+	}
+
+	creds.Zero()
+	if creds.Password != "" || creds.Username != "user" {
+		t.Errorf("expected Zero to clear only the password, got %q/%q", creds.Username, creds.Password)
+	}
+}
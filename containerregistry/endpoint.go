@@ -0,0 +1,50 @@
+package containerregistry
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+	"github.com/MagaluCloud/mgc-sdk-go/internal/utils"
+)
+
+// registryHosts maps the region segment of an API base URL to the registry host
+// that docker and containerd authenticate against in that region.
+var registryHosts = map[string]string{
+	"br-se1": "container-registry.br-se1.magalu.cloud",
+	"br-ne1": "container-registry.br-ne1.magalu.cloud",
+}
+
+// WithRegistryEndpoint sets the registry host returned by RegistryEndpoint, for regions
+// or environments whose host cannot be derived from the API base URL.
+//
+// Example:
+//
+//	crClient := containerregistry.New(core, containerregistry.WithRegistryEndpoint("registry.example.com"))
+func WithRegistryEndpoint(host string) ClientOption {
+	return func(c *ContainerRegistryClient) {
+		c.registryEndpoint = host
+	}
+}
+
+// RegistryEndpoint returns the registry host the credentials authenticate against, e.g.
+// container-registry.br-se1.magalu.cloud, ready to pass to DockerConfigJSON or ImagePullSecret.
+// It is derived from the region of the base URL the request would use (see
+// client.WithRequestBaseURL) unless WithRegistryEndpoint set one; no request is made.
+func (c *credentialsService) RegistryEndpoint(ctx context.Context) (string, error) {
+	if c.client.registryEndpoint != "" {
+		return c.client.registryEndpoint, nil
+	}
+
+	baseURL := client.RequestBaseURL(ctx)
+	if baseURL == "" {
+		baseURL = c.client.GetConfig().BaseURL
+	}
+
+	if region, _, err := utils.SplitRegionURL(baseURL.String()); err == nil {
+		if host, ok := registryHosts[region]; ok {
+			return host, nil
+		}
+	}
+	return "", fmt.Errorf("no container registry endpoint is known for base URL %s; set one with WithRegistryEndpoint", baseURL)
+}
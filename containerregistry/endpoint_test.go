@@ -0,0 +1,65 @@
+package containerregistry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+)
+
+func TestCredentialsService_RegistryEndpoint(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL client.MgcUrl
+		ctx     context.Context
+		opts    []ClientOption
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "default region",
+			baseURL: client.BrSe1,
+			ctx:     context.Background(),
+			want:    "container-registry.br-se1.magalu.cloud",
+		},
+		{
+			name:    "northeast region",
+			baseURL: client.BrNe1,
+			ctx:     context.Background(),
+			want:    "container-registry.br-ne1.magalu.cloud",
+		},
+		{
+			name:    "per-request base URL",
+			baseURL: client.BrSe1,
+			ctx:     client.WithRequestBaseURL(context.Background(), client.BrNe1),
+			want:    "container-registry.br-ne1.magalu.cloud",
+		},
+		{
+			name:    "explicit endpoint",
+			baseURL: "http://127.0.0.1:8080",
+			ctx:     context.Background(),
+			opts:    []ClientOption{WithRegistryEndpoint("registry.example.com")},
+			want:    "registry.example.com",
+		},
+		{
+			name:    "unknown base URL",
+			baseURL: "http://127.0.0.1:8080",
+			ctx:     context.Background(),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core := client.NewMgcClient("test-api", client.WithBaseURL(tt.baseURL))
+			got, err := New(core, tt.opts...).Credentials().RegistryEndpoint(tt.ctx)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RegistryEndpoint() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("RegistryEndpoint() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
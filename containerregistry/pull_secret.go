@@ -0,0 +1,77 @@
+package containerregistry
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+	"github.com/MagaluCloud/mgc-sdk-go/internal/utils"
+)
+
+// DockerConfigJSONSecretType is the Kubernetes Secret type for image-pull credentials
+const DockerConfigJSONSecretType = "kubernetes.io/dockerconfigjson"
+
+type (
+	// dockerConfig is the layout of a Docker config.json holding registry auths
+	dockerConfig struct {
+		Auths map[string]dockerAuth `json:"auths"`
+	}
+
+	dockerAuth struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+		Email    string `json:"email,omitempty"`
+		Auth     string `json:"auth"`
+	}
+
+	// secretManifest is the subset of a Kubernetes Secret needed for image pulls
+	secretManifest struct {
+		APIVersion string            `json:"apiVersion"`
+		Kind       string            `json:"kind"`
+		Metadata   secretMetadata    `json:"metadata"`
+		Type       string            `json:"type"`
+		Data       map[string]string `json:"data"`
+	}
+
+	secretMetadata struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace,omitempty"`
+	}
+)
+
+// DockerConfigJSON returns a Docker config.json that authenticates against registryHost
+// with these credentials, as used by docker login and Kubernetes image-pull secrets.
+func (c *CredentialsResponse) DockerConfigJSON(registryHost string) ([]byte, error) {
+	if registryHost == "" {
+		return nil, &client.ValidationError{Field: "registryHost", Message: utils.CannotBeEmpty}
+	}
+
+	auth := base64.StdEncoding.EncodeToString([]byte(c.Username + ":" + c.Password))
+	return json.Marshal(dockerConfig{
+		Auths: map[string]dockerAuth{
+			registryHost: {Username: c.Username, Password: c.Password, Email: c.Email, Auth: auth},
+		},
+	})
+}
+
+// ImagePullSecret returns a Kubernetes Secret manifest of type kubernetes.io/dockerconfigjson
+// that lets a cluster pull images from registryHost. The manifest is JSON, which kubectl apply
+// accepts like YAML. An empty namespace leaves the Secret in the namespace it is applied to.
+func (c *CredentialsResponse) ImagePullSecret(name, namespace, registryHost string) ([]byte, error) {
+	if name == "" {
+		return nil, &client.ValidationError{Field: "name", Message: utils.CannotBeEmpty}
+	}
+
+	config, err := c.DockerConfigJSON(registryHost)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.MarshalIndent(secretManifest{
+		APIVersion: "v1",
+		Kind:       "Secret",
+		Metadata:   secretMetadata{Name: name, Namespace: namespace},
+		Type:       DockerConfigJSONSecretType,
+		Data:       map[string]string{".dockerconfigjson": base64.StdEncoding.EncodeToString(config)},
+	}, "", "  ")
+}
@@ -0,0 +1,91 @@
+package containerregistry
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+)
+
+func TestCredentialsResponse_ImagePullSecret(t *testing.T) {
+	creds := &CredentialsResponse{Username: "user", Password: "s3cret", Email: "user@example.com"}
+
+	manifest, err := creds.ImagePullSecret("regcred", "apps", "registry.example.com")
+	if err != nil {
+		t.Fatalf("ImagePullSecret() unexpected error: %v", err)
+	}
+
+	var secret struct {
+		APIVersion string `json:"apiVersion"`
+		Kind       string `json:"kind"`
+		Metadata   struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"metadata"`
+		Type string            `json:"type"`
+		Data map[string]string `json:"data"`
+	}
+	if err := json.Unmarshal(manifest, &secret); err != nil {
+		t.Fatalf("manifest is not valid JSON: %v", err)
+	}
+
+	if secret.APIVersion != "v1" || secret.Kind != "Secret" || secret.Type != "kubernetes.io/dockerconfigjson" {
+		t.Errorf("unexpected header %s/%s of type %s", secret.APIVersion, secret.Kind, secret.Type)
+	}
+	if secret.Metadata.Name != "regcred" || secret.Metadata.Namespace != "apps" {
+		t.Errorf("unexpected metadata %+v", secret.Metadata)
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(secret.Data[".dockerconfigjson"])
+	if err != nil {
+		t.Fatalf(".dockerconfigjson is not base64: %v", err)
+	}
+	var config struct {
+		Auths map[string]struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+			Email    string `json:"email"`
+			Auth     string `json:"auth"`
+		} `json:"auths"`
+	}
+	if err := json.Unmarshal(raw, &config); err != nil {
+		t.Fatalf(".dockerconfigjson is not valid JSON: %v", err)
+	}
+
+	auth, ok := config.Auths["registry.example.com"]
+	if !ok || len(config.Auths) != 1 {
+		t.Fatalf("expected a single auth for registry.example.com, got %v", config.Auths)
+	}
+	if auth.Username != "user" || auth.Password != "s3cret" || auth.Email != "user@example.com" {
+		t.Errorf("unexpected auth %+v", auth)
+	}
+	if want := base64.StdEncoding.EncodeToString([]byte("user:s3cret")); auth.Auth != want {
+		t.Errorf("expected auth %q, got %q", want, auth.Auth)
+	}
+}
+
+func TestCredentialsResponse_ImagePullSecret_Validation(t *testing.T) {
+	creds := &CredentialsResponse{Username: "user", Password: "s3cret"}
+
+	tests := []struct {
+		name         string
+		secretName   string
+		registryHost string
+		wantField    string
+	}{
+		{name: "empty name", registryHost: "registry.example.com", wantField: "name"},
+		{name: "empty registry host", secretName: "regcred", wantField: "registryHost"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := creds.ImagePullSecret(tt.secretName, "", tt.registryHost)
+			var validationErr *client.ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Field != tt.wantField {
+				t.Errorf("expected a ValidationError on %s, got %v", tt.wantField, err)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	mgc_http "github.com/MagaluCloud/mgc-sdk-go/internal/http"
+	"github.com/MagaluCloud/mgc-sdk-go/internal/pagination"
 )
 
 type (
@@ -16,6 +17,7 @@ type (
 	RegistriesService interface {
 		Create(ctx context.Context, request *RegistryRequest) (*RegistryResponse, error)
 		List(ctx context.Context, opts ListOptions) (*ListRegistriesResponse, error)
+		ListAll(ctx context.Context, opts ListOptions) ([]RegistryResponse, error)
 		Get(ctx context.Context, registryID string) (*RegistryResponse, error)
 		Delete(ctx context.Context, registryID string) error
 	}
@@ -91,6 +93,39 @@ func (c *registriesService) List(ctx context.Context, opts ListOptions) (*ListRe
 	return res, nil
 }
 
+// ListAll returns every registry of the account, fetching opts.Limit registries per page
+// (the client's DefaultPageSize or 50 by default) starting at opts.Offset.
+// Sort and Expand apply to each page.
+func (c *registriesService) ListAll(ctx context.Context, opts ListOptions) ([]RegistryResponse, error) {
+	p := pagination.Paginator[RegistryResponse]{
+		Fetch: func(ctx context.Context, offset, limit int) ([]RegistryResponse, error) {
+			pageOpts := opts
+			pageOpts.Offset = &offset
+			pageOpts.Limit = &limit
+			res, err := c.List(ctx, pageOpts)
+			if err != nil {
+				return nil, err
+			}
+			return res.Registries, nil
+		},
+	}
+	if limit := c.client.GetConfig().PageLimit(opts.Limit); limit != nil {
+		p.PageSize = *limit
+	}
+	if opts.Offset != nil {
+		p.Offset = *opts.Offset
+	}
+
+	registries := []RegistryResponse{}
+	for registry, err := range p.All(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		registries = append(registries, registry)
+	}
+	return registries, nil
+}
+
 // Get retrieves a specific container registry by ID
 func (c *registriesService) Get(ctx context.Context, registryID string) (*RegistryResponse, error) {
 	path := fmt.Sprintf("/v0/registries/%s", registryID)
@@ -286,6 +286,35 @@ func TestRegistriesService_List(t *testing.T) {
 	}
 }
 
+func TestRegistriesService_ListAll(t *testing.T) {
+	var offsets []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offsets = append(offsets, r.URL.Query().Get("_offset"))
+		if r.URL.Query().Get("_limit") != "2" {
+			t.Errorf("expected _limit=2, got %q", r.URL.Query().Get("_limit"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("_offset") {
+		case "0":
+			w.Write([]byte(`{"results": [{"id": "reg-1", "name": "a"}, {"id": "reg-2", "name": "b"}]}`))
+		default:
+			w.Write([]byte(`{"results": [{"id": "reg-3", "name": "c"}]}`))
+		}
+	}))
+	defer server.Close()
+
+	registries, err := testClient(server.URL).Registries().ListAll(context.Background(), ListOptions{Limit: intPtr(2)})
+	if err != nil {
+		t.Fatalf("ListAll() unexpected error: %v", err)
+	}
+	if len(registries) != 3 || registries[0].ID != "reg-1" || registries[2].ID != "reg-3" {
+		t.Errorf("expected reg-1..reg-3, got %+v", registries)
+	}
+	if len(offsets) != 2 || offsets[0] != "0" || offsets[1] != "2" {
+		t.Errorf("expected pages at offsets [0 2], got %v", offsets)
+	}
+}
+
 func TestRegistriesService_Get(t *testing.T) {
 	tests := []struct {
 		name       string
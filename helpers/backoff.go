@@ -0,0 +1,78 @@
+package helpers
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// Jitter is the strategy a Backoff uses to randomize its delays.
+type Jitter int
+
+const (
+	// NoJitter returns the exponential delay as is.
+	NoJitter Jitter = iota
+	// FullJitter returns a random delay between zero and the exponential delay.
+	FullJitter
+	// EqualJitter returns half the exponential delay plus a random part of the other half,
+	// so delays never drop below half of it.
+	EqualJitter
+)
+
+// Backoff computes the delays between attempts of a retry or poll loop.
+// The delay grows from Initial by Factor on every attempt, is capped at Max and is then
+// randomized according to Jitter. The zero value returns no delay.
+type Backoff struct {
+	// Initial is the delay before the first retry.
+	Initial time.Duration
+	// Max caps the delay before jitter is applied. Zero or negative means no cap.
+	Max time.Duration
+	// Factor is the growth per attempt. Values below 1 are treated as 1, a constant delay.
+	Factor float64
+	// Jitter selects how delays are randomized. Defaults to NoJitter.
+	Jitter Jitter
+	// Rand returns a number in [0, 1) used for jitter. Defaults to math/rand/v2's Float64;
+	// set it to make delays deterministic in tests.
+	Rand func() float64
+}
+
+// NextDelay returns the delay to wait before retrying after attempt, counted from zero:
+// attempt 0 waits Initial (before jitter), attempt 1 waits Initial*Factor and so on.
+// Negative attempts are treated as zero.
+func (b Backoff) NextDelay(attempt int) time.Duration {
+	if b.Initial <= 0 {
+		return 0
+	}
+
+	factor := max(b.Factor, 1)
+	delay := float64(b.Initial) * math.Pow(factor, float64(max(attempt, 0)))
+
+	limit := float64(math.MaxInt64)
+	if b.Max > 0 {
+		limit = float64(b.Max)
+	}
+	// Compare as float64 so large attempts cannot overflow time.Duration.
+	if delay >= limit {
+		delay = limit
+	}
+
+	switch b.Jitter {
+	case FullJitter:
+		delay *= b.random()
+	case EqualJitter:
+		delay = delay/2 + delay/2*b.random()
+	}
+
+	if delay >= float64(math.MaxInt64) {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(delay)
+}
+
+// random returns a jitter sample in [0, 1).
+func (b Backoff) random() float64 {
+	if b.Rand == nil {
+		return rand.Float64()
+	}
+	return min(max(b.Rand(), 0), math.Nextafter(1, 0))
+}
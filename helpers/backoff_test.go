@@ -0,0 +1,141 @@
+package helpers
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestBackoff_NextDelay(t *testing.T) {
+	tests := []struct {
+		name    string
+		backoff Backoff
+		attempt int
+		want    time.Duration
+	}{
+		{
+			name:    "first attempt waits initial",
+			backoff: Backoff{Initial: time.Second, Max: 30 * time.Second, Factor: 2},
+			attempt: 0,
+			want:    time.Second,
+		},
+		{
+			name:    "exponential growth",
+			backoff: Backoff{Initial: time.Second, Max: 30 * time.Second, Factor: 2},
+			attempt: 3,
+			want:    8 * time.Second,
+		},
+		{
+			name:    "fractional factor",
+			backoff: Backoff{Initial: 2 * time.Second, Max: time.Minute, Factor: 1.5},
+			attempt: 2,
+			want:    4500 * time.Millisecond,
+		},
+		{
+			name:    "capped at max",
+			backoff: Backoff{Initial: time.Second, Max: 30 * time.Second, Factor: 2},
+			attempt: 10,
+			want:    30 * time.Second,
+		},
+		{
+			name:    "factor below one is constant",
+			backoff: Backoff{Initial: time.Second, Factor: 0.5},
+			attempt: 5,
+			want:    time.Second,
+		},
+		{
+			name:    "zero factor is constant",
+			backoff: Backoff{Initial: 5 * time.Second},
+			attempt: 7,
+			want:    5 * time.Second,
+		},
+		{
+			name:    "negative attempt is treated as first",
+			backoff: Backoff{Initial: time.Second, Factor: 2},
+			attempt: -3,
+			want:    time.Second,
+		},
+		{
+			name:    "huge attempt without max does not overflow",
+			backoff: Backoff{Initial: time.Second, Factor: 2},
+			attempt: 1000,
+			want:    time.Duration(math.MaxInt64),
+		},
+		{
+			name:    "zero value",
+			attempt: 4,
+			want:    0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.backoff.NextDelay(tt.attempt); got != tt.want {
+				t.Errorf("NextDelay(%d) = %v, want %v", tt.attempt, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackoff_Jitter(t *testing.T) {
+	tests := []struct {
+		name   string
+		jitter Jitter
+		rand   float64
+		want   time.Duration
+	}{
+		{name: "full jitter low", jitter: FullJitter, rand: 0, want: 0},
+		{name: "full jitter mid", jitter: FullJitter, rand: 0.25, want: time.Second},
+		{name: "equal jitter low", jitter: EqualJitter, rand: 0, want: 2 * time.Second},
+		{name: "equal jitter mid", jitter: EqualJitter, rand: 0.5, want: 3 * time.Second},
+		{name: "no jitter ignores rand", jitter: NoJitter, rand: 0.5, want: 4 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := Backoff{Initial: time.Second, Factor: 2, Jitter: tt.jitter, Rand: func() float64 { return tt.rand }}
+			if got := b.NextDelay(2); got != tt.want {
+				t.Errorf("NextDelay(2) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackoff_JitterBounds(t *testing.T) {
+	base := Backoff{Initial: time.Second, Max: 8 * time.Second, Factor: 2}
+
+	tests := []struct {
+		name   string
+		jitter Jitter
+		lower  func(exp time.Duration) time.Duration
+	}{
+		{name: "full jitter", jitter: FullJitter, lower: func(time.Duration) time.Duration { return 0 }},
+		{name: "equal jitter", jitter: EqualJitter, lower: func(exp time.Duration) time.Duration { return exp / 2 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := base
+			b.Jitter = tt.jitter
+			for i := range 500 {
+				attempt := i % 6
+				exp := base.NextDelay(attempt)
+				if got := b.NextDelay(attempt); got < tt.lower(exp) || got > exp {
+					t.Fatalf("NextDelay(%d) = %v, want within [%v, %v]", attempt, got, tt.lower(exp), exp)
+				}
+			}
+		})
+	}
+}
+
+func TestBackoff_RandOutOfRangeIsClamped(t *testing.T) {
+	b := Backoff{Initial: time.Second, Jitter: FullJitter, Rand: func() float64 { return 1.5 }}
+	if got := b.NextDelay(0); got >= time.Second {
+		t.Errorf("NextDelay(0) = %v, want below %v", got, time.Second)
+	}
+
+	b.Rand = func() float64 { return -1 }
+	if got := b.NextDelay(0); got != 0 {
+		t.Errorf("NextDelay(0) = %v, want 0", got)
+	}
+}
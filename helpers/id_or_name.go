@@ -0,0 +1,28 @@
+package helpers
+
+// IDOrName references a resource by ID or by name, for APIs that accept either.
+// Services such as compute and blockstorage expose it under their own IDOrName name.
+type IDOrName struct {
+	ID   *string `json:"id,omitempty"`
+	Name *string `json:"name,omitempty"`
+}
+
+// ParseIDOrName builds an IDOrName from user input that may be either an ID or a name.
+// UUID-formatted strings are treated as IDs and everything else as names.
+func ParseIDOrName(s string) IDOrName {
+	if IsUUID(s) {
+		return ForceID(s)
+	}
+	return ForceName(s)
+}
+
+// ForceID builds an IDOrName that always refers to s as an ID.
+func ForceID(s string) IDOrName {
+	return IDOrName{ID: &s}
+}
+
+// ForceName builds an IDOrName that always refers to s as a name,
+// even when s looks like a UUID.
+func ForceName(s string) IDOrName {
+	return IDOrName{Name: &s}
+}
@@ -0,0 +1,47 @@
+package helpers
+
+import "testing"
+
+func TestParseIDOrName(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantID   string
+		wantName string
+	}{
+		{name: "uuid is an id", input: "123e4567-e89b-12d3-a456-426614174000", wantID: "123e4567-e89b-12d3-a456-426614174000"},
+		{name: "uppercase uuid is an id", input: "123E4567-E89B-12D3-A456-426614174000", wantID: "123E4567-E89B-12D3-A456-426614174000"},
+		{name: "plain name", input: "my-resource", wantName: "my-resource"},
+		{name: "name with spaces", input: "cloud-ubuntu-24.04 LTS", wantName: "cloud-ubuntu-24.04 LTS"},
+		{name: "truncated uuid is a name", input: "123e4567-e89b-12d3-a456", wantName: "123e4567-e89b-12d3-a456"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseIDOrName(tt.input)
+			if tt.wantID != "" {
+				if got.ID == nil || *got.ID != tt.wantID || got.Name != nil {
+					t.Errorf("ParseIDOrName(%q) = %+v, want ID %q", tt.input, got, tt.wantID)
+				}
+				return
+			}
+			if got.Name == nil || *got.Name != tt.wantName || got.ID != nil {
+				t.Errorf("ParseIDOrName(%q) = %+v, want Name %q", tt.input, got, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestForceIDAndForceName(t *testing.T) {
+	uuid := "123e4567-e89b-12d3-a456-426614174000"
+
+	byName := ForceName(uuid)
+	if byName.Name == nil || *byName.Name != uuid || byName.ID != nil {
+		t.Errorf("ForceName() = %+v, want Name %q", byName, uuid)
+	}
+
+	byID := ForceID("legacy-id")
+	if byID.ID == nil || *byID.ID != "legacy-id" || byID.Name != nil {
+		t.Errorf("ForceID() = %+v, want ID %q", byID, "legacy-id")
+	}
+}
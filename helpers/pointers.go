@@ -55,3 +55,13 @@ func Uint32Ptr(u uint32) *uint32 {
 func Uint64Ptr(u uint64) *uint64 {
 	return &u
 }
+
+// SlicePtr returns a pointer to s for optional collection fields such as Tags or Taints.
+// A nil s becomes an empty slice, so the field is sent as [] (e.g. to clear every tag)
+// rather than null; leave the field nil to omit it.
+func SlicePtr[T any](s []T) *[]T {
+	if s == nil {
+		s = []T{}
+	}
+	return &s
+}
@@ -155,3 +155,15 @@ func TestUint64Ptr(t *testing.T) {
 		t.Errorf("Expected %v, got %v", value, *ptr)
 	}
 }
+
+func TestSlicePtr(t *testing.T) {
+	ptr := SlicePtr([]string{"a", "b"})
+	if ptr == nil || len(*ptr) != 2 || (*ptr)[1] != "b" {
+		t.Errorf("Expected [a b], got %v", ptr)
+	}
+
+	empty := SlicePtr[string](nil)
+	if empty == nil || *empty == nil || len(*empty) != 0 {
+		t.Errorf("Expected a pointer to a non-nil empty slice, got %v", empty)
+	}
+}
@@ -33,14 +33,23 @@ func (q *queryParam) Add(name string, value *string) {
 }
 
 func (q *queryParam) AddReflect(name string, value any) {
-	if value != nil {
-		typeOf := reflect.TypeOf(value)
-		switch typeOf.Kind() {
-		case reflect.String:
-			q.query.Set(name, value.(string))
-		case reflect.Int:
-			q.query.Set(name, strconv.Itoa(value.(int)))
+	if value == nil {
+		return
+	}
+
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return
 		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		q.query.Set(name, v.String())
+	case reflect.Int:
+		q.query.Set(name, strconv.FormatInt(v.Int(), 10))
 	}
 }
 
@@ -232,6 +232,23 @@ func TestQueryParam_AddReflect(t *testing.T) {
 			t.Errorf("Esperado 'negative_param=-42', obtido '%s'", encoded)
 		}
 	})
+
+	t.Run("Adicionar ponteiros usando reflection", func(t *testing.T) {
+		req10, _ := http.NewRequest("GET", "http://example.com", nil)
+		qp10 := NewQueryParams(req10)
+
+		limit := 10
+		sort := "name:asc"
+		var nilLimit *int
+		qp10.AddReflect("_limit", &limit)
+		qp10.AddReflect("_sort", &sort)
+		qp10.AddReflect("_offset", nilLimit)
+
+		encoded := qp10.Encode()
+		if encoded != "_limit=10&_sort=name%3Aasc" {
+			t.Errorf("Esperado '_limit=10&_sort=name%%3Aasc', obtido '%s'", encoded)
+		}
+	})
 }
 
 func TestQueryParam_Encode(t *testing.T) {
@@ -0,0 +1,68 @@
+package helpers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// APITimeLayout is the layout used by the MGC APIs for timestamps: RFC 3339 in UTC.
+const APITimeLayout = time.RFC3339
+
+// apiTimeParseLayouts are the layouts accepted when decoding timestamps.
+// Some endpoints return fractional seconds or omit the zone, which is then assumed to be UTC.
+var apiTimeParseLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+}
+
+// APITime is a timestamp that marshals to and from the MGC API layout.
+// Marshaling always emits APITimeLayout in UTC, so values built in any
+// location are sent in the format the API expects.
+type APITime time.Time
+
+// NewAPITime converts a time.Time to APITime.
+func NewAPITime(t time.Time) APITime {
+	return APITime(t)
+}
+
+// ParseAPITime parses a timestamp in any layout accepted by the MGC APIs.
+func ParseAPITime(s string) (APITime, error) {
+	for _, layout := range apiTimeParseLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return APITime(t), nil
+		}
+	}
+	return APITime{}, fmt.Errorf("invalid API time %q: expected layout %s", s, APITimeLayout)
+}
+
+// Time returns the value as a time.Time.
+func (t APITime) Time() time.Time {
+	return time.Time(t)
+}
+
+// String returns the timestamp formatted with APITimeLayout in UTC.
+func (t APITime) String() string {
+	return time.Time(t).UTC().Format(APITimeLayout)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t APITime) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + t.String() + `"`), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// A JSON null leaves the value unchanged.
+func (t *APITime) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+
+	parsed, err := ParseAPITime(strings.Trim(s, `"`))
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
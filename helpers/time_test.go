@@ -0,0 +1,121 @@
+package helpers
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestAPITime_MarshalJSON(t *testing.T) {
+	loc := time.FixedZone("BRT", -3*60*60)
+	tests := []struct {
+		name string
+		in   time.Time
+		want string
+	}{
+		{
+			name: "utc time",
+			in:   time.Date(2024, time.January, 2, 12, 34, 56, 0, time.UTC),
+			want: `"2024-01-02T12:34:56Z"`,
+		},
+		{
+			name: "non-utc time is converted",
+			in:   time.Date(2024, time.January, 2, 9, 34, 56, 0, loc),
+			want: `"2024-01-02T12:34:56Z"`,
+		},
+		{
+			name: "sub-second precision is dropped",
+			in:   time.Date(2024, time.January, 2, 12, 34, 56, 123456789, time.UTC),
+			want: `"2024-01-02T12:34:56Z"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(NewAPITime(tt.in))
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Marshal() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPITime_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name: "rfc3339",
+			in:   `"2024-01-02T12:34:56Z"`,
+			want: time.Date(2024, time.January, 2, 12, 34, 56, 0, time.UTC),
+		},
+		{
+			name: "rfc3339 with offset",
+			in:   `"2024-01-02T09:34:56-03:00"`,
+			want: time.Date(2024, time.January, 2, 12, 34, 56, 0, time.UTC),
+		},
+		{
+			name: "fractional seconds",
+			in:   `"2024-01-02T12:34:56.500Z"`,
+			want: time.Date(2024, time.January, 2, 12, 34, 56, 500000000, time.UTC),
+		},
+		{
+			name: "without zone",
+			in:   `"2024-01-02T12:34:56.000000"`,
+			want: time.Date(2024, time.January, 2, 12, 34, 56, 0, time.UTC),
+		},
+		{
+			name: "null",
+			in:   `null`,
+			want: time.Time{},
+		},
+		{
+			name:    "invalid layout",
+			in:      `"2024-01-02 12:34:56"`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got APITime
+			err := json.Unmarshal([]byte(tt.in), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Time().Equal(tt.want) {
+				t.Errorf("Unmarshal() got = %v, want %v", got.Time(), tt.want)
+			}
+		})
+	}
+}
+
+func TestAPITime_RoundTrip(t *testing.T) {
+	type payload struct {
+		At  APITime  `json:"at"`
+		Opt *APITime `json:"opt,omitempty"`
+	}
+
+	at := NewAPITime(time.Date(2024, time.June, 30, 23, 59, 59, 0, time.UTC))
+	in := payload{At: at, Opt: &at}
+
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var out payload
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if !out.At.Time().Equal(in.At.Time()) || out.Opt == nil || !out.Opt.Time().Equal(in.Opt.Time()) {
+		t.Errorf("round trip mismatch: got %+v, want %+v", out, in)
+	}
+}
@@ -0,0 +1,10 @@
+package helpers
+
+import "regexp"
+
+var uuidRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// IsUUID reports whether s is formatted as a UUID (8-4-4-4-12 hexadecimal digits).
+func IsUUID(s string) bool {
+	return uuidRegex.MatchString(s)
+}
@@ -0,0 +1,26 @@
+package helpers
+
+import "testing"
+
+func TestIsUUID(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"123e4567-e89b-12d3-a456-426614174000", true},
+		{"123E4567-E89B-12D3-A456-426614174000", true},
+		{"123e4567e89b12d3a456426614174000", false},
+		{"123e4567-e89b-12d3-a456-42661417400", false},
+		{"123e4567-e89b-12d3-a456-4266141740000", false},
+		{"cloud-ubuntu-24.04 LTS", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := IsUUID(tt.input); got != tt.want {
+				t.Errorf("IsUUID(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+// Package cache provides a small in-memory cache with per-entry expiry.
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/MagaluCloud/mgc-sdk-go/internal/utils"
+)
+
+// TTL caches values per key for a fixed duration after they are loaded.
+// It is safe for concurrent use. Failed loads are not cached.
+// Expiry follows the clock of the context passed to Get, see utils.WithClock.
+type TTL[K comparable, V any] struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[K]entry[V]
+}
+
+type entry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// New returns an empty cache whose entries expire ttl after being loaded.
+func New[K comparable, V any](ttl time.Duration) *TTL[K, V] {
+	return &TTL[K, V]{
+		ttl:     ttl,
+		entries: make(map[K]entry[V]),
+	}
+}
+
+// Get returns the cached value for key, calling load when it is missing or expired.
+// Concurrent misses for the same key may each call load; the last result wins.
+func (c *TTL[K, V]) Get(ctx context.Context, key K, load func(ctx context.Context) (V, error)) (V, error) {
+	clock := utils.ClockFrom(ctx)
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && clock.Now().Before(e.expiresAt) {
+		return e.value, nil
+	}
+
+	value, err := load(ctx)
+	if err != nil {
+		var zero V
+		return zero, err
+	}
+
+	c.mu.Lock()
+	c.entries[key] = entry[V]{value: value, expiresAt: clock.Now().Add(c.ttl)}
+	c.mu.Unlock()
+	return value, nil
+}
+
+// Invalidate drops the cached value for key.
+func (c *TTL[K, V]) Invalidate(key K) {
+	c.mu.Lock()
+	delete(c.entries, key)
+	c.mu.Unlock()
+}
+
+// InvalidateAll drops every cached value.
+func (c *TTL[K, V]) InvalidateAll() {
+	c.mu.Lock()
+	clear(c.entries)
+	c.mu.Unlock()
+}
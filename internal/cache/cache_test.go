@@ -0,0 +1,77 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/MagaluCloud/mgc-sdk-go/internal/utils"
+)
+
+func TestTTL_Get(t *testing.T) {
+	clock := utils.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	ctx := utils.WithClock(context.Background(), clock)
+	c := New[string, int](time.Minute)
+
+	loads := 0
+	load := func(context.Context) (int, error) {
+		loads++
+		return loads, nil
+	}
+
+	for range 3 {
+		if v, err := c.Get(ctx, "a", load); err != nil || v != 1 {
+			t.Fatalf("Get() = %d, %v, want cached 1", v, err)
+		}
+	}
+
+	clock.Advance(time.Minute)
+	if v, _ := c.Get(ctx, "a", load); v != 2 {
+		t.Errorf("Get() after expiry = %d, want 2", v)
+	}
+
+	c.Invalidate("a")
+	if v, _ := c.Get(ctx, "a", load); v != 3 {
+		t.Errorf("Get() after Invalidate = %d, want 3", v)
+	}
+
+	c.Get(ctx, "b", load)
+	c.InvalidateAll()
+	if v, _ := c.Get(ctx, "a", load); v != 5 {
+		t.Errorf("Get() after InvalidateAll = %d, want 5", v)
+	}
+}
+
+func TestTTL_GetErrorNotCached(t *testing.T) {
+	c := New[string, int](time.Minute)
+	wantErr := errors.New("boom")
+
+	if _, err := c.Get(context.Background(), "a", func(context.Context) (int, error) { return 0, wantErr }); !errors.Is(err, wantErr) {
+		t.Fatalf("Get() error = %v, want %v", err, wantErr)
+	}
+	if v, err := c.Get(context.Background(), "a", func(context.Context) (int, error) { return 7, nil }); err != nil || v != 7 {
+		t.Errorf("Get() = %d, %v, want 7", v, err)
+	}
+}
+
+func TestTTL_Concurrent(t *testing.T) {
+	c := New[int, int](time.Minute)
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			key := i % 5
+			v, err := c.Get(context.Background(), key, func(context.Context) (int, error) { return key * 10, nil })
+			if err != nil || v != key*10 {
+				t.Errorf("Get(%d) = %d, %v", key, v, err)
+			}
+			if i%10 == 0 {
+				c.InvalidateAll()
+			}
+		}()
+	}
+	wg.Wait()
+}
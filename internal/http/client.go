@@ -2,6 +2,7 @@ package mgc_http
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -9,10 +10,12 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/MagaluCloud/mgc-sdk-go/client"
 	"github.com/MagaluCloud/mgc-sdk-go/internal/retry"
+	"github.com/MagaluCloud/mgc-sdk-go/internal/utils"
 	"gopkg.in/yaml.v3"
 )
 
@@ -27,11 +30,20 @@ func NewRequest[T any](c *client.Config, ctx context.Context, method, path strin
 		"path", path,
 		"hasBody", body != nil)
 
-	url := c.BaseURL.String() + path
+	baseURL := c.BaseURL
+	if override := client.RequestBaseURL(ctx); override != "" {
+		if err := client.ValidateBaseURL(override); err != nil {
+			return nil, err
+		}
+		baseURL = override
+	}
+	url := baseURL.String() + path
 
 	var bodyReader io.Reader
+	compressed := false
+	contentType := c.ContentType
 	if body != nil {
-		bodyBytes, err := json.Marshal(body)
+		bodyBytes, err := encodeBody(body, &contentType)
 		if err != nil {
 			c.Logger.Error("failed to marshal request body",
 				"error", err,
@@ -39,6 +51,18 @@ func NewRequest[T any](c *client.Config, ctx context.Context, method, path strin
 				"path", path)
 			return nil, fmt.Errorf("error marshalling body: %w", err)
 		}
+
+		if c.CompressRequestBodyOver > 0 && len(bodyBytes) > c.CompressRequestBodyOver {
+			originalSize := len(bodyBytes)
+			bodyBytes, err = gzipBody(bodyBytes)
+			if err != nil {
+				return nil, fmt.Errorf("error compressing body: %w", err)
+			}
+			compressed = true
+			c.Logger.Debug("compressed request body",
+				"originalSize", originalSize,
+				"compressedSize", len(bodyBytes))
+		}
 		bodyReader = io.NopCloser(bytes.NewReader(bodyBytes))
 	}
 
@@ -64,9 +88,16 @@ func NewRequest[T any](c *client.Config, ctx context.Context, method, path strin
 		"apiKey", "redacted",
 		"userAgent", c.UserAgent)
 
+	if key := client.IdempotencyKey(ctx); key != "" {
+		req.Header.Set(client.IdempotencyKeyHeader, key)
+	}
+
 	req.Header.Set("X-API-Key", c.APIKey)
 	req.Header.Set("User-Agent", c.UserAgent)
-	req.Header.Set("Content-Type", c.ContentType)
+	req.Header.Set("Content-Type", contentType)
+	if compressed {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
 
 	if c.CustomHeaders != nil {
 		for k, v := range c.CustomHeaders {
@@ -75,13 +106,34 @@ func NewRequest[T any](c *client.Config, ctx context.Context, method, path strin
 		}
 	}
 
+	for k, v := range client.RequestTags(ctx) {
+		if err := client.ValidateRequestTag(k, v); err != nil {
+			return nil, err
+		}
+		req.Header.Set(k, v)
+		c.Logger.Debug("Request with tag header", "key", k, "value", v)
+	}
+
 	return req, nil
 }
 
+func gzipBody(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
 // Do executes an HTTP request and processes the response.
 // If v is provided, the response body will be JSON decoded into it.
 // Returns the parsed response and an error if the request fails,
 // the response status is not 2xx, or if there are JSON decoding issues.
+// The request is sent through the client's Doer, the retrying executor by default.
 func Do[T any](c *client.Config, ctx context.Context, req *http.Request, v *T) (*T, error) {
 	c.Logger.Debug("starting request execution",
 		"method", req.Method,
@@ -92,6 +144,58 @@ func Do[T any](c *client.Config, ctx context.Context, req *http.Request, v *T) (
 		return nil, fmt.Errorf("HTTP client is nil")
 	}
 
+	done, err := c.BeginRequest()
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	timeout := c.Timeout
+	if callTimeout, ok := client.CallTimeout(ctx); ok {
+		timeout = callTimeout
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	doer := c.Chain(&attemptDoer{config: c})
+	resp, err := doer.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if v != nil && resp.StatusCode != http.StatusNoContent {
+		ct := resp.Header.Get("Content-Type")
+		if strings.Contains(ct, "application/x-yaml") || strings.Contains(ct, "application/yaml") {
+			return decodeYamlResponse(resp, v)
+		}
+		// JSON is the default
+		return decodeJsonResponse(resp, v, c.StrictDecoding, c.MaxPooledDecodeBuffer)
+	}
+
+	return nil, nil
+}
+
+// attemptDoer is the Doer at the end of the chain built by client.Config.Chain: it sends a
+// single attempt through the circuit breaker and the request slots, and records exchanges,
+// warnings and request hooks. Retries wrap it, see client.RetryMiddleware. A non-2xx response
+// is returned as a *client.HTTPError.
+type attemptDoer struct {
+	config *client.Config
+}
+
+// Do implements client.Doer
+func (d *attemptDoer) Do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	c := d.config
+	attempt := utils.AttemptFrom(ctx)
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	var bodyBytes []byte
 	if req.Body != nil {
 		var err error
@@ -102,76 +206,132 @@ func Do[T any](c *client.Config, ctx context.Context, req *http.Request, v *T) (
 		req.Body.Close()
 	}
 
-	if c.Timeout > 0 {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, c.Timeout)
-		defer cancel()
+	clonedReq := req.Clone(ctx)
+	if len(bodyBytes) > 0 {
+		clonedReq.Body = io.NopCloser(bytes.NewReader(bodyBytes))
 	}
 
-	var lastError error
-	for attempt := range c.RetryConfig.MaxAttempts {
-		if attempt > 0 {
-			backoff := retry.GetNextBackoff(attempt-1, c.RetryConfig.BackoffFactor, c.RetryConfig.InitialInterval, c.RetryConfig.MaxInterval)
-			timer := time.NewTimer(backoff)
-			select {
-			case <-ctx.Done():
-				timer.Stop()
-				return nil, ctx.Err()
-			case <-timer.C:
-			}
-		}
-
-		clonedReq := req.Clone(ctx)
-		if len(bodyBytes) > 0 {
-			clonedReq.Body = io.NopCloser(bytes.NewReader(bodyBytes))
-		}
+	// The slot is taken first so that a wait ended by ctx never strands a half-open probe.
+	release, err := c.AcquireRequestSlot(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-		c.Logger.Info("making request",
+	if c.CircuitBreaker != nil && !c.CircuitBreaker.Allow() {
+		release()
+		c.Logger.Warn("circuit breaker is open, failing fast",
 			"method", clonedReq.Method,
-			"url", clonedReq.URL.String(),
-			"attempt", attempt+1)
+			"url", clonedReq.URL.String())
+		return nil, client.ErrCircuitOpen
+	}
 
-		resp, err := c.HTTPClient.Do(clonedReq)
-		if err != nil {
-			lastError = err
-			continue
+	c.Logger.Info("making request",
+		"method", clonedReq.Method,
+		"url", clonedReq.URL.String(),
+		"pathTemplate", client.PathTemplate(ctx),
+		"attempt", attempt)
+
+	if c.BeforeAttempt != nil {
+		c.BeforeAttempt(attempt, clonedReq)
+	}
+	clock := utils.ClockFrom(ctx)
+	start := clock.Now()
+	resp, err := c.HTTPClient.Do(clonedReq)
+	notifyRequest(c, clonedReq, attempt, clock.Now().Sub(start), resp, err)
+	if c.AfterAttempt != nil {
+		c.AfterAttempt(attempt, resp, err)
+	}
+	if err != nil {
+		release()
+		c.RecordExchange(clonedReq, bodyBytes, nil, nil)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			// A cancelled caller says nothing about the API's health, so the breaker is left alone.
+			abandonCircuitProbe(c)
+			return nil, ctxErr
 		}
+		recordCircuitFailure(c)
+		return nil, err
+	}
 
-		defer resp.Body.Close()
+	// The slot is held until the body is closed, since reading it still uses the connection.
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: release}
 
-		if xRequestID := resp.Header.Get("X-Request-ID"); xRequestID != "" {
-			c.Logger.Info("X-Request-ID received in response", "requestID", xRequestID)
-		} else {
-			c.Logger.Info("X-Request-ID not found in response")
+	if c.CaptureLastExchange {
+		respBody, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if readErr != nil {
+			abandonCircuitProbe(c)
+			return nil, readErr
 		}
+		resp.Body = io.NopCloser(bytes.NewReader(respBody))
+		c.RecordExchange(clonedReq, bodyBytes, resp, respBody)
+	}
+
+	if err := notifyWarnings(c, clonedReq, resp); err != nil {
+		resp.Body.Close()
+		abandonCircuitProbe(c)
+		return nil, err
+	}
+
+	if xRequestID := resp.Header.Get("X-Request-ID"); xRequestID != "" {
+		c.Logger.Info("X-Request-ID received in response", "requestID", xRequestID)
+	} else {
+		c.Logger.Info("X-Request-ID not found in response")
+	}
+
+	if xTraceID := resp.Header.Get("X-Mgc-Trace-Id"); xTraceID != "" {
+		c.Logger.Info("X-Mgc-Trace-ID received in response", "mgcTraceID", xTraceID)
+	} else {
+		c.Logger.Info("X-Mgc-Trace-ID not found in response")
+	}
 
-		if xTraceID := resp.Header.Get("X-Mgc-Trace-Id"); xTraceID != "" {
-			c.Logger.Info("X-Mgc-Trace-ID received in response", "mgcTraceID", xTraceID)
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		httpErr := client.NewHTTPError(resp)
+		resp.Body.Close()
+		if retry.ShouldRetry(resp.StatusCode) {
+			recordCircuitFailure(c)
 		} else {
-			c.Logger.Info("X-Mgc-Trace-ID not found in response")
+			recordCircuitSuccess(c)
 		}
+		return nil, httpErr
+	}
 
-		if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
-			lastError = client.NewHTTPError(resp)
-			if !retry.ShouldRetry(resp.StatusCode) {
-				return nil, lastError
-			}
-			continue
-		}
+	recordCircuitSuccess(c)
+	return resp, nil
+}
 
-		if v != nil && resp.StatusCode != http.StatusNoContent {
-			ct := resp.Header.Get("Content-Type")
-			if strings.Contains(ct, "application/x-yaml") || strings.Contains(ct, "application/yaml") {
-				return decodeYamlResponse(resp, v)
-			}
-			// JSON is the default
-			return decodeJsonResponse(resp, v)
-		}
+// releasingBody releases a request slot when the response body is closed
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
 
-		return nil, nil
+// Close implements io.Closer
+func (b *releasingBody) Close() error {
+	defer b.release()
+	return b.ReadCloser.Close()
+}
+
+// recordCircuitFailure reports a failed attempt to the circuit breaker, if any
+func recordCircuitFailure(c *client.Config) {
+	if c.CircuitBreaker != nil {
+		c.CircuitBreaker.RecordFailure()
 	}
+}
 
-	return nil, &client.RetryError{LastError: lastError, Retries: c.RetryConfig.MaxAttempts}
+// recordCircuitSuccess reports an attempt that reached a healthy API to the circuit breaker, if any
+func recordCircuitSuccess(c *client.Config) {
+	if c.CircuitBreaker != nil {
+		c.CircuitBreaker.RecordSuccess()
+	}
+}
+
+// abandonCircuitProbe tells the circuit breaker, if any, that an attempt ended without a
+// result to record
+func abandonCircuitProbe(c *client.Config) {
+	if c.CircuitBreaker != nil {
+		c.CircuitBreaker.Abandon()
+	}
 }
 
 func decodeYamlResponse[T any](resp *http.Response, v *T) (*T, error) {
@@ -195,28 +355,105 @@ func decodeYamlResponse[T any](resp *http.Response, v *T) (*T, error) {
 	return v, nil
 }
 
-func decodeJsonResponse[T any](resp *http.Response, v *T) (*T, error) {
-	var raw json.RawMessage
-	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+// encodeBody marshals body as JSON unless it is a client.EncodedBody, in which case it
+// encodes itself and contentType is replaced by its own
+func encodeBody[T any](body *T, contentType *string) ([]byte, error) {
+	if encoded, ok := any(*body).(client.EncodedBody); ok {
+		*contentType = encoded.ContentType()
+		return encoded.EncodeBody()
+	}
+	return json.Marshal(body)
+}
+
+// notifyRequest reports an HTTP attempt to the configured request hook
+func notifyRequest(c *client.Config, req *http.Request, attempt int, duration time.Duration, resp *http.Response, err error) {
+	if c.OnRequest == nil {
+		return
+	}
+
+	info := client.RequestInfo{
+		Method:       req.Method,
+		URL:          req.URL.String(),
+		PathTemplate: client.PathTemplate(req.Context()),
+		Attempt:      attempt,
+		Duration:     duration,
+		Err:          err,
+	}
+	if resp != nil {
+		info.StatusCode = resp.StatusCode
+	}
+	c.OnRequest(info)
+}
+
+// decodeJsonResponse decodes a JSON body into v. With strict set, fields unknown to v are errors.
+func decodeJsonResponse[T any](resp *http.Response, v *T, strict bool, maxPooledBuffer int) (*T, error) {
+	buf := getDecodeBuffer()
+	defer putDecodeBuffer(buf, maxPooledBuffer)
+
+	if _, err := buf.ReadFrom(resp.Body); err != nil {
 		return nil, fmt.Errorf("error decoding response: %w", err)
 	}
 
-	var checkNull any
-	if err := json.Unmarshal(raw, &checkNull); err != nil {
-		return nil, fmt.Errorf("error validating null response: %w", err)
+	body := bytes.TrimSpace(buf.Bytes())
+	if len(body) == 0 {
+		return nil, fmt.Errorf("error decoding response: %w", io.EOF)
 	}
-	if checkNull == nil {
+	if bytes.Equal(body, []byte("null")) {
 		return nil, fmt.Errorf("response body is null")
 	}
 
-	decoder := json.NewDecoder(bytes.NewReader(raw))
+	// The decoder copies what it keeps, so the buffer can be reused once it returns.
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	if strict {
+		decoder.DisallowUnknownFields()
+	}
 	if err := decoder.Decode(v); err != nil {
-		return nil, fmt.Errorf("error decoding response: %w", err)
+		contentType := resp.Header.Get("Content-Type")
+		decodeErr := &client.DecodeError{StatusCode: resp.StatusCode, ContentType: contentType, Err: err}
+		// A JSON body may carry secrets, such as registry passwords, so only other bodies are kept.
+		if !strings.Contains(contentType, "json") && !json.Valid(body) {
+			decodeErr.Snippet = bodySnippet(body)
+		}
+		return nil, decodeErr
 	}
 
 	return v, nil
 }
 
+// decodeErrorSnippetSize is the most bytes of a response body kept in a DecodeError
+const decodeErrorSnippetSize = 512
+
+// bodySnippet returns the start of body as valid UTF-8, marking a truncation with "...".
+// It copies, so body may be a reused buffer.
+func bodySnippet(body []byte) string {
+	if len(body) <= decodeErrorSnippetSize {
+		return strings.ToValidUTF8(string(body), "\uFFFD")
+	}
+	return strings.ToValidUTF8(string(body[:decodeErrorSnippetSize]), "\uFFFD") + "..."
+}
+
+// decodeBuffers holds the buffers response bodies are read into before decoding
+var decodeBuffers = sync.Pool{New: func() any { return new(bytes.Buffer) }}
+
+// getDecodeBuffer returns an empty buffer from decodeBuffers
+func getDecodeBuffer() *bytes.Buffer {
+	buf := decodeBuffers.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+// putDecodeBuffer returns buf to decodeBuffers unless it grew beyond maxPooled bytes,
+// so one huge response does not pin its memory for the life of the process
+func putDecodeBuffer(buf *bytes.Buffer, maxPooled int) {
+	if maxPooled == 0 {
+		maxPooled = client.DefaultMaxPooledDecodeBuffer
+	}
+	if maxPooled < 0 || buf.Cap() > maxPooled {
+		return
+	}
+	decodeBuffers.Put(buf)
+}
+
 // ExecuteSimpleRequestWithRespBody handles HTTP requests that require response body parsing
 func ExecuteSimpleRequestWithRespBody[T any](
 	ctx context.Context,
@@ -1,8 +1,11 @@
 package mgc_http
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -12,10 +15,13 @@ import (
 	"reflect"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/MagaluCloud/mgc-sdk-go/client"
+	"github.com/MagaluCloud/mgc-sdk-go/helpers"
+	"github.com/MagaluCloud/mgc-sdk-go/internal/utils"
 )
 
 type mockResponse struct {
@@ -97,6 +103,69 @@ func TestCoreClient_NewRequest_CustomHeaders(t *testing.T) {
 	}
 }
 
+func TestCoreClient_NewRequest_Compression(t *testing.T) {
+	smallBody := mockRequest{Data: "small"}
+	largeBody := mockRequest{Data: strings.Repeat("a", 2048)}
+
+	tests := []struct {
+		name         string
+		threshold    int
+		body         mockRequest
+		wantEncoding string
+	}{
+		{
+			name:         "compression disabled",
+			threshold:    0,
+			body:         largeBody,
+			wantEncoding: "",
+		},
+		{
+			name:         "body below threshold",
+			threshold:    1024,
+			body:         smallBody,
+			wantEncoding: "",
+		},
+		{
+			name:         "body above threshold",
+			threshold:    1024,
+			body:         largeBody,
+			wantEncoding: "gzip",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ct := client.NewMgcClient("test-api-key", client.WithRequestCompression(tt.threshold))
+
+			req, err := NewRequest(ct.GetConfig(), context.Background(), http.MethodPost, "/test", &tt.body)
+			if err != nil {
+				t.Fatalf("NewRequest() error = %v", err)
+			}
+
+			if got := req.Header.Get("Content-Encoding"); got != tt.wantEncoding {
+				t.Errorf("Content-Encoding = %q, want %q", got, tt.wantEncoding)
+			}
+
+			var reader io.Reader = req.Body
+			if tt.wantEncoding == "gzip" {
+				zr, err := gzip.NewReader(req.Body)
+				if err != nil {
+					t.Fatalf("expected gzip body: %v", err)
+				}
+				reader = zr
+			}
+
+			var got mockRequest
+			if err := json.NewDecoder(reader).Decode(&got); err != nil {
+				t.Fatalf("failed to decode body: %v", err)
+			}
+			if got != tt.body {
+				t.Errorf("body = %+v, want %+v", got, tt.body)
+			}
+		})
+	}
+}
+
 func TestCoreClient_Do(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -258,6 +327,37 @@ func TestRetryLogic(t *testing.T) {
 	}
 }
 
+func TestRetryLogic_FakeClock(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	mgcClient := client.NewMgcClient("test-api-key",
+		client.WithBaseURL(client.MgcUrl(server.URL)),
+		client.WithRetryConfig(4, time.Minute, 3*time.Minute, 2.0))
+
+	clock := utils.NewFakeClock(time.Now())
+	ctx := utils.WithClock(context.Background(), clock)
+	req, _ := NewRequest[any](mgcClient.GetConfig(), ctx, http.MethodGet, "/test", nil)
+	var response mockResponse
+	_, err := Do(mgcClient.GetConfig(), ctx, req, &response)
+
+	var retryErr *client.RetryError
+	if !errors.As(err, &retryErr) {
+		t.Fatalf("Expected a RetryError, got %v", err)
+	}
+	if attempts != 4 {
+		t.Errorf("Expected 4 attempts, got %d", attempts)
+	}
+	want := []time.Duration{time.Minute, 2 * time.Minute, 3 * time.Minute}
+	if got := clock.Sleeps(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected backoff delays %v, got %v", want, got)
+	}
+}
+
 func TestRequestHeaders(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Header.Get("X-API-Key") != "test-api-key" {
@@ -1383,3 +1483,1103 @@ func TestRetryPreservesRequestBody(t *testing.T) {
 		}
 	}
 }
+
+func TestDo_CircuitBreaker(t *testing.T) {
+	attemptCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attemptCount++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	core := client.NewMgcClient("test-api-key",
+		client.WithBaseURL(client.MgcUrl(server.URL)),
+		client.WithRetryConfig(5, time.Millisecond, time.Millisecond, 1),
+		client.WithCircuitBreaker(2, time.Minute, time.Hour))
+
+	req, _ := NewRequest[any](core.GetConfig(), context.Background(), http.MethodGet, "/test", nil)
+	_, err := Do[any](core.GetConfig(), context.Background(), req, nil)
+	if !errors.Is(err, client.ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+	if attemptCount != 2 {
+		t.Errorf("expected 2 attempts before the circuit opened, got %d", attemptCount)
+	}
+
+	req, _ = NewRequest[any](core.GetConfig(), context.Background(), http.MethodGet, "/test", nil)
+	_, err = Do[any](core.GetConfig(), context.Background(), req, nil)
+	if !errors.Is(err, client.ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+	if attemptCount != 2 {
+		t.Errorf("expected no request to be sent while open, got %d attempts", attemptCount)
+	}
+}
+
+func TestDo_CircuitBreaker_CancelledProbe(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch attempts.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			// the probe: held until the caller gives up
+			<-r.Context().Done()
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	core := client.NewMgcClient("test-api-key",
+		client.WithBaseURL(client.MgcUrl(server.URL)),
+		client.WithRetryConfig(1, time.Millisecond, time.Millisecond, 1),
+		client.WithCircuitBreaker(1, time.Minute, time.Millisecond))
+	send := func(ctx context.Context) error {
+		req, _ := NewRequest[any](core.GetConfig(), ctx, http.MethodGet, "/test", nil)
+		_, err := Do[any](core.GetConfig(), ctx, req, nil)
+		return err
+	}
+
+	if err := send(context.Background()); err == nil {
+		t.Fatal("expected the first request to fail")
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := send(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the probe to end with the caller's deadline, got %v", err)
+	}
+
+	if err := send(context.Background()); err != nil {
+		t.Errorf("expected the request after a cancelled probe to be allowed, got %v", err)
+	}
+	if core.GetConfig().CircuitBreaker.State() != client.CircuitClosed {
+		t.Errorf("expected circuit to be closed, got %s", core.GetConfig().CircuitBreaker.State())
+	}
+}
+
+func TestDo_CircuitBreaker_ClientErrorsDoNotCount(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	core := client.NewMgcClient("test-api-key",
+		client.WithBaseURL(client.MgcUrl(server.URL)),
+		client.WithCircuitBreaker(1, time.Minute, time.Hour))
+
+	for i := 0; i < 3; i++ {
+		req, _ := NewRequest[any](core.GetConfig(), context.Background(), http.MethodGet, "/test", nil)
+		_, err := Do[any](core.GetConfig(), context.Background(), req, nil)
+		if errors.Is(err, client.ErrCircuitOpen) {
+			t.Fatalf("expected 404 responses not to open the circuit")
+		}
+	}
+	if core.GetConfig().CircuitBreaker.State() != client.CircuitClosed {
+		t.Errorf("expected circuit to be closed, got %s", core.GetConfig().CircuitBreaker.State())
+	}
+}
+
+func TestDo_CaptureLastExchange(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"message":"ok"}`))
+	}))
+	defer server.Close()
+
+	core := client.NewMgcClient("test-api-key",
+		client.WithBaseURL(client.MgcUrl(server.URL)),
+		client.WithCaptureLastExchange(true))
+
+	body := mockRequest{Data: "payload"}
+	req, _ := NewRequest(core.GetConfig(), context.Background(), http.MethodPost, "/test", &body)
+	result, err := Do(core.GetConfig(), context.Background(), req, &mockResponse{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Message != "ok" {
+		t.Errorf("expected response to still be decoded, got %q", result.Message)
+	}
+
+	exchange := core.LastExchange()
+	if exchange == nil || exchange.Response == nil {
+		t.Fatal("expected exchange with response to be captured")
+	}
+	if exchange.Request.URL != server.URL+"/test" {
+		t.Errorf("unexpected URL %s", exchange.Request.URL)
+	}
+	if string(exchange.Request.Body) != `{"data":"payload"}` {
+		t.Errorf("unexpected request body %s", exchange.Request.Body)
+	}
+	if exchange.Request.Header.Get("X-API-Key") == "test-api-key" {
+		t.Error("expected API key to be redacted")
+	}
+	if exchange.Response.StatusCode != http.StatusOK || string(exchange.Response.Body) != `{"message":"ok"}` {
+		t.Errorf("unexpected response %d %s", exchange.Response.StatusCode, exchange.Response.Body)
+	}
+}
+
+func TestDo_CloseDrainsInFlightRequests(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	core := client.NewMgcClient("test-api-key",
+		client.WithBaseURL(client.MgcUrl(server.URL)),
+		client.WithHTTPClient(&http.Client{}))
+
+	result := make(chan error, 1)
+	go func() {
+		req, _ := NewRequest[any](core.GetConfig(), context.Background(), http.MethodGet, "/slow", nil)
+		_, err := Do[any](core.GetConfig(), context.Background(), req, nil)
+		result <- err
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := core.Close(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Close() error = %v, want context.DeadlineExceeded while a request is in flight", err)
+	}
+
+	req, _ := NewRequest[any](core.GetConfig(), context.Background(), http.MethodGet, "/new", nil)
+	if _, err := Do[any](core.GetConfig(), context.Background(), req, nil); !errors.Is(err, client.ErrClientClosed) {
+		t.Errorf("expected ErrClientClosed for a new request, got %v", err)
+	}
+
+	close(release)
+	if err := <-result; err != nil {
+		t.Errorf("expected in-flight request to finish, got %v", err)
+	}
+	if err := core.Close(context.Background()); err != nil {
+		t.Errorf("Close() after drain unexpected error: %v", err)
+	}
+}
+
+func TestDo_StrictDecoding(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"message":"ok","new_field":"added by the API"}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		strict  bool
+		wantErr bool
+	}{
+		{name: "lenient decoding ignores unknown fields", strict: false, wantErr: false},
+		{name: "strict decoding rejects unknown fields", strict: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core := client.NewMgcClient("test-api-key",
+				client.WithBaseURL(client.MgcUrl(server.URL)),
+				client.WithStrictDecoding(tt.strict))
+
+			req, _ := NewRequest[any](core.GetConfig(), context.Background(), http.MethodGet, "/test", nil)
+			result, err := Do(core.GetConfig(), context.Background(), req, &mockResponse{})
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Do() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !strings.Contains(err.Error(), "new_field") {
+				t.Errorf("expected error to name the unknown field, got %v", err)
+			}
+			if !tt.wantErr && result.Message != "ok" {
+				t.Errorf("expected message ok, got %q", result.Message)
+			}
+		})
+	}
+}
+
+func TestNewRequest_RequestTags(t *testing.T) {
+	core := client.NewMgcClient("test-api-key")
+
+	ctx := client.WithRequestTags(context.Background(), map[string]string{"X-Cost-Center": "team-a"})
+	req, err := NewRequest[any](core.GetConfig(), ctx, http.MethodGet, "/test", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := req.Header.Get("X-Cost-Center"); got != "team-a" {
+		t.Errorf("expected X-Cost-Center header team-a, got %q", got)
+	}
+
+	ctx = client.WithRequestTags(context.Background(), map[string]string{"X-Tenant": "bad\nvalue"})
+	if _, err := NewRequest[any](core.GetConfig(), ctx, http.MethodGet, "/test", nil); err == nil {
+		t.Error("expected error for a tag value with a newline")
+	}
+}
+
+func TestDo_ContextCancellation(t *testing.T) {
+	const bound = 500 * time.Millisecond
+
+	t.Run("during backoff", func(t *testing.T) {
+		var attempts atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		core := client.NewMgcClient("test-api-key",
+			client.WithBaseURL(client.MgcUrl(server.URL)),
+			client.WithRetryConfig(5, time.Minute, time.Minute, 1))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+
+		req, _ := NewRequest[any](core.GetConfig(), ctx, http.MethodGet, "/test", nil)
+		start := time.Now()
+		_, err := Do[any](core.GetConfig(), ctx, req, nil)
+		if elapsed := time.Since(start); elapsed > bound {
+			t.Errorf("Do() returned after %v, want under %v", elapsed, bound)
+		}
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Do() error = %v, want context.Canceled", err)
+		}
+		if got := attempts.Load(); got != 1 {
+			t.Errorf("expected 1 attempt, got %d", got)
+		}
+	})
+
+	t.Run("during request", func(t *testing.T) {
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-release:
+			}
+		}))
+		defer server.Close()
+		defer close(release)
+
+		core := client.NewMgcClient("test-api-key",
+			client.WithBaseURL(client.MgcUrl(server.URL)),
+			client.WithRetryConfig(5, time.Millisecond, time.Millisecond, 1),
+			client.WithCircuitBreaker(1, time.Minute, time.Minute))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+
+		req, _ := NewRequest[any](core.GetConfig(), ctx, http.MethodGet, "/test", nil)
+		start := time.Now()
+		_, err := Do[any](core.GetConfig(), ctx, req, nil)
+		if elapsed := time.Since(start); elapsed > bound {
+			t.Errorf("Do() returned after %v, want under %v", elapsed, bound)
+		}
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Do() error = %v, want context.Canceled", err)
+		}
+		if state := core.GetConfig().CircuitBreaker.State(); state != client.CircuitClosed {
+			t.Errorf("cancellation should not trip the circuit breaker, state = %v", state)
+		}
+	})
+
+	t.Run("already cancelled", func(t *testing.T) {
+		var attempts atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts.Add(1)
+		}))
+		defer server.Close()
+
+		core := client.NewMgcClient("test-api-key", client.WithBaseURL(client.MgcUrl(server.URL)))
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		req, _ := NewRequest[any](core.GetConfig(), context.Background(), http.MethodGet, "/test", nil)
+		if _, err := Do[any](core.GetConfig(), ctx, req, nil); !errors.Is(err, context.Canceled) {
+			t.Errorf("Do() error = %v, want context.Canceled", err)
+		}
+		if got := attempts.Load(); got != 0 {
+			t.Errorf("expected no request to be sent, got %d", got)
+		}
+	})
+}
+
+func TestDo_RequestHook(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	var infos []client.RequestInfo
+	core := client.NewMgcClient("test-api-key",
+		client.WithBaseURL(client.MgcUrl(server.URL)),
+		client.WithRetryConfig(3, time.Millisecond, time.Millisecond, 1),
+		client.WithRequestHook(func(info client.RequestInfo) { infos = append(infos, info) }))
+
+	ctx, path := ExpandPathTemplate(context.Background(), "/compute", "/v1/snapshots/{id}", "snap-1")
+	req, err := NewRequest[any](core.GetConfig(), ctx, http.MethodDelete, "/compute"+path, nil)
+	if err != nil {
+		t.Fatalf("NewRequest() unexpected error: %v", err)
+	}
+	if _, err := Do[any](core.GetConfig(), ctx, req, nil); err != nil {
+		t.Fatalf("Do() unexpected error: %v", err)
+	}
+
+	if len(infos) != 2 {
+		t.Fatalf("expected 2 attempts reported, got %d", len(infos))
+	}
+	for i, info := range infos {
+		if info.PathTemplate != "/compute/v1/snapshots/{id}" {
+			t.Errorf("attempt %d path template = %q", i+1, info.PathTemplate)
+		}
+		if info.Attempt != i+1 || info.Method != http.MethodDelete || !strings.HasSuffix(info.URL, "/compute/v1/snapshots/snap-1") {
+			t.Errorf("unexpected request info %+v", info)
+		}
+	}
+	if infos[0].StatusCode != http.StatusServiceUnavailable || infos[1].StatusCode != http.StatusNoContent {
+		t.Errorf("unexpected status codes %d, %d", infos[0].StatusCode, infos[1].StatusCode)
+	}
+}
+
+func TestNewRequest_EncodedBody(t *testing.T) {
+	core := client.NewMgcClient("test-api-key")
+
+	tests := []struct {
+		name            string
+		body            any
+		wantContentType string
+		wantBody        string
+	}{
+		{name: "json by default", body: map[string]string{"a": "b"}, wantContentType: "application/json", wantBody: `{"a":"b"}`},
+		{name: "form body", body: client.FormBody{"grant_type": {"refresh_token"}, "token": {"x&y"}},
+			wantContentType: "application/x-www-form-urlencoded", wantBody: "grant_type=refresh_token&token=x%26y"},
+		{name: "explicit content type", body: client.WithContentType("text/plain", []byte("hello")),
+			wantContentType: "text/plain", wantBody: "hello"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := NewRequest(core.GetConfig(), context.Background(), http.MethodPost, "/token", &tt.body)
+			if err != nil {
+				t.Fatalf("NewRequest() unexpected error: %v", err)
+			}
+			if ct := req.Header.Get("Content-Type"); ct != tt.wantContentType {
+				t.Errorf("Content-Type = %q, want %q", ct, tt.wantContentType)
+			}
+			body, _ := io.ReadAll(req.Body)
+			if string(body) != tt.wantBody {
+				t.Errorf("body = %q, want %q", body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestNewRequest_IdempotencyKey(t *testing.T) {
+	core := client.NewMgcClient("test-api-key")
+
+	ctx := client.WithIdempotencyKey(context.Background(), "key-1")
+	req, err := NewRequest[any](core.GetConfig(), ctx, http.MethodPost, "/snapshots", nil)
+	if err != nil {
+		t.Fatalf("NewRequest() unexpected error: %v", err)
+	}
+	if got := req.Header.Get("Idempotency-Key"); got != "key-1" {
+		t.Errorf("Idempotency-Key = %q, want %q", got, "key-1")
+	}
+
+	req, _ = NewRequest[any](core.GetConfig(), context.Background(), http.MethodPost, "/snapshots", nil)
+	if got := req.Header.Get("Idempotency-Key"); got != "" {
+		t.Errorf("expected no Idempotency-Key without one in the context, got %q", got)
+	}
+
+	if a, b := client.NewIdempotencyKey(), client.NewIdempotencyKey(); a == b || len(a) != 32 {
+		t.Errorf("NewIdempotencyKey() returned %q and %q, want distinct 32-char keys", a, b)
+	}
+}
+
+func TestDo_RequestBaseURL(t *testing.T) {
+	var defaultHits, overrideHits atomic.Int32
+	defaultServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defaultHits.Add(1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer defaultServer.Close()
+	overrideServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		overrideHits.Add(1)
+		if r.URL.Path != "/compute/v1/snapshots" {
+			t.Errorf("expected the service path to be kept, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer overrideServer.Close()
+
+	core := client.NewMgcClient("test-api-key", client.WithBaseURL(client.MgcUrl(defaultServer.URL)))
+
+	ctx := client.WithRequestBaseURL(context.Background(), client.MgcUrl(overrideServer.URL))
+	req, err := NewRequest[any](core.GetConfig(), ctx, http.MethodGet, "/compute/v1/snapshots", nil)
+	if err != nil {
+		t.Fatalf("NewRequest() unexpected error: %v", err)
+	}
+	if _, err := Do[any](core.GetConfig(), ctx, req, nil); err != nil {
+		t.Fatalf("Do() unexpected error: %v", err)
+	}
+
+	req, _ = NewRequest[any](core.GetConfig(), context.Background(), http.MethodGet, "/compute/v1/snapshots", nil)
+	if _, err := Do[any](core.GetConfig(), context.Background(), req, nil); err != nil {
+		t.Fatalf("Do() unexpected error: %v", err)
+	}
+
+	if overrideHits.Load() != 1 || defaultHits.Load() != 1 {
+		t.Errorf("expected one request per server, got override=%d default=%d", overrideHits.Load(), defaultHits.Load())
+	}
+	if got := core.GetConfig().BaseURL; got != client.MgcUrl(defaultServer.URL) {
+		t.Errorf("expected the client base URL to be unchanged, got %s", got)
+	}
+
+	for _, bad := range []client.MgcUrl{"api.magalu.cloud/br-ne1", "ftp://api.magalu.cloud"} {
+		ctx := client.WithRequestBaseURL(context.Background(), bad)
+		_, err := NewRequest[any](core.GetConfig(), ctx, http.MethodGet, "/test", nil)
+		var validationErr *client.ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Field != "baseURL" {
+			t.Errorf("expected a baseURL ValidationError for %q, got %v", bad, err)
+		}
+	}
+}
+
+// countingDoer is an example decorator counting the requests sent through the executor.
+type countingDoer struct {
+	next  client.Doer
+	count atomic.Int32
+}
+
+func (d *countingDoer) Do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	d.count.Add(1)
+	return d.next.Do(ctx, req)
+}
+
+func TestDo_WrapDoer(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(mockResponse{Message: "ok"})
+	}))
+	defer server.Close()
+
+	counter := &countingDoer{}
+	core := client.NewMgcClient("test-api-key",
+		client.WithBaseURL(client.MgcUrl(server.URL)),
+		client.WithRetryConfig(3, time.Millisecond, time.Millisecond, 1),
+		client.WithDoer(func(next client.Doer) client.Doer {
+			counter.next = next
+			return counter
+		}))
+
+	for range 2 {
+		attempts = 0
+		req, _ := NewRequest[any](core.GetConfig(), context.Background(), http.MethodGet, "/test", nil)
+		var got mockResponse
+		if _, err := Do(core.GetConfig(), context.Background(), req, &got); err != nil {
+			t.Fatalf("Do() unexpected error: %v", err)
+		}
+		if got.Message != "ok" {
+			t.Errorf("unexpected response %+v", got)
+		}
+	}
+
+	if got := counter.count.Load(); got != 2 {
+		t.Errorf("expected 2 requests through the decorator, got %d", got)
+	}
+}
+
+func TestDo_WrapDoer_ShortCircuit(t *testing.T) {
+	core := client.NewMgcClient("test-api-key",
+		client.WithDoer(func(client.Doer) client.Doer {
+			return client.DoerFunc(func(ctx context.Context, req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": {"application/json"}},
+					Body:       io.NopCloser(strings.NewReader(`{"message": "cached"}`)),
+				}, nil
+			})
+		}))
+
+	req, _ := NewRequest[any](core.GetConfig(), context.Background(), http.MethodGet, "/test", nil)
+	var got mockResponse
+	if _, err := Do(core.GetConfig(), context.Background(), req, &got); err != nil {
+		t.Fatalf("Do() unexpected error: %v", err)
+	}
+	if got.Message != "cached" {
+		t.Errorf("expected the decorator's response to be decoded, got %+v", got)
+	}
+}
+
+func TestDo_MaxConcurrentRequests(t *testing.T) {
+	const limit = 3
+	var current, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := current.Add(1)
+		defer current.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(mockResponse{Message: "ok"})
+	}))
+	defer server.Close()
+
+	core := client.NewMgcClient("test-api-key",
+		client.WithBaseURL(client.MgcUrl(server.URL)),
+		client.WithMaxConcurrentRequests(limit))
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, _ := NewRequest[any](core.GetConfig(), context.Background(), http.MethodGet, "/test", nil)
+			var got mockResponse
+			if _, err := Do(core.GetConfig(), context.Background(), req, &got); err != nil {
+				t.Errorf("Do() unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := peak.Load(); got > limit {
+		t.Errorf("expected at most %d concurrent requests, got %d", limit, got)
+	}
+	if got := peak.Load(); got < 2 {
+		t.Errorf("expected requests to run concurrently, peak was %d", got)
+	}
+}
+
+func TestDo_MaxConcurrentRequests_WaitCancelled(t *testing.T) {
+	unblock := make(chan struct{})
+	started := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-unblock
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	defer close(unblock)
+
+	core := client.NewMgcClient("test-api-key",
+		client.WithBaseURL(client.MgcUrl(server.URL)),
+		client.WithMaxConcurrentRequests(1))
+
+	go func() {
+		req, _ := NewRequest[any](core.GetConfig(), context.Background(), http.MethodGet, "/busy", nil)
+		Do[any](core.GetConfig(), context.Background(), req, nil)
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	req, _ := NewRequest[any](core.GetConfig(), ctx, http.MethodGet, "/waiting", nil)
+	_, err := Do[any](core.GetConfig(), ctx, req, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the wait for a slot to end with the context, got %v", err)
+	}
+}
+
+func TestDo_DecodeError(t *testing.T) {
+	page := "<html><head><title>Bad Gateway</title></head><body>upstream unavailable</body></html>"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		switch r.URL.Path {
+		case "/long":
+			w.Write([]byte(strings.Repeat("<p>", 500)))
+		default:
+			w.Write([]byte(page))
+		}
+	}))
+	defer server.Close()
+
+	core := client.NewMgcClient("test-api-key", client.WithBaseURL(client.MgcUrl(server.URL)))
+
+	req, _ := NewRequest[any](core.GetConfig(), context.Background(), http.MethodGet, "/html", nil)
+	_, err := Do(core.GetConfig(), context.Background(), req, &mockResponse{})
+
+	var decodeErr *client.DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("expected a DecodeError, got %v", err)
+	}
+	if decodeErr.StatusCode != http.StatusOK || decodeErr.ContentType != "text/html; charset=utf-8" {
+		t.Errorf("unexpected status %d and content type %q", decodeErr.StatusCode, decodeErr.ContentType)
+	}
+	if decodeErr.Snippet != page || !strings.Contains(err.Error(), "Bad Gateway") {
+		t.Errorf("expected the page in the error, got %v", err)
+	}
+	var syntaxErr *json.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		t.Errorf("expected the JSON syntax error to be unwrapped, got %v", decodeErr.Err)
+	}
+
+	req, _ = NewRequest[any](core.GetConfig(), context.Background(), http.MethodGet, "/long", nil)
+	_, err = Do(core.GetConfig(), context.Background(), req, &mockResponse{})
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("expected a DecodeError, got %v", err)
+	}
+	if len(decodeErr.Snippet) != decodeErrorSnippetSize+len("...") || !strings.HasSuffix(decodeErr.Snippet, "...") {
+		t.Errorf("expected a snippet truncated to %d bytes, got %d bytes", decodeErrorSnippetSize, len(decodeErr.Snippet))
+	}
+}
+
+func TestDo_DecodeError_JSONBodyNotKept(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"message": "ok", "password": "s3cr3t-registry-password"}`))
+	}))
+	defer server.Close()
+
+	core := client.NewMgcClient("test-api-key",
+		client.WithBaseURL(client.MgcUrl(server.URL)),
+		client.WithStrictDecoding(true))
+
+	req, _ := NewRequest[any](core.GetConfig(), context.Background(), http.MethodGet, "/credentials", nil)
+	_, err := Do(core.GetConfig(), context.Background(), req, &mockResponse{})
+
+	var decodeErr *client.DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("expected a DecodeError, got %v", err)
+	}
+	if decodeErr.Snippet != "" || strings.Contains(err.Error(), "s3cr3t") {
+		t.Errorf("expected the JSON body to be left out of the error, got %v", err)
+	}
+}
+
+func TestDo_RetryPolicy(t *testing.T) {
+	fast := helpers.Backoff{Initial: time.Millisecond}
+
+	tests := []struct {
+		name         string
+		status       int
+		retryAfter   string
+		policy       client.RetryPolicy
+		wantAttempts int32
+		wantRetryErr bool
+	}{
+		{
+			name:         "rule allows more attempts than RetryConfig",
+			status:       http.StatusServiceUnavailable,
+			policy:       client.RetryPolicy{http.StatusServiceUnavailable: {MaxAttempts: 5, Backoff: fast}},
+			wantAttempts: 5,
+			wantRetryErr: true,
+		},
+		{
+			name:         "rule retries a status the default never retries",
+			status:       http.StatusConflict,
+			policy:       client.RetryPolicy{http.StatusConflict: {MaxAttempts: 3, Backoff: fast}},
+			wantAttempts: 3,
+			wantRetryErr: true,
+		},
+		{
+			name:         "rule disables retries of a default retryable status",
+			status:       http.StatusInternalServerError,
+			policy:       client.RetryPolicy{http.StatusInternalServerError: {MaxAttempts: 1}},
+			wantAttempts: 1,
+		},
+		{
+			name:         "rule requiring Retry-After without the header",
+			status:       http.StatusTooManyRequests,
+			policy:       client.RetryPolicy{http.StatusTooManyRequests: {MaxAttempts: 3, RequireRetryAfter: true}},
+			wantAttempts: 1,
+		},
+		{
+			name:         "Retry-After takes precedence over the rule backoff",
+			status:       http.StatusTooManyRequests,
+			retryAfter:   "0",
+			policy:       client.RetryPolicy{http.StatusTooManyRequests: {MaxAttempts: 3, Backoff: helpers.Backoff{Initial: time.Hour}, RequireRetryAfter: true}},
+			wantAttempts: 3,
+			wantRetryErr: true,
+		},
+		{
+			name:         "status without a rule follows RetryConfig",
+			status:       http.StatusBadGateway,
+			policy:       client.RetryPolicy{http.StatusServiceUnavailable: {MaxAttempts: 5, Backoff: fast}},
+			wantAttempts: 2,
+			wantRetryErr: true,
+		},
+		{
+			name:         "status without a rule and not retryable",
+			status:       http.StatusBadRequest,
+			policy:       client.RetryPolicy{http.StatusServiceUnavailable: {MaxAttempts: 5, Backoff: fast}},
+			wantAttempts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts.Add(1)
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			core := client.NewMgcClient("test-api-key",
+				client.WithBaseURL(client.MgcUrl(server.URL)),
+				client.WithRetryConfig(2, time.Millisecond, time.Millisecond, 1),
+				client.WithRetryPolicy(tt.policy))
+
+			req, _ := NewRequest[any](core.GetConfig(), context.Background(), http.MethodGet, "/test", nil)
+			_, err := Do[any](core.GetConfig(), context.Background(), req, nil)
+
+			var retryErr *client.RetryError
+			if got := errors.As(err, &retryErr); got != tt.wantRetryErr {
+				t.Fatalf("expected RetryError %v, got %v", tt.wantRetryErr, err)
+			}
+			if retryErr != nil && retryErr.Retries != int(tt.wantAttempts) {
+				t.Errorf("expected Retries %d, got %d", tt.wantAttempts, retryErr.Retries)
+			}
+			if retryErr != nil {
+				err = retryErr.LastError
+			}
+			var httpErr *client.HTTPError
+			if !errors.As(err, &httpErr) || httpErr.StatusCode != tt.status {
+				t.Errorf("expected an HTTPError with status %d, got %v", tt.status, err)
+			}
+			if got := attempts.Load(); got != tt.wantAttempts {
+				t.Errorf("expected %d attempts, got %d", tt.wantAttempts, got)
+			}
+		})
+	}
+}
+
+func TestDo_RetryPolicy_MixedStatuses(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	core := client.NewMgcClient("test-api-key",
+		client.WithBaseURL(client.MgcUrl(server.URL)),
+		client.WithRetryConfig(1, time.Millisecond, time.Millisecond, 1),
+		client.WithRetryPolicy(client.RetryPolicy{
+			http.StatusServiceUnavailable: {MaxAttempts: 5, Backoff: helpers.Backoff{Initial: time.Millisecond}},
+			http.StatusTooManyRequests:    {MaxAttempts: 3, Backoff: helpers.Backoff{Initial: time.Millisecond}},
+		}))
+
+	req, _ := NewRequest[any](core.GetConfig(), context.Background(), http.MethodGet, "/test", nil)
+	_, err := Do[any](core.GetConfig(), context.Background(), req, nil)
+
+	var retryErr *client.RetryError
+	if !errors.As(err, &retryErr) || retryErr.Retries != 3 {
+		t.Errorf("expected a RetryError after 3 attempts, got %v", err)
+	}
+	if got := attempts.Load(); got != 3 {
+		t.Errorf("expected the 429 rule to stop at 3 attempts counted per request, got %d", got)
+	}
+}
+
+func TestDo_CallTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"message": "done"}`))
+	}))
+	defer server.Close()
+
+	core := client.NewMgcClient("test-api-key",
+		client.WithBaseURL(client.MgcUrl(server.URL)),
+		client.WithTimeout(20*time.Millisecond),
+		client.WithRetryConfig(1, time.Millisecond, time.Millisecond, 1))
+
+	call := func(ctx context.Context) error {
+		req, _ := NewRequest[any](core.GetConfig(), ctx, http.MethodGet, "/slow", nil)
+		_, err := Do(core.GetConfig(), ctx, req, &mockResponse{})
+		return err
+	}
+
+	if err := call(client.WithCallTimeout(context.Background(), 2*time.Second)); err != nil {
+		t.Errorf("expected the call timeout to give the request room, got %v", err)
+	}
+	if err := call(context.Background()); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the client timeout to apply to other requests, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := call(client.WithCallTimeout(ctx, 2*time.Second)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the shorter context deadline to win, got %v", err)
+	}
+}
+
+func TestDo_AttemptHooks(t *testing.T) {
+	var calls atomic.Int32
+	var seenHeaders []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seenHeaders = append(seenHeaders, r.Header.Get("X-Attempt"))
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"message": "ok"}`))
+	}))
+	defer server.Close()
+
+	var before []int
+	var after []string
+	core := client.NewMgcClient("test-api-key",
+		client.WithBaseURL(client.MgcUrl(server.URL)),
+		client.WithRetryConfig(3, time.Millisecond, time.Millisecond, 1),
+		client.WithAttemptHooks(
+			func(attempt int, req *http.Request) {
+				before = append(before, attempt)
+				req.Header.Set("X-Attempt", fmt.Sprint(attempt))
+			},
+			func(attempt int, resp *http.Response, err error) {
+				after = append(after, fmt.Sprintf("%d:%d:%v", attempt, resp.StatusCode, err))
+			}))
+
+	req, _ := NewRequest[any](core.GetConfig(), context.Background(), http.MethodGet, "/test", nil)
+	if _, err := Do(core.GetConfig(), context.Background(), req, &mockResponse{}); err != nil {
+		t.Fatalf("Do() unexpected error: %v", err)
+	}
+
+	if fmt.Sprint(before) != "[1 2 3]" {
+		t.Errorf("expected BeforeAttempt for attempts [1 2 3], got %v", before)
+	}
+	if want := "[1:503:<nil> 2:503:<nil> 3:200:<nil>]"; fmt.Sprint(after) != want {
+		t.Errorf("expected AfterAttempt %s, got %v", want, after)
+	}
+	if fmt.Sprint(seenHeaders) != "[1 2 3]" {
+		t.Errorf("expected headers set by BeforeAttempt on each attempt, got %v", seenHeaders)
+	}
+	if req.Header.Get("X-Attempt") != "" {
+		t.Error("expected the caller's request to be left untouched")
+	}
+}
+
+func TestDo_Middlewares(t *testing.T) {
+	var attempts, failures atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) <= failures.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"message": "ok"}`))
+	}))
+	defer server.Close()
+
+	var calls []string
+	count := func(name string) client.Middleware {
+		return func(next client.Doer) client.Doer {
+			return client.DoerFunc(func(ctx context.Context, req *http.Request) (*http.Response, error) {
+				calls = append(calls, name)
+				return next.Do(ctx, req)
+			})
+		}
+	}
+	retry := client.RetryMiddleware(client.RetryConfig{
+		MaxAttempts: 3, InitialInterval: time.Millisecond, MaxInterval: time.Millisecond, BackoffFactor: 1,
+	}, nil)
+
+	tests := []struct {
+		name         string
+		middlewares  []client.Middleware
+		failures     int32
+		wantCalls    string
+		wantAttempts int32
+		wantErr      bool
+	}{
+		{
+			name:         "empty chain keeps the built-in retries",
+			wantCalls:    "[]",
+			failures:     1,
+			wantAttempts: 2,
+		},
+		{
+			name:         "chain without retry keeps the built-in retries",
+			middlewares:  []client.Middleware{count("outer")},
+			wantCalls:    "[outer]",
+			failures:     1,
+			wantAttempts: 2,
+		},
+		{
+			name:         "outside retry sees the request once",
+			middlewares:  []client.Middleware{count("outer"), retry},
+			wantCalls:    "[outer]",
+			failures:     1,
+			wantAttempts: 2,
+		},
+		{
+			name:         "inside retry sees every attempt",
+			middlewares:  []client.Middleware{retry, count("inner")},
+			wantCalls:    "[inner inner]",
+			failures:     1,
+			wantAttempts: 2,
+		},
+		{
+			name:         "retry in the chain replaces the built-in one",
+			middlewares:  []client.Middleware{retry},
+			failures:     10,
+			wantCalls:    "[]",
+			wantAttempts: 3,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts.Store(0)
+			failures.Store(tt.failures)
+			calls = nil
+			core := client.NewMgcClient("test-api-key",
+				client.WithBaseURL(client.MgcUrl(server.URL)),
+				client.WithRetryConfig(5, time.Millisecond, time.Millisecond, 1),
+				client.WithMiddlewares(tt.middlewares...))
+
+			req, _ := NewRequest[any](core.GetConfig(), context.Background(), http.MethodGet, "/test", nil)
+			_, err := Do(core.GetConfig(), context.Background(), req, &mockResponse{})
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Do() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var retryErr *client.RetryError
+			if tt.wantErr && (!errors.As(err, &retryErr) || retryErr.Retries != 3) {
+				t.Errorf("expected a RetryError after the chain's 3 attempts, got %v", err)
+			}
+			if got := attempts.Load(); got != tt.wantAttempts {
+				t.Errorf("expected %d attempts, got %d", tt.wantAttempts, got)
+			}
+			if fmt.Sprint(calls) != tt.wantCalls {
+				t.Errorf("expected middleware calls %s, got %v", tt.wantCalls, calls)
+			}
+		})
+	}
+}
+
+func TestDo_WithoutRetry(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	core := client.NewMgcClient("test-api-key",
+		client.WithBaseURL(client.MgcUrl(server.URL)),
+		client.WithRetryConfig(4, time.Millisecond, time.Millisecond, 1))
+
+	ctx := client.WithoutRetry(context.Background())
+	req, _ := NewRequest[any](core.GetConfig(), ctx, http.MethodGet, "/probe", nil)
+	_, err := Do[any](core.GetConfig(), ctx, req, nil)
+
+	var retryErr *client.RetryError
+	if !errors.As(err, &retryErr) || retryErr.Retries != 1 {
+		t.Errorf("expected a RetryError after 1 attempt, got %v", err)
+	}
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("expected a single attempt, got %d", got)
+	}
+
+	attempts.Store(0)
+	req, _ = NewRequest[any](core.GetConfig(), context.Background(), http.MethodGet, "/probe", nil)
+	Do[any](core.GetConfig(), context.Background(), req, nil)
+	if got := attempts.Load(); got != 4 {
+		t.Errorf("expected other calls to keep retrying, got %d attempts", got)
+	}
+}
+
+func TestDecodeJsonResponse_BufferReuse(t *testing.T) {
+	type withRaw struct {
+		Message string          `json:"message"`
+		Extra   json.RawMessage `json:"extra"`
+	}
+
+	decode := func(body string) *withRaw {
+		resp := &http.Response{Body: io.NopCloser(strings.NewReader(body))}
+		var v withRaw
+		got, err := decodeJsonResponse(resp, &v, false, 0)
+		if err != nil {
+			t.Fatalf("decodeJsonResponse() unexpected error: %v", err)
+		}
+		return got
+	}
+
+	first := decode(`{"message": "first", "extra": {"a": 1}}`)
+	decode(`{"message": "XXXXX", "extra": {"b": 2}}`)
+
+	if first.Message != "first" || string(first.Extra) != `{"a": 1}` {
+		t.Errorf("earlier result changed after the buffer was reused: %+v", first)
+	}
+}
+
+// largeListPayload returns a snapshot-listing-like JSON body of n items.
+func largeListPayload(n int) []byte {
+	var b strings.Builder
+	b.WriteString(`{"results": [`)
+	for i := range n {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		fmt.Fprintf(&b, `{"id": "item-%d", "name": "node-%d", "status": "ACTIVE", "size": %d,`+
+			` "labels": {"env": "prod", "team": "platform"}, "created_at": "2024-01-01T00:00:00Z"}`, i, i, i)
+	}
+	b.WriteString(`]}`)
+	return []byte(b.String())
+}
+
+func BenchmarkDecodeJsonResponse(b *testing.B) {
+	type item struct {
+		ID        string            `json:"id"`
+		Name      string            `json:"name"`
+		Status    string            `json:"status"`
+		Size      int               `json:"size"`
+		Labels    map[string]string `json:"labels"`
+		CreatedAt time.Time         `json:"created_at"`
+	}
+	type list struct {
+		Results []item `json:"results"`
+	}
+
+	payload := largeListPayload(5000)
+	for _, bc := range []struct {
+		name      string
+		maxPooled int
+	}{
+		{name: "pooled", maxPooled: 4 << 20},
+		{name: "unpooled", maxPooled: -1},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			b.SetBytes(int64(len(payload)))
+			b.ReportAllocs()
+			for b.Loop() {
+				resp := &http.Response{Body: io.NopCloser(bytes.NewReader(payload))}
+				var v list
+				if _, err := decodeJsonResponse(resp, &v, false, bc.maxPooled); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package mgc_http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+)
+
+// deprecationWarning reads the Deprecation and Sunset headers of a response. Deprecation is
+// either a structured date ("@1735689600"), an HTTP date or the legacy "true"; Sunset is an
+// HTTP date. It reports false when neither header is present.
+func deprecationWarning(header http.Header) (client.Warning, bool) {
+	deprecation := strings.TrimSpace(header.Get("Deprecation"))
+	sunset := strings.TrimSpace(header.Get("Sunset"))
+	if deprecation == "" && sunset == "" {
+		return client.Warning{}, false
+	}
+
+	w := client.Warning{Deprecated: true}
+	if deprecation != "" {
+		w.DeprecatedAt = parseDeprecationDate(deprecation)
+	}
+	if sunset != "" {
+		if t, err := http.ParseTime(sunset); err == nil {
+			w.Sunset = t.UTC()
+		}
+	}
+
+	w.Text = "endpoint is deprecated"
+	if !w.Sunset.IsZero() {
+		w.Text += " and will be removed after " + w.Sunset.Format(time.RFC3339)
+	}
+	return w, true
+}
+
+// parseDeprecationDate returns the date of a Deprecation header value, zero when it has none
+func parseDeprecationDate(value string) time.Time {
+	if seconds, ok := strings.CutPrefix(value, "@"); ok {
+		if n, err := strconv.ParseInt(seconds, 10, 64); err == nil {
+			return time.Unix(n, 0).UTC()
+		}
+		return time.Time{}
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return t.UTC()
+	}
+	return time.Time{}
+}
+
+// logDeprecation logs a deprecated endpoint once per client, keyed by method and path template
+func logDeprecation(c *client.Config, req *http.Request, w client.Warning) {
+	path := client.PathTemplate(req.Context())
+	if path == "" {
+		path = req.URL.Path
+	}
+	endpoint := req.Method + " " + path
+	if !c.FirstDeprecationNotice(endpoint) {
+		return
+	}
+
+	attrs := []any{"endpoint", endpoint}
+	if !w.DeprecatedAt.IsZero() {
+		attrs = append(attrs, "deprecatedAt", w.DeprecatedAt)
+	}
+	if !w.Sunset.IsZero() {
+		attrs = append(attrs, "sunset", w.Sunset)
+	}
+	c.Logger.Warn("API endpoint is deprecated", attrs...)
+}
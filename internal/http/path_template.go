@@ -0,0 +1,34 @@
+package mgc_http
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+)
+
+// ExpandPathTemplate fills the {placeholders} of template, in order, with the path-escaped
+// args. It returns the expanded path and a context recording basePath+template, so hooks and
+// metrics see "/compute/v1/snapshots/{id}" instead of the concrete URL. Placeholders without
+// a matching arg are left as they are.
+func ExpandPathTemplate(ctx context.Context, basePath, template string, args ...string) (context.Context, string) {
+	var b strings.Builder
+	rest := template
+	for _, arg := range args {
+		before, after, ok := strings.Cut(rest, "{")
+		if !ok {
+			break
+		}
+		_, after, ok = strings.Cut(after, "}")
+		if !ok {
+			break
+		}
+		b.WriteString(before)
+		b.WriteString(url.PathEscape(arg))
+		rest = after
+	}
+	b.WriteString(rest)
+
+	return client.WithPathTemplate(ctx, basePath+template), b.String()
+}
@@ -0,0 +1,37 @@
+package mgc_http
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+)
+
+func TestExpandPathTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		args     []string
+		want     string
+	}{
+		{name: "no placeholders", template: "/v1/snapshots", want: "/v1/snapshots"},
+		{name: "single placeholder", template: "/v1/snapshots/{id}", args: []string{"snap-1"}, want: "/v1/snapshots/snap-1"},
+		{name: "several placeholders", template: "/v0/clusters/{cluster_id}/node_pools/{node_pool_id}/nodes",
+			args: []string{"c1", "np1"}, want: "/v0/clusters/c1/node_pools/np1/nodes"},
+		{name: "args are escaped", template: "/v1/snapshots/{id}", args: []string{"a/b c"}, want: "/v1/snapshots/a%2Fb%20c"},
+		{name: "missing arg keeps placeholder", template: "/v1/snapshots/{id}/{action}", args: []string{"snap-1"},
+			want: "/v1/snapshots/snap-1/{action}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, got := ExpandPathTemplate(context.Background(), "/compute", tt.template, tt.args...)
+			if got != tt.want {
+				t.Errorf("ExpandPathTemplate() path = %q, want %q", got, tt.want)
+			}
+			if template := client.PathTemplate(ctx); template != "/compute"+tt.template {
+				t.Errorf("PathTemplate() = %q, want %q", template, "/compute"+tt.template)
+			}
+		})
+	}
+}
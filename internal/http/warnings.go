@@ -0,0 +1,151 @@
+package mgc_http
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+)
+
+// notifyWarnings calls the configured warning hook for every Warning header of resp, for
+// its Deprecation and Sunset headers and, for successful JSON responses, every entry of a
+// top-level "warnings" body field. The body is restored so it can still be decoded.
+// Deprecated endpoints are also logged once per client, with or without a hook.
+func notifyWarnings(c *client.Config, req *http.Request, resp *http.Response) error {
+	deprecation, deprecated := deprecationWarning(resp.Header)
+	if deprecated {
+		logDeprecation(c, req, deprecation)
+	}
+
+	if c.OnWarning == nil {
+		return nil
+	}
+
+	var warnings []client.Warning
+	for _, value := range resp.Header.Values("Warning") {
+		warnings = append(warnings, parseWarningHeader(value)...)
+	}
+	if deprecated {
+		warnings = append(warnings, deprecation)
+	}
+
+	isJSON := strings.Contains(resp.Header.Get("Content-Type"), "json")
+	if isJSON && resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		warnings = append(warnings, parseBodyWarnings(body)...)
+	}
+
+	for _, w := range warnings {
+		w.Method = req.Method
+		w.URL = req.URL.String()
+		w.PathTemplate = client.PathTemplate(req.Context())
+		c.OnWarning(w)
+	}
+	return nil
+}
+
+// parseWarningHeader parses a Warning header value ("299 - \"text\", 199 agent \"other\"").
+// Values that do not follow the warn-code warn-agent "warn-text" format are reported as plain text.
+func parseWarningHeader(value string) []client.Warning {
+	var warnings []client.Warning
+	rest := strings.TrimSpace(value)
+
+	for rest != "" {
+		w, remaining, ok := parseWarningValue(rest)
+		if !ok {
+			return append(warnings, client.Warning{Text: strings.TrimSpace(rest)})
+		}
+		warnings = append(warnings, w)
+		rest = strings.TrimLeft(remaining, ", ")
+	}
+	return warnings
+}
+
+// parseWarningValue parses a single warning and returns the unparsed remainder
+func parseWarningValue(s string) (client.Warning, string, bool) {
+	code, rest, ok := strings.Cut(s, " ")
+	if !ok {
+		return client.Warning{}, "", false
+	}
+	n, err := strconv.Atoi(code)
+	if err != nil {
+		return client.Warning{}, "", false
+	}
+
+	agent, rest, ok := strings.Cut(strings.TrimLeft(rest, " "), " ")
+	if !ok {
+		return client.Warning{}, "", false
+	}
+
+	text, rest, ok := cutQuoted(strings.TrimLeft(rest, " "))
+	if !ok {
+		return client.Warning{}, "", false
+	}
+
+	// skip the optional warn-date
+	if trimmed := strings.TrimLeft(rest, " "); strings.HasPrefix(trimmed, `"`) {
+		if _, afterDate, ok := cutQuoted(trimmed); ok {
+			rest = afterDate
+		}
+	}
+
+	return client.Warning{Code: n, Agent: agent, Text: text}, rest, true
+}
+
+// cutQuoted reads a quoted-string at the start of s, unescaping backslash escapes
+func cutQuoted(s string) (string, string, bool) {
+	if !strings.HasPrefix(s, `"`) {
+		return "", "", false
+	}
+
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if i+1 < len(s) {
+				i++
+				b.WriteByte(s[i])
+			}
+		case '"':
+			return b.String(), s[i+1:], true
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return "", "", false
+}
+
+// parseBodyWarnings reads a top-level "warnings" field holding strings or objects with a message
+func parseBodyWarnings(body []byte) []client.Warning {
+	var payload struct {
+		Warnings []json.RawMessage `json:"warnings"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil
+	}
+
+	var warnings []client.Warning
+	for _, raw := range payload.Warnings {
+		var text string
+		if err := json.Unmarshal(raw, &text); err == nil {
+			warnings = append(warnings, client.Warning{Text: text})
+			continue
+		}
+
+		var obj struct {
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal(raw, &obj); err == nil && obj.Message != "" {
+			warnings = append(warnings, client.Warning{Text: obj.Message})
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,176 @@
+package mgc_http
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+)
+
+func TestParseWarningHeader(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  []client.Warning
+	}{
+		{
+			name:  "single warning",
+			value: `299 - "field 'zone' is deprecated"`,
+			want:  []client.Warning{{Code: 299, Agent: "-", Text: "field 'zone' is deprecated"}},
+		},
+		{
+			name:  "multiple warnings with date and escapes",
+			value: `199 api.magalu.cloud "quota at 90%, consider \"cleanup\"" "Wed, 21 Oct 2015 07:28:00 GMT", 299 - "second"`,
+			want: []client.Warning{
+				{Code: 199, Agent: "api.magalu.cloud", Text: `quota at 90%, consider "cleanup"`},
+				{Code: 299, Agent: "-", Text: "second"},
+			},
+		},
+		{
+			name:  "free text",
+			value: "something is deprecated",
+			want:  []client.Warning{{Text: "something is deprecated"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseWarningHeader(tt.value)
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseWarningHeader() got %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("parseWarningHeader()[%d] = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDo_WarningHook(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Add("Warning", `299 - "machine type BV1-1-10 is deprecated"`)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"message": "ok", "warnings": ["quota nearing limit", {"message": "field renamed"}]}`))
+	}))
+	defer server.Close()
+
+	var warnings []client.Warning
+	core := client.NewMgcClient("test-api-key",
+		client.WithBaseURL(client.MgcUrl(server.URL)),
+		client.WithWarningHandler(func(w client.Warning) { warnings = append(warnings, w) }))
+
+	req, _ := NewRequest[any](core.GetConfig(), context.Background(), http.MethodGet, "/test", nil)
+	result, err := Do(core.GetConfig(), context.Background(), req, &mockResponse{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Message != "ok" {
+		t.Errorf("expected body to still be decoded, got %q", result.Message)
+	}
+
+	wantTexts := []string{"machine type BV1-1-10 is deprecated", "quota nearing limit", "field renamed"}
+	if len(warnings) != len(wantTexts) {
+		t.Fatalf("expected %d warnings, got %+v", len(wantTexts), warnings)
+	}
+	for i, text := range wantTexts {
+		if warnings[i].Text != text {
+			t.Errorf("warning %d = %q, want %q", i, warnings[i].Text, text)
+		}
+		if warnings[i].Method != http.MethodGet || warnings[i].URL != server.URL+"/test" {
+			t.Errorf("warning %d has request %s %s", i, warnings[i].Method, warnings[i].URL)
+		}
+	}
+	if warnings[0].Code != 299 {
+		t.Errorf("expected header warning code 299, got %d", warnings[0].Code)
+	}
+}
+
+func TestDo_DeprecationHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Deprecation", "@1735689600")
+		w.Header().Set("Sunset", "Wed, 01 Jul 2026 00:00:00 GMT")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"message": "ok"}`))
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	var warnings []client.Warning
+	core := client.NewMgcClient("test-api-key",
+		client.WithBaseURL(client.MgcUrl(server.URL)),
+		client.WithLogger(slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelWarn}))),
+		client.WithWarningHandler(func(w client.Warning) { warnings = append(warnings, w) }))
+
+	for range 2 {
+		ctx := client.WithPathTemplate(context.Background(), "/v1/things/{id}")
+		req, _ := NewRequest[any](core.GetConfig(), ctx, http.MethodGet, "/v1/things/1", nil)
+		if _, err := Do(core.GetConfig(), ctx, req, &mockResponse{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if len(warnings) != 2 {
+		t.Fatalf("expected a deprecation warning per call, got %+v", warnings)
+	}
+	w := warnings[0]
+	if !w.Deprecated {
+		t.Error("expected the warning to be marked deprecated")
+	}
+	if want := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC); !w.DeprecatedAt.Equal(want) {
+		t.Errorf("DeprecatedAt = %v, want %v", w.DeprecatedAt, want)
+	}
+	if want := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC); !w.Sunset.Equal(want) {
+		t.Errorf("Sunset = %v, want %v", w.Sunset, want)
+	}
+	if !strings.Contains(w.Text, "2026-07-01") || w.PathTemplate != "/v1/things/{id}" {
+		t.Errorf("unexpected warning %+v", w)
+	}
+
+	if n := strings.Count(logs.String(), "API endpoint is deprecated"); n != 1 {
+		t.Errorf("expected the deprecation to be logged once, got %d times:\n%s", n, logs.String())
+	}
+	if !strings.Contains(logs.String(), "endpoint=\"GET /v1/things/{id}\"") {
+		t.Errorf("expected the log to name the endpoint, got %s", logs.String())
+	}
+}
+
+func TestDeprecationWarning(t *testing.T) {
+	tests := []struct {
+		name       string
+		header     http.Header
+		deprecated bool
+		wantAt     time.Time
+	}{
+		{name: "no headers", header: http.Header{}},
+		{name: "legacy true", header: http.Header{"Deprecation": {"true"}}, deprecated: true},
+		{
+			name:       "http date",
+			header:     http.Header{"Deprecation": {"Sun, 01 Jun 2025 00:00:00 GMT"}},
+			deprecated: true,
+			wantAt:     time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{name: "sunset only", header: http.Header{"Sunset": {"Wed, 01 Jul 2026 00:00:00 GMT"}}, deprecated: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, ok := deprecationWarning(tt.header)
+			if ok != tt.deprecated || w.Deprecated != tt.deprecated {
+				t.Fatalf("deprecationWarning() = %+v, %v, want deprecated %v", w, ok, tt.deprecated)
+			}
+			if !w.DeprecatedAt.Equal(tt.wantAt) {
+				t.Errorf("DeprecatedAt = %v, want %v", w.DeprecatedAt, tt.wantAt)
+			}
+		})
+	}
+}
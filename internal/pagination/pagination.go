@@ -0,0 +1,56 @@
+// Package pagination provides lazy iteration over offset-paginated list endpoints.
+package pagination
+
+import (
+	"context"
+	"iter"
+)
+
+// DefaultPageSize is the page size used when a Paginator has none set.
+const DefaultPageSize = 50
+
+// FetchPage fetches up to limit items starting at offset.
+type FetchPage[T any] func(ctx context.Context, offset, limit int) ([]T, error)
+
+// Paginator walks an offset-paginated endpoint, fetching pages on demand.
+type Paginator[T any] struct {
+	Fetch FetchPage[T]
+	// PageSize is the number of items requested per page. Defaults to DefaultPageSize.
+	PageSize int
+	// Offset is the offset of the first page.
+	Offset int
+}
+
+// All returns an iterator over every item, fetching the next page only when the
+// previous one is exhausted. A page shorter than PageSize ends the iteration.
+// A fetch error is yielded once with the zero value of T and ends the iteration.
+// Breaking out of the loop stops fetching.
+func (p Paginator[T]) All(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		pageSize := p.PageSize
+		if pageSize <= 0 {
+			pageSize = DefaultPageSize
+		}
+
+		offset := p.Offset
+		for {
+			page, err := p.Fetch(ctx, offset, pageSize)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range page {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if len(page) < pageSize {
+				return
+			}
+			offset += len(page)
+		}
+	}
+}
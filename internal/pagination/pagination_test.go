@@ -0,0 +1,107 @@
+package pagination
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func fakeFetch(items []int, calls *[]int) FetchPage[int] {
+	return func(ctx context.Context, offset, limit int) ([]int, error) {
+		*calls = append(*calls, offset)
+		if offset >= len(items) {
+			return nil, nil
+		}
+		end := min(offset+limit, len(items))
+		return items[offset:end], nil
+	}
+}
+
+func TestPaginator_All(t *testing.T) {
+	tests := []struct {
+		name      string
+		items     []int
+		pageSize  int
+		offset    int
+		wantItems int
+		wantCalls []int
+	}{
+		{name: "multiple pages", items: []int{1, 2, 3, 4, 5}, pageSize: 2, wantItems: 5, wantCalls: []int{0, 2, 4}},
+		{name: "exact multiple of page size", items: []int{1, 2, 3, 4}, pageSize: 2, wantItems: 4, wantCalls: []int{0, 2, 4}},
+		{name: "starting offset", items: []int{1, 2, 3, 4, 5}, pageSize: 2, offset: 3, wantItems: 2, wantCalls: []int{3, 5}},
+		{name: "empty", items: nil, pageSize: 2, wantItems: 0, wantCalls: []int{0}},
+		{name: "default page size", items: []int{1, 2, 3}, wantItems: 3, wantCalls: []int{0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []int
+			p := Paginator[int]{Fetch: fakeFetch(tt.items, &calls), PageSize: tt.pageSize, Offset: tt.offset}
+
+			count := 0
+			for _, err := range p.All(context.Background()) {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				count++
+			}
+
+			if count != tt.wantItems {
+				t.Errorf("expected %d items, got %d", tt.wantItems, count)
+			}
+			if len(calls) != len(tt.wantCalls) {
+				t.Fatalf("expected fetches at %v, got %v", tt.wantCalls, calls)
+			}
+			for i := range calls {
+				if calls[i] != tt.wantCalls[i] {
+					t.Errorf("expected fetches at %v, got %v", tt.wantCalls, calls)
+				}
+			}
+		})
+	}
+}
+
+func TestPaginator_All_Break(t *testing.T) {
+	var calls []int
+	p := Paginator[int]{Fetch: fakeFetch([]int{1, 2, 3, 4, 5}, &calls), PageSize: 2}
+
+	for item := range p.All(context.Background()) {
+		if item == 2 {
+			break
+		}
+	}
+
+	if len(calls) != 1 {
+		t.Errorf("expected a single fetch after early break, got %v", calls)
+	}
+}
+
+func TestPaginator_All_Error(t *testing.T) {
+	fetchErr := errors.New("boom")
+	p := Paginator[int]{
+		PageSize: 2,
+		Fetch: func(ctx context.Context, offset, limit int) ([]int, error) {
+			if offset > 0 {
+				return nil, fetchErr
+			}
+			return []int{1, 2}, nil
+		},
+	}
+
+	var items []int
+	var gotErr error
+	for item, err := range p.All(context.Background()) {
+		if err != nil {
+			gotErr = err
+			continue
+		}
+		items = append(items, item)
+	}
+
+	if !errors.Is(gotErr, fetchErr) {
+		t.Errorf("expected fetch error, got %v", gotErr)
+	}
+	if len(items) != 2 {
+		t.Errorf("expected items before the error, got %v", items)
+	}
+}
@@ -1,7 +1,8 @@
 package retry
 
 import (
-	"math"
+	"net/http"
+	"strconv"
 	"time"
 )
 
@@ -9,7 +10,22 @@ func ShouldRetry(statusCode int) bool {
 	return statusCode >= 500 || statusCode == 429
 }
 
-func GetNextBackoff(attempt int, backoffFactor float64, initialInterval, maxInterval time.Duration) time.Duration {
-	multiplier := math.Pow(backoffFactor, float64(attempt))
-	return min(initialInterval*time.Duration(multiplier), maxInterval)
+// RetryAfter parses a Retry-After header value, given either in seconds or as an HTTP date,
+// into the time to wait from now. A date in the past yields zero. The second result is false
+// when the header is empty or malformed.
+func RetryAfter(header string, now time.Time) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	at, err := http.ParseTime(header)
+	if err != nil {
+		return 0, false
+	}
+	return max(at.Sub(now), 0), true
 }
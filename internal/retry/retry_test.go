@@ -32,54 +32,28 @@ func Test_shouldRetry(t *testing.T) {
 	}
 }
 
-func Test_getNextBackoff(t *testing.T) {
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2024, time.January, 2, 12, 0, 0, 0, time.UTC)
 	tests := []struct {
-		name            string
-		attempt         int
-		backoffFactor   float64
-		initialInterval time.Duration
-		maxInterval     time.Duration
-		wantDelay       time.Duration
+		name   string
+		header string
+		want   time.Duration
+		wantOK bool
 	}{
-		{
-			name:            "first attempt with default config",
-			attempt:         0,
-			backoffFactor:   2.0,
-			initialInterval: time.Second,
-			maxInterval:     time.Second * 30,
-			wantDelay:       time.Second,
-		},
-		{
-			name:            "second attempt with default config",
-			attempt:         1,
-			backoffFactor:   2.0,
-			initialInterval: time.Second,
-			maxInterval:     time.Second * 30,
-			wantDelay:       time.Second * 2,
-		},
-		{
-			name:            "max interval reached",
-			attempt:         10,
-			backoffFactor:   2.0,
-			initialInterval: time.Second,
-			maxInterval:     time.Second * 30,
-			wantDelay:       time.Second * 30,
-		},
-		{
-			name:            "custom config with different factor",
-			attempt:         2,
-			backoffFactor:   1.5,
-			initialInterval: time.Second * 2,
-			maxInterval:     time.Second * 60,
-			wantDelay:       time.Second * 4,
-		},
+		{name: "seconds", header: "120", want: 2 * time.Minute, wantOK: true},
+		{name: "zero seconds", header: "0", want: 0, wantOK: true},
+		{name: "http date", header: "Tue, 02 Jan 2024 12:00:30 GMT", want: 30 * time.Second, wantOK: true},
+		{name: "date in the past", header: "Tue, 02 Jan 2024 11:00:00 GMT", want: 0, wantOK: true},
+		{name: "empty", header: ""},
+		{name: "negative seconds", header: "-5"},
+		{name: "malformed", header: "soon"},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := GetNextBackoff(tt.attempt, tt.backoffFactor, tt.initialInterval, tt.maxInterval)
-			if got != tt.wantDelay {
-				t.Errorf("getNextBackoff() = %v, want %v", got, tt.wantDelay)
+			got, ok := RetryAfter(tt.header, now)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("RetryAfter(%q) = %v, %v, want %v, %v", tt.header, got, ok, tt.want, tt.wantOK)
 			}
 		})
 	}
@@ -0,0 +1,19 @@
+package utils
+
+import "context"
+
+// attemptKey is the context key holding the number of the attempt being sent.
+type attemptKey struct{}
+
+// WithAttempt returns a context for sending attempt n of a request, counted from 1.
+func WithAttempt(ctx context.Context, n int) context.Context {
+	return context.WithValue(ctx, attemptKey{}, n)
+}
+
+// AttemptFrom returns the attempt number set by WithAttempt, or 1.
+func AttemptFrom(ctx context.Context) int {
+	if n, ok := ctx.Value(attemptKey{}).(int); ok {
+		return n
+	}
+	return 1
+}
@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MinBatchItemBudget is the least time a batch item is given before its context's deadline.
+// Items that would start with less are not sent.
+const MinBatchItemBudget = 250 * time.Millisecond
+
+// BatchBudget shares the time left before a context's deadline among the items of a batch run
+// by a bounded number of workers, so the first items cannot use up the whole budget. Without a
+// deadline on the context it only checks that the context is not done. The time left is
+// measured with the context's Clock, see WithClock.
+type BatchBudget struct {
+	concurrency int
+
+	mu      sync.Mutex
+	pending int
+}
+
+// NewBatchBudget returns the budget of a batch of items run at most concurrency at a time.
+func NewBatchBudget(items, concurrency int) *BatchBudget {
+	return &BatchBudget{concurrency: max(concurrency, 1), pending: items}
+}
+
+// Start is called when an item is about to be sent. It returns a context for the item bounded
+// to its share of the time left: that time divided by the rounds of concurrency items still to
+// run, and at least MinBatchItemBudget. When ctx is done, or less than MinBatchItemBudget is left
+// before its deadline, Start returns an error wrapping ctx.Err() or context.DeadlineExceeded and
+// the item must not be sent. The returned cancel function must be called once the item is done.
+func (b *BatchBudget) Start(ctx context.Context) (context.Context, context.CancelFunc, error) {
+	b.mu.Lock()
+	rounds := (b.pending + b.concurrency - 1) / b.concurrency
+	b.pending--
+	b.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, nil, fmt.Errorf("not started: %w", err)
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		ctx, cancel := context.WithCancel(ctx)
+		return ctx, cancel, nil
+	}
+
+	left := deadline.Sub(ClockFrom(ctx).Now())
+	if left < MinBatchItemBudget {
+		return nil, nil, fmt.Errorf("not started with %s left before the deadline: %w",
+			left.Round(time.Millisecond), context.DeadlineExceeded)
+	}
+	share := max(left/time.Duration(max(rounds, 1)), MinBatchItemBudget)
+	ctx, cancel := context.WithTimeout(ctx, share)
+	return ctx, cancel, nil
+}
@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBatchBudget_Start(t *testing.T) {
+	t.Run("no deadline", func(t *testing.T) {
+		ctx, cancel, err := NewBatchBudget(3, 1).Start(context.Background())
+		if err != nil {
+			t.Fatalf("Start() unexpected error: %v", err)
+		}
+		defer cancel()
+		if _, ok := ctx.Deadline(); ok {
+			t.Error("Start() set a deadline on a context without one")
+		}
+	})
+
+	t.Run("shares the time left among rounds", func(t *testing.T) {
+		parent, cancelParent := context.WithTimeout(context.Background(), 4*time.Second)
+		defer cancelParent()
+
+		// 4 items, 2 at a time: the first gets half of the time left, since 2 rounds remain
+		budget := NewBatchBudget(4, 2)
+		ctx, cancel, err := budget.Start(parent)
+		if err != nil {
+			t.Fatalf("Start() unexpected error: %v", err)
+		}
+		defer cancel()
+		deadline, _ := ctx.Deadline()
+		if left := time.Until(deadline); left > 2*time.Second || left < 1500*time.Millisecond {
+			t.Errorf("item deadline in %s, want about 2s", left)
+		}
+	})
+
+	t.Run("measures the time left with the context clock", func(t *testing.T) {
+		start := time.Now()
+		clock := NewFakeClock(start)
+		parent, cancelParent := context.WithDeadline(WithClock(context.Background(), clock), start.Add(time.Hour))
+		defer cancelParent()
+
+		clock.Advance(time.Hour - MinBatchItemBudget/2)
+		if _, _, err := NewBatchBudget(1, 1).Start(parent); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Start() error = %v, want context.DeadlineExceeded", err)
+		}
+	})
+
+	t.Run("keeps a minimum per item", func(t *testing.T) {
+		parent, cancelParent := context.WithTimeout(context.Background(), 2*MinBatchItemBudget)
+		defer cancelParent()
+
+		ctx, cancel, err := NewBatchBudget(100, 1).Start(parent)
+		if err != nil {
+			t.Fatalf("Start() unexpected error: %v", err)
+		}
+		defer cancel()
+		deadline, _ := ctx.Deadline()
+		if left := time.Until(deadline); left < MinBatchItemBudget-50*time.Millisecond {
+			t.Errorf("item deadline in %s, want at least about %s", left, MinBatchItemBudget)
+		}
+	})
+
+	t.Run("deadline near", func(t *testing.T) {
+		parent, cancelParent := context.WithTimeout(context.Background(), MinBatchItemBudget/2)
+		defer cancelParent()
+
+		_, _, err := NewBatchBudget(1, 1).Start(parent)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Start() error = %v, want context.DeadlineExceeded", err)
+		}
+	})
+
+	t.Run("context cancelled", func(t *testing.T) {
+		parent, cancelParent := context.WithCancel(context.Background())
+		cancelParent()
+
+		_, _, err := NewBatchBudget(1, 1).Start(parent)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Start() error = %v, want context.Canceled", err)
+		}
+	})
+}
@@ -0,0 +1,149 @@
+package utils
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Clock is the source of time of the waiters, retry loops, rate limits, caches and batch
+// budgets, so tests can replace the real one with a FakeClock through WithClock.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// Sleep waits for d, returning early with ctx.Err() when ctx is done first.
+	Sleep(ctx context.Context, d time.Duration) error
+	// After returns a channel that receives the time once d has passed.
+	After(d time.Duration) <-chan time.Time
+}
+
+// RealClock is the Clock backed by the time package.
+var RealClock Clock = realClock{}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) Sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// clockKey is the context key holding the Clock set by WithClock.
+type clockKey struct{}
+
+// WithClock returns a context whose waiters, retry loops and rate limits use clock.
+func WithClock(ctx context.Context, clock Clock) context.Context {
+	return context.WithValue(ctx, clockKey{}, clock)
+}
+
+// ClockFrom returns the Clock set on ctx by WithClock, or RealClock.
+func ClockFrom(ctx context.Context) Clock {
+	if clock, ok := ctx.Value(clockKey{}).(Clock); ok && clock != nil {
+		return clock
+	}
+	return RealClock
+}
+
+// FakeClock is a Clock for tests whose time only moves when told to. Sleep returns at once,
+// moving the clock forward by the time slept, and a deadline on the sleeping context is
+// honoured in the fake time: a sleep that would pass it stops there and returns
+// context.DeadlineExceeded. Build the deadline from Now so it is exact.
+type FakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	sleeps []time.Duration
+	timers []fakeTimer
+}
+
+// fakeTimer is a channel returned by FakeClock.After, fired once the clock reaches at.
+type fakeTimer struct {
+	at time.Time
+	ch chan time.Time
+}
+
+// NewFakeClock returns a FakeClock set to now.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the fake current time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Sleep records d and moves the clock forward by it, or up to the deadline of ctx when
+// that comes first, in which case it returns context.DeadlineExceeded.
+func (c *FakeClock) Sleep(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sleeps = append(c.sleeps, d)
+	if deadline, ok := ctx.Deadline(); ok && !c.now.Add(d).Before(deadline) {
+		c.advance(deadline.Sub(c.now))
+		return context.DeadlineExceeded
+	}
+	c.advance(d)
+	return nil
+}
+
+// After returns a channel that receives the fake time once the clock has moved d forward.
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.timers = append(c.timers, fakeTimer{at: c.now.Add(d), ch: ch})
+	return ch
+}
+
+// Advance moves the clock forward by d, firing the channels of After that are due.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.advance(d)
+}
+
+// Sleeps returns the durations passed to Sleep, in order.
+func (c *FakeClock) Sleeps() []time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]time.Duration(nil), c.sleeps...)
+}
+
+// advance moves the clock forward by d and fires the due timers. c.mu must be held.
+func (c *FakeClock) advance(d time.Duration) {
+	if d > 0 {
+		c.now = c.now.Add(d)
+	}
+
+	pending := c.timers[:0]
+	for _, timer := range c.timers {
+		if timer.at.After(c.now) {
+			pending = append(pending, timer)
+			continue
+		}
+		timer.ch <- c.now
+	}
+	c.timers = pending
+}
@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestClockFrom(t *testing.T) {
+	if got := ClockFrom(context.Background()); got != RealClock {
+		t.Errorf("ClockFrom() = %v, want RealClock without WithClock", got)
+	}
+
+	clock := NewFakeClock(time.Now())
+	if got := ClockFrom(WithClock(context.Background(), clock)); got != clock {
+		t.Errorf("ClockFrom() = %v, want the clock set by WithClock", got)
+	}
+}
+
+func TestRealClock_Sleep(t *testing.T) {
+	if err := RealClock.Sleep(context.Background(), time.Millisecond); err != nil {
+		t.Errorf("Sleep() unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := RealClock.Sleep(ctx, time.Hour); !errors.Is(err, context.Canceled) {
+		t.Errorf("Sleep() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestFakeClock_Sleep(t *testing.T) {
+	// the deadline must also lie ahead in real time, or ctx is already done
+	start := time.Now()
+	clock := NewFakeClock(start)
+	ctx, cancel := context.WithDeadline(context.Background(), start.Add(25*time.Second))
+	defer cancel()
+
+	for range 2 {
+		if err := clock.Sleep(ctx, 10*time.Second); err != nil {
+			t.Fatalf("Sleep() unexpected error: %v", err)
+		}
+	}
+	if err := clock.Sleep(ctx, 10*time.Second); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Sleep() error = %v, want context.DeadlineExceeded past the deadline", err)
+	}
+
+	if got := clock.Now(); !got.Equal(start.Add(25 * time.Second)) {
+		t.Errorf("Now() = %v, want the deadline", got)
+	}
+	want := []time.Duration{10 * time.Second, 10 * time.Second, 10 * time.Second}
+	if got := clock.Sleeps(); !slices.Equal(got, want) {
+		t.Errorf("Sleeps() = %v, want %v", got, want)
+	}
+}
+
+func TestFakeClock_After(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	ch := clock.After(time.Minute)
+
+	clock.Advance(30 * time.Second)
+	select {
+	case <-ch:
+		t.Fatal("After() fired before its time")
+	default:
+	}
+
+	clock.Advance(30 * time.Second)
+	select {
+	case got := <-ch:
+		if want := clock.Now(); !got.Equal(want) {
+			t.Errorf("After() sent %v, want %v", got, want)
+		}
+	default:
+		t.Error("After() did not fire once its time was reached")
+	}
+
+	select {
+	case <-clock.After(0):
+	default:
+		t.Error("After(0) did not fire at once")
+	}
+}
@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// UnknownJSONFields returns the top-level fields of the JSON object in data that do not
+// map to any field of the struct v, or nil when there are none. Field names are matched
+// case-insensitively, like encoding/json does.
+//
+// Response types keep these fields in a RawExtra map as a compatibility bridge: callers can
+// read fields the API has added before the SDK declares them, and should move to the typed
+// fields once it does. It also lets strict decoding accept such types (see
+// client.WithStrictDecoding), since their unknown fields are collected rather than lost.
+func UnknownJSONFields(data []byte, v any) (map[string]json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	known := jsonFieldNames(reflect.TypeOf(v))
+	for name := range fields {
+		if known[strings.ToLower(name)] {
+			delete(fields, name)
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	return fields, nil
+}
+
+// UnmarshalWithExtra decodes data into v, a pointer to a struct, and returns the fields of
+// data it has no field for, see UnknownJSONFields. UnmarshalJSON methods call it with a
+// pointer to a local copy of their type, which lacks the method and so avoids recursion.
+func UnmarshalWithExtra(data []byte, v any) (map[string]json.RawMessage, error) {
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+	return UnknownJSONFields(data, v)
+}
+
+// jsonFieldNames returns the lowercased JSON names of the exported fields of t,
+// including those promoted from embedded structs.
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	names := make(map[string]bool)
+	if t.Kind() != reflect.Struct {
+		return names
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, _, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			for embedded := range jsonFieldNames(f.Type) {
+				names[embedded] = true
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		names[strings.ToLower(name)] = true
+	}
+	return names
+}
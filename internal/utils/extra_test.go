@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUnknownJSONFields(t *testing.T) {
+	type Base struct {
+		ID string `json:"id"`
+	}
+	type resource struct {
+		Base
+		Name   string `json:"name,omitempty"`
+		Status string
+		Extra  map[string]json.RawMessage `json:"-"`
+	}
+
+	extra, err := UnknownJSONFields([]byte(`{"id":"1","NAME":"n","status":"ok","zone":"br-se1-a","tier":{"level":2}}`), resource{})
+	if err != nil {
+		t.Fatalf("UnknownJSONFields() unexpected error: %v", err)
+	}
+	if len(extra) != 2 {
+		t.Fatalf("expected 2 unknown fields, got %v", extra)
+	}
+	if string(extra["zone"]) != `"br-se1-a"` || string(extra["tier"]) != `{"level":2}` {
+		t.Errorf("unexpected unknown fields %v", extra)
+	}
+
+	extra, err = UnknownJSONFields([]byte(`{"id":"1","name":"n"}`), &resource{})
+	if err != nil || extra != nil {
+		t.Errorf("expected no unknown fields, got %v, %v", extra, err)
+	}
+
+	if _, err := UnknownJSONFields([]byte(`[1,2]`), resource{}); err == nil {
+		t.Error("expected error for a non-object payload")
+	}
+
+	var decoded resource
+	extra, err = UnmarshalWithExtra([]byte(`{"id":"1","name":"n","zone":"br-se1-a"}`), &decoded)
+	if err != nil {
+		t.Fatalf("UnmarshalWithExtra() unexpected error: %v", err)
+	}
+	if decoded.ID != "1" || decoded.Name != "n" || len(extra) != 1 || string(extra["zone"]) != `"br-se1-a"` {
+		t.Errorf("UnmarshalWithExtra() = %+v, %v", decoded, extra)
+	}
+}
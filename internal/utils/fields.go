@@ -0,0 +1,27 @@
+package utils
+
+import "strings"
+
+// FieldsQueryParam is the query parameter selecting the fields of a partial response.
+const FieldsQueryParam = "_fields"
+
+// FieldsQuery joins the fields requested for a partial response into the value of
+// FieldsQueryParam, adding the required fields first and dropping blanks and duplicates.
+// It returns "" when no fields are requested, meaning the full object.
+func FieldsQuery(fields []string, required ...string) string {
+	if len(fields) == 0 {
+		return ""
+	}
+
+	seen := map[string]bool{}
+	var selected []string
+	for _, field := range append(required, fields...) {
+		field = strings.TrimSpace(field)
+		if field == "" || seen[field] {
+			continue
+		}
+		seen[field] = true
+		selected = append(selected, field)
+	}
+	return strings.Join(selected, ",")
+}
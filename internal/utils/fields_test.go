@@ -0,0 +1,25 @@
+package utils
+
+import "testing"
+
+func TestFieldsQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		fields   []string
+		required []string
+		want     string
+	}{
+		{name: "no fields", required: []string{"id"}, want: ""},
+		{name: "adds required fields first", fields: []string{"name", "status"}, required: []string{"id"}, want: "id,name,status"},
+		{name: "drops duplicates and blanks", fields: []string{"name", " ", "id", "name"}, required: []string{"id"}, want: "id,name"},
+		{name: "trims fields", fields: []string{" name "}, want: "name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FieldsQuery(tt.fields, tt.required...); got != tt.want {
+				t.Errorf("FieldsQuery() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
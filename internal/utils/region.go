@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// SplitRegionURL splits a regional base URL, which ends with the region code as in
+// https://api.magalu.cloud/br-se1, into that code and the URL of the global services
+// served from the same host without it.
+func SplitRegionURL(baseURL string) (region, globalURL string, err error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", "", fmt.Errorf("parse base URL %q: %w", baseURL, err)
+	}
+
+	p := strings.TrimSuffix(u.Path, "/")
+	region = path.Base(p)
+	if p == "" || region == "." || region == "/" {
+		return "", "", fmt.Errorf("cannot tell the region of base URL %s", baseURL)
+	}
+	u.Path = strings.TrimSuffix(path.Dir(p), "/")
+	return region, u.String(), nil
+}
@@ -0,0 +1,31 @@
+package utils
+
+import "testing"
+
+func TestSplitRegionURL(t *testing.T) {
+	tests := []struct {
+		baseURL    string
+		wantRegion string
+		wantGlobal string
+		wantErr    bool
+	}{
+		{baseURL: "https://api.magalu.cloud/br-se1", wantRegion: "br-se1", wantGlobal: "https://api.magalu.cloud"},
+		{baseURL: "https://api.magalu.cloud/br-ne1/", wantRegion: "br-ne1", wantGlobal: "https://api.magalu.cloud"},
+		{baseURL: "http://127.0.0.1:8080/proxy/br-se1", wantRegion: "br-se1", wantGlobal: "http://127.0.0.1:8080/proxy"},
+		{baseURL: "http://127.0.0.1:8080", wantErr: true},
+		{baseURL: "http://127.0.0.1:8080/", wantErr: true},
+		{baseURL: "://bad", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.baseURL, func(t *testing.T) {
+			region, global, err := SplitRegionURL(tt.baseURL)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SplitRegionURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if region != tt.wantRegion || global != tt.wantGlobal {
+				t.Errorf("SplitRegionURL() = %q, %q, want %q, %q", region, global, tt.wantRegion, tt.wantGlobal)
+			}
+		})
+	}
+}
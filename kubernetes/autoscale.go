@@ -0,0 +1,62 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// enabled reports whether the autoscale configuration turns autoscaling on, that is it is
+// set and has at least one bound. A nil AutoScale means autoscaling is disabled.
+func (a *AutoScale) enabled() bool {
+	return a != nil && (a.MinReplicas != nil || a.MaxReplicas != nil)
+}
+
+// describe returns the bounds as "min 1, max 5", or "disabled".
+func (a *AutoScale) describe() string {
+	if !a.enabled() {
+		return "disabled"
+	}
+	return fmt.Sprintf("min %s, max %s", formatBound(a.MinReplicas), formatBound(a.MaxReplicas))
+}
+
+func formatBound(bound *int) string {
+	if bound == nil {
+		return "unset"
+	}
+	return strconv.Itoa(*bound)
+}
+
+// AutoScaleMatches reports whether the node pool autoscales between exactly minReplicas and
+// maxReplicas. It is false for a node pool without autoscaling.
+func (p NodePool) AutoScaleMatches(minReplicas, maxReplicas int) bool {
+	drifted, _ := p.AutoScaleDrift(AutoScale{MinReplicas: &minReplicas, MaxReplicas: &maxReplicas})
+	return !drifted
+}
+
+// AutoScaleDrift compares the node pool's autoscaling to desired and reports whether they
+// differ, with a description of the differences for logs and events. A nil AutoScale, or one
+// with neither bound set, means autoscaling is disabled on either side, so a zero desired
+// value detects pools that should not autoscale. details is "" when there is no drift.
+func (p NodePool) AutoScaleDrift(desired AutoScale) (drifted bool, details string) {
+	current := p.AutoScale
+	switch {
+	case !current.enabled() && !desired.enabled():
+		return false, ""
+	case !current.enabled():
+		return true, fmt.Sprintf("autoscaling is disabled, want %s", desired.describe())
+	case !desired.enabled():
+		return true, fmt.Sprintf("autoscaling is enabled (%s), want disabled", current.describe())
+	}
+
+	var diffs []string
+	if !equalIntPtr(current.MinReplicas, desired.MinReplicas) {
+		diffs = append(diffs, fmt.Sprintf("min replicas is %s, want %s",
+			formatBound(current.MinReplicas), formatBound(desired.MinReplicas)))
+	}
+	if !equalIntPtr(current.MaxReplicas, desired.MaxReplicas) {
+		diffs = append(diffs, fmt.Sprintf("max replicas is %s, want %s",
+			formatBound(current.MaxReplicas), formatBound(desired.MaxReplicas)))
+	}
+	return len(diffs) > 0, strings.Join(diffs, "; ")
+}
@@ -0,0 +1,82 @@
+package kubernetes
+
+import "testing"
+
+func TestNodePool_AutoScaleDrift(t *testing.T) {
+	tests := []struct {
+		name        string
+		current     *AutoScale
+		desired     AutoScale
+		wantDrift   bool
+		wantDetails string
+	}{
+		{
+			name: "both disabled",
+		},
+		{
+			name:    "empty bounds count as disabled",
+			current: &AutoScale{},
+		},
+		{
+			name:        "disabled to enabled",
+			desired:     AutoScale{MinReplicas: intPtr(1), MaxReplicas: intPtr(5)},
+			wantDrift:   true,
+			wantDetails: "autoscaling is disabled, want min 1, max 5",
+		},
+		{
+			name:        "enabled to disabled",
+			current:     &AutoScale{MinReplicas: intPtr(1), MaxReplicas: intPtr(5)},
+			wantDrift:   true,
+			wantDetails: "autoscaling is enabled (min 1, max 5), want disabled",
+		},
+		{
+			name:    "same bounds",
+			current: &AutoScale{MinReplicas: intPtr(1), MaxReplicas: intPtr(5)},
+			desired: AutoScale{MinReplicas: intPtr(1), MaxReplicas: intPtr(5)},
+		},
+		{
+			name:        "max changed",
+			current:     &AutoScale{MinReplicas: intPtr(1), MaxReplicas: intPtr(5)},
+			desired:     AutoScale{MinReplicas: intPtr(1), MaxReplicas: intPtr(10)},
+			wantDrift:   true,
+			wantDetails: "max replicas is 5, want 10",
+		},
+		{
+			name:        "both bounds changed",
+			current:     &AutoScale{MinReplicas: intPtr(1), MaxReplicas: intPtr(5)},
+			desired:     AutoScale{MinReplicas: intPtr(2), MaxReplicas: intPtr(10)},
+			wantDrift:   true,
+			wantDetails: "min replicas is 1, want 2; max replicas is 5, want 10",
+		},
+		{
+			name:        "bound unset on one side",
+			current:     &AutoScale{MaxReplicas: intPtr(5)},
+			desired:     AutoScale{MinReplicas: intPtr(1), MaxReplicas: intPtr(5)},
+			wantDrift:   true,
+			wantDetails: "min replicas is unset, want 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := NodePool{AutoScale: tt.current}
+			drifted, details := pool.AutoScaleDrift(tt.desired)
+			if drifted != tt.wantDrift || details != tt.wantDetails {
+				t.Errorf("AutoScaleDrift() = %v, %q, want %v, %q", drifted, details, tt.wantDrift, tt.wantDetails)
+			}
+		})
+	}
+}
+
+func TestNodePool_AutoScaleMatches(t *testing.T) {
+	pool := NodePool{AutoScale: &AutoScale{MinReplicas: intPtr(1), MaxReplicas: intPtr(5)}}
+	if !pool.AutoScaleMatches(1, 5) {
+		t.Error("expected bounds 1-5 to match")
+	}
+	if pool.AutoScaleMatches(1, 6) {
+		t.Error("expected bounds 1-6 not to match")
+	}
+	if (NodePool{}).AutoScaleMatches(1, 5) {
+		t.Error("expected a node pool without autoscaling not to match")
+	}
+}
@@ -0,0 +1,92 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+	"github.com/MagaluCloud/mgc-sdk-go/internal/utils"
+)
+
+type (
+	// CapacitySummary aggregates the node resources of a cluster
+	CapacitySummary struct {
+		// Replicas is the sum of the desired replicas of every node pool
+		Replicas int
+		// Nodes is the number of nodes currently reported by the node pools
+		Nodes       int
+		Capacity    ResourceQuantities
+		Allocatable ResourceQuantities
+		Pools       []NodePoolCapacity
+	}
+
+	// NodePoolCapacity aggregates the node resources of a single node pool
+	NodePoolCapacity struct {
+		NodePoolID  string
+		Name        string
+		Flavor      string
+		Replicas    int
+		Nodes       int
+		Capacity    ResourceQuantities
+		Allocatable ResourceQuantities
+	}
+)
+
+// add sums other into q
+func (q *ResourceQuantities) add(other ResourceQuantities) {
+	q.CPUMillis += other.CPUMillis
+	q.MemoryBytes += other.MemoryBytes
+	q.EphemeralStorageBytes += other.EphemeralStorageBytes
+	q.Hugepages1GiBytes += other.Hugepages1GiBytes
+	q.Hugepages2MiBytes += other.Hugepages2MiBytes
+	q.Pods += other.Pods
+}
+
+// ClusterCapacitySummary lists every node pool of a cluster and its nodes, and sums the
+// nodes' capacity and allocatable resources per node pool and for the whole cluster
+func (s *nodePoolService) ClusterCapacitySummary(ctx context.Context, clusterID string) (*CapacitySummary, error) {
+	if clusterID == "" {
+		return nil, &client.ValidationError{Field: clusterIdField, Message: utils.CannotBeEmpty}
+	}
+
+	pools, err := s.listAll(ctx, clusterID, ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &CapacitySummary{Pools: make([]NodePoolCapacity, 0, len(pools))}
+	for _, pool := range pools {
+		nodes, err := s.Nodes(ctx, clusterID, pool.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		poolCapacity := NodePoolCapacity{
+			NodePoolID: pool.ID,
+			Name:       pool.Name,
+			Flavor:     pool.Flavor,
+			Replicas:   pool.Replicas,
+			Nodes:      len(nodes),
+		}
+		for _, node := range nodes {
+			capacity, err := node.Infrastructure.Capacity.Quantities()
+			if err != nil {
+				return nil, fmt.Errorf("node %s capacity: %w", node.ID, err)
+			}
+			allocatable, err := node.Infrastructure.Allocatable.Quantities()
+			if err != nil {
+				return nil, fmt.Errorf("node %s allocatable: %w", node.ID, err)
+			}
+			poolCapacity.Capacity.add(*capacity)
+			poolCapacity.Allocatable.add(*allocatable)
+		}
+
+		summary.Replicas += poolCapacity.Replicas
+		summary.Nodes += poolCapacity.Nodes
+		summary.Capacity.add(poolCapacity.Capacity)
+		summary.Allocatable.add(poolCapacity.Allocatable)
+		summary.Pools = append(summary.Pools, poolCapacity)
+	}
+
+	return summary, nil
+}
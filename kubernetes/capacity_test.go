@@ -0,0 +1,91 @@
+package kubernetes
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNodePoolService_ClusterCapacitySummary(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/kubernetes/v1alpha0/clusters/cluster-123/node-pools":
+			w.Write([]byte(`{"results": [
+				{"id": "small", "name": "small-pool", "flavor": "cloud-k8s.gp1.small", "replicas": 2},
+				{"id": "large", "name": "large-pool", "flavor": "cloud-k8s.gp1.large", "replicas": 1}
+			]}`))
+		case strings.HasSuffix(r.URL.Path, "/node_pools/small/nodes"):
+			w.Write([]byte(`{"results": [
+				{"id": "s1", "infrastructure": {
+					"capacity": {"cpu": "2", "memory": "4Gi", "pods": "110"},
+					"allocatable": {"cpu": "1930m", "memory": "3Gi", "pods": "110"}}},
+				{"id": "s2", "infrastructure": {
+					"capacity": {"cpu": "2", "memory": "4Gi", "pods": "110"},
+					"allocatable": {"cpu": "1930m", "memory": "3Gi", "pods": "110"}}}
+			]}`))
+		case strings.HasSuffix(r.URL.Path, "/node_pools/large/nodes"):
+			w.Write([]byte(`{"results": [
+				{"id": "l1", "infrastructure": {
+					"capacity": {"cpu": "8", "memory": "32Gi", "pods": "250"},
+					"allocatable": {"cpu": "7800m", "memory": "30Gi", "pods": "250"}}}
+			]}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	summary, err := testClient(server.URL).Nodepools().ClusterCapacitySummary(context.Background(), "cluster-123")
+	if err != nil {
+		t.Fatalf("ClusterCapacitySummary() unexpected error: %v", err)
+	}
+
+	if summary.Replicas != 3 || summary.Nodes != 3 {
+		t.Errorf("expected 3 replicas and 3 nodes, got %d and %d", summary.Replicas, summary.Nodes)
+	}
+	wantCapacity := ResourceQuantities{CPUMillis: 12000, MemoryBytes: 40 << 30, Pods: 470}
+	if summary.Capacity != wantCapacity {
+		t.Errorf("Capacity got = %+v, want %+v", summary.Capacity, wantCapacity)
+	}
+	wantAllocatable := ResourceQuantities{CPUMillis: 11660, MemoryBytes: 36 << 30, Pods: 470}
+	if summary.Allocatable != wantAllocatable {
+		t.Errorf("Allocatable got = %+v, want %+v", summary.Allocatable, wantAllocatable)
+	}
+
+	if len(summary.Pools) != 2 {
+		t.Fatalf("expected 2 pools, got %d", len(summary.Pools))
+	}
+	small := summary.Pools[0]
+	if small.NodePoolID != "small" || small.Flavor != "cloud-k8s.gp1.small" || small.Nodes != 2 ||
+		small.Capacity.CPUMillis != 4000 || small.Allocatable.MemoryBytes != 6<<30 {
+		t.Errorf("unexpected small pool summary %+v", small)
+	}
+	large := summary.Pools[1]
+	if large.NodePoolID != "large" || large.Nodes != 1 || large.Capacity.MemoryBytes != 32<<30 {
+		t.Errorf("unexpected large pool summary %+v", large)
+	}
+}
+
+func TestNodePoolService_ClusterCapacitySummary_Errors(t *testing.T) {
+	if _, err := testClient("http://localhost").Nodepools().ClusterCapacitySummary(context.Background(), ""); err == nil {
+		t.Error("expected validation error for empty cluster ID")
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/nodes") {
+			w.Write([]byte(`{"results": [{"id": "n1", "infrastructure": {"capacity": {"cpu": "lots"}}}]}`))
+			return
+		}
+		w.Write([]byte(`{"results": [{"id": "pool"}]}`))
+	}))
+	defer server.Close()
+
+	if _, err := testClient(server.URL).Nodepools().ClusterCapacitySummary(context.Background(), "cluster-123"); err == nil {
+		t.Error("expected error for unparsable node capacity")
+	}
+}
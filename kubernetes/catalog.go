@@ -0,0 +1,64 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+	"github.com/MagaluCloud/mgc-sdk-go/internal/cache"
+)
+
+// DefaultCatalogTTL is how long FlavorCatalog keeps a region's flavors when no TTL is given
+const DefaultCatalogTTL = 10 * time.Minute
+
+// FlavorCatalog caches the node pool flavors of each region, so validating many node pools
+// does not list flavors every time. It is safe for concurrent use.
+type FlavorCatalog struct {
+	services map[string]FlavorService
+	cache    *cache.TTL[string, []Flavor]
+}
+
+// NewFlavorCatalog returns a catalog reading flavors from services, keyed by region name
+// (e.g. "br-se1" mapped to kubernetes.New(regionCore).Flavors()). Entries expire after ttl,
+// or DefaultCatalogTTL when ttl is not positive.
+func NewFlavorCatalog(ttl time.Duration, services map[string]FlavorService) *FlavorCatalog {
+	if ttl <= 0 {
+		ttl = DefaultCatalogTTL
+	}
+	return &FlavorCatalog{
+		services: services,
+		cache:    cache.New[string, []Flavor](ttl),
+	}
+}
+
+// Flavors returns the node pool flavors available in region, from the cache when fresh
+func (c *FlavorCatalog) Flavors(ctx context.Context, region string) ([]Flavor, error) {
+	service, ok := c.services[region]
+	if !ok {
+		return nil, &client.ValidationError{Field: "region", Message: fmt.Sprintf("no flavor service for region %q", region)}
+	}
+
+	flavors, err := c.cache.Get(ctx, region, func(ctx context.Context) ([]Flavor, error) {
+		available, err := service.List(ctx, ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("list flavors in %s: %w", region, err)
+		}
+		return available.NodePool, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return slices.Clone(flavors), nil
+}
+
+// Invalidate drops the cached flavors of region
+func (c *FlavorCatalog) Invalidate(region string) {
+	c.cache.Invalidate(region)
+}
+
+// InvalidateAll drops the cached flavors of every region
+func (c *FlavorCatalog) InvalidateAll() {
+	c.cache.InvalidateAll()
+}
@@ -0,0 +1,68 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+	"github.com/MagaluCloud/mgc-sdk-go/internal/utils"
+)
+
+func TestFlavorCatalog_Flavors(t *testing.T) {
+	calls := map[string]int{}
+	newRegion := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls[name]++
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"results": [{"nodepool": [{"id": "f1", "name": "` + name + `.small"}], "controlplane": []}]}`))
+		}))
+	}
+	se1, ne1 := newRegion("br-se1"), newRegion("br-ne1")
+	defer se1.Close()
+	defer ne1.Close()
+
+	catalog := NewFlavorCatalog(time.Minute, map[string]FlavorService{
+		"br-se1": testClient(se1.URL).Flavors(),
+		"br-ne1": testClient(ne1.URL).Flavors(),
+	})
+	clock := utils.NewFakeClock(time.Now())
+	ctx := utils.WithClock(context.Background(), clock)
+
+	for range 3 {
+		flavors, err := catalog.Flavors(ctx, "br-se1")
+		if err != nil {
+			t.Fatalf("Flavors() unexpected error: %v", err)
+		}
+		if len(flavors) != 1 || flavors[0].Name != "br-se1.small" {
+			t.Errorf("unexpected flavors %+v", flavors)
+		}
+	}
+	if _, err := catalog.Flavors(ctx, "br-ne1"); err != nil {
+		t.Fatalf("Flavors() unexpected error: %v", err)
+	}
+	if calls["br-se1"] != 1 || calls["br-ne1"] != 1 {
+		t.Errorf("expected one call per region, got %v", calls)
+	}
+
+	clock.Advance(time.Minute)
+	catalog.Flavors(ctx, "br-se1")
+	if calls["br-se1"] != 2 {
+		t.Errorf("expected expired entry to be reloaded, got %d calls", calls["br-se1"])
+	}
+
+	catalog.Invalidate("br-ne1")
+	catalog.Flavors(ctx, "br-ne1")
+	catalog.Flavors(ctx, "br-se1")
+	if calls["br-ne1"] != 2 || calls["br-se1"] != 2 {
+		t.Errorf("expected only the invalidated region to be reloaded, got %v", calls)
+	}
+
+	var validationErr *client.ValidationError
+	if _, err := catalog.Flavors(ctx, "us-east1"); !errors.As(err, &validationErr) {
+		t.Errorf("Flavors() error = %v, want ValidationError for unknown region", err)
+	}
+}
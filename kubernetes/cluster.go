@@ -183,8 +183,13 @@ type (
 	}
 )
 
-// List returns a list of Kubernetes clusters with optional filtering and pagination
+// List returns a list of Kubernetes clusters with optional filtering and pagination.
+// The node pool only options of ListOptions are rejected with a ValidationError.
 func (s *clusterService) List(ctx context.Context, opts ListOptions) ([]ClusterList, error) {
+	if err := validateNoNodePoolOptions(opts); err != nil {
+		return nil, err
+	}
+
 	query := url.Values{}
 	if opts.Limit != nil {
 		query.Add("_limit", strconv.Itoa(*opts.Limit))
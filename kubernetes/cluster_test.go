@@ -61,6 +61,20 @@ func TestClusterService_List(t *testing.T) {
 			statusCode: http.StatusInternalServerError,
 			wantErr:    true,
 		},
+		{
+			name:       "node pool label selector is rejected",
+			response:   `{"results": [{"id": "cluster1", "name": "prod-cluster"}]}`,
+			opts:       ListOptions{LabelSelector: map[string]string{"team": "payments"}},
+			statusCode: http.StatusOK,
+			wantErr:    true,
+		},
+		{
+			name:       "node pool fields are rejected",
+			response:   `{"results": [{"id": "cluster1", "name": "prod-cluster"}]}`,
+			opts:       ListOptions{Fields: []string{"name"}},
+			statusCode: http.StatusOK,
+			wantErr:    true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,66 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/MagaluCloud/mgc-sdk-go/helpers"
+)
+
+func TestCollectionFields_EmptyVersusOmitted(t *testing.T) {
+	tests := []struct {
+		name string
+		req  any
+		keys []string
+	}{
+		{
+			name: "create node pool",
+			req: CreateNodePoolRequest{
+				Tags:              helpers.SlicePtr[string](nil),
+				Taints:            helpers.SlicePtr([]Taint{}),
+				AvailabilityZones: helpers.SlicePtr[string](nil),
+			},
+			keys: []string{"tags", "taints", "availability_zones"},
+		},
+		{
+			name: "patch node pool",
+			req:  PatchNodePoolRequest{AvailabilityZones: &[]string{}},
+			keys: []string{"availability_zones"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := marshalFields(t, tt.req)
+			for _, key := range tt.keys {
+				if got := string(body[key]); got != "[]" {
+					t.Errorf("expected empty %s to be sent as [], got %q", key, got)
+				}
+			}
+		})
+	}
+
+	omitted := marshalFields(t, CreateNodePoolRequest{})
+	for _, key := range []string{"tags", "taints", "availability_zones"} {
+		if raw, ok := omitted[key]; ok {
+			t.Errorf("expected nil %s to be omitted, got %s", key, raw)
+		}
+	}
+	if raw, ok := marshalFields(t, PatchNodePoolRequest{})["availability_zones"]; ok {
+		t.Errorf("expected nil availability_zones to be omitted from a patch, got %s", raw)
+	}
+}
+
+// marshalFields encodes v and returns its top-level JSON fields
+func marshalFields(t *testing.T, v any) map[string]json.RawMessage {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	return fields
+}
@@ -0,0 +1,56 @@
+package kubernetes
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+)
+
+// DiskType is the kind of disk backing a node pool's nodes
+type DiskType string
+
+const (
+	// DiskTypeCloudNVMe is NVMe-backed block storage
+	DiskTypeCloudNVMe DiskType = "cloud_nvme"
+	// DiskTypeCloudHDD is HDD-backed block storage
+	DiskTypeCloudHDD DiskType = "cloud_hdd"
+)
+
+// diskTypesByRegion lists the disk types offered in each region. The flavors API does not
+// report disk types, so this table is maintained by hand.
+var diskTypesByRegion = map[string][]DiskType{
+	"br-se1": {DiskTypeCloudNVMe, DiskTypeCloudHDD},
+	"br-ne1": {DiskTypeCloudNVMe, DiskTypeCloudHDD},
+}
+
+// ValidDiskTypes returns the disk types offered in region, or nil for an unknown region
+func ValidDiskTypes(region string) []DiskType {
+	return slices.Clone(diskTypesByRegion[region])
+}
+
+// ValidateDiskType checks that diskType is offered in region. The returned
+// *client.ValidationError names the valid disk types.
+func ValidateDiskType(region string, diskType DiskType) error {
+	valid := ValidDiskTypes(region)
+	if valid == nil {
+		return &client.ValidationError{Field: "region", Message: fmt.Sprintf("unknown region %q", region)}
+	}
+	if slices.Contains(valid, diskType) {
+		return nil
+	}
+	return &client.ValidationError{
+		Field:   diskTypeField,
+		Message: fmt.Sprintf("unsupported disk type %q in %s, valid disk types are %s", diskType, region, joinDiskTypes(valid)),
+	}
+}
+
+// joinDiskTypes formats disk types for error messages
+func joinDiskTypes(types []DiskType) string {
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = string(t)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,97 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+)
+
+func TestValidateDiskType(t *testing.T) {
+	tests := []struct {
+		name         string
+		region       string
+		diskType     DiskType
+		wantErrField string
+		wantErrText  string
+	}{
+		{name: "nvme in br-se1", region: "br-se1", diskType: DiskTypeCloudNVMe},
+		{name: "hdd in br-ne1", region: "br-ne1", diskType: DiskTypeCloudHDD},
+		{
+			name:         "typo names the valid disk types",
+			region:       "br-se1",
+			diskType:     "cloud_nvm",
+			wantErrField: "diskType",
+			wantErrText:  "valid disk types are cloud_nvme, cloud_hdd",
+		},
+		{name: "unknown region", region: "us-east-1", diskType: DiskTypeCloudNVMe, wantErrField: "region"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateDiskType(tt.region, tt.diskType)
+			if tt.wantErrField == "" {
+				if err != nil {
+					t.Errorf("ValidateDiskType() unexpected error: %v", err)
+				}
+				return
+			}
+
+			var validationErr *client.ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Field != tt.wantErrField {
+				t.Fatalf("expected a ValidationError on %s, got %v", tt.wantErrField, err)
+			}
+			if !strings.Contains(err.Error(), tt.wantErrText) {
+				t.Errorf("expected %q in %q", tt.wantErrText, err.Error())
+			}
+		})
+	}
+
+	if got := ValidDiskTypes("us-east-1"); got != nil {
+		t.Errorf("expected no disk types for an unknown region, got %v", got)
+	}
+}
+
+func TestNodePoolService_Create_DiskType(t *testing.T) {
+	tests := []struct {
+		name     string
+		diskType DiskType
+	}{
+		{name: "known disk type", diskType: DiskTypeCloudHDD},
+		{name: "unknown disk type is left to the server", diskType: "ssd"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent map[string]any
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				json.NewDecoder(r.Body).Decode(&sent)
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"id": "pool-new", "instance_template": {"disk_type": "` + string(tt.diskType) + `"}}`))
+			}))
+			defer server.Close()
+
+			pool, err := testClient(server.URL).Nodepools().Create(context.Background(), "cluster-123", CreateNodePoolRequest{
+				Name:     "pool",
+				Flavor:   "gp1.small",
+				Replicas: 1,
+				DiskType: &tt.diskType,
+			})
+			if err != nil {
+				t.Fatalf("Create() unexpected error: %v", err)
+			}
+			if sent["disk_type"] != string(tt.diskType) {
+				t.Errorf("expected disk_type %s sent, got %v", tt.diskType, sent["disk_type"])
+			}
+			if pool.InstanceTemplate.DiskType != string(tt.diskType) {
+				t.Errorf("expected disk type %s, got %s", tt.diskType, pool.InstanceTemplate.DiskType)
+			}
+		})
+	}
+}
@@ -43,8 +43,13 @@ type (
 	}
 )
 
-// List returns available flavors for node pools and control planes
+// List returns available flavors for node pools and control planes.
+// The node pool only options of ListOptions are rejected with a ValidationError.
 func (s *flavorService) List(ctx context.Context, opts ListOptions) (*FlavorsAvailable, error) {
+	if err := validateNoNodePoolOptions(opts); err != nil {
+		return nil, err
+	}
+
 	query := url.Values{}
 	if opts.Limit != nil {
 		query.Add("_limit", strconv.Itoa(*opts.Limit))
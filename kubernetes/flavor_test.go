@@ -2,11 +2,13 @@ package kubernetes
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/MagaluCloud/mgc-sdk-go/client"
 	"github.com/MagaluCloud/mgc-sdk-go/helpers"
 )
 
@@ -119,6 +121,34 @@ func TestFlavorService_List_InvalidOptions(t *testing.T) {
 	}
 }
 
+func TestFlavorService_List_NodePoolOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts ListOptions
+	}{
+		{name: "label selector", opts: ListOptions{LabelSelector: map[string]string{"team": "payments"}}},
+		{name: "tags", opts: ListOptions{Tags: []string{"env=prod"}}},
+		{name: "fields", opts: ListOptions{Fields: []string{"name"}}},
+		{name: "status", opts: ListOptions{Status: []string{NodePoolStateRunning}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			}))
+			defer server.Close()
+
+			_, err := testClient(server.URL).Flavors().List(context.Background(), tt.opts)
+
+			var validationErr *client.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Errorf("expected a ValidationError, got %v", err)
+			}
+		})
+	}
+}
+
 func TestFlavorService_List_EmptyResults(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -0,0 +1,92 @@
+package kubernetes
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+)
+
+const (
+	labelsField = "labels"
+
+	// maxLabelNameLength is the limit for label values and the name part of keys
+	maxLabelNameLength = 63
+	// maxLabelPrefixLength is the limit for the DNS subdomain prefix of keys
+	maxLabelPrefixLength = 253
+)
+
+var (
+	labelNamePattern   = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$`)
+	labelPrefixPattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+)
+
+// validateLabels checks label keys and values against the Kubernetes rules and returns a
+// ValidationError on the labels field naming the first offending key (in sorted order)
+func validateLabels(labels map[string]string) error {
+	for _, key := range sortedKeys(labels) {
+		if err := validateQualifiedName(key); err != nil {
+			return &client.ValidationError{Field: labelsField, Message: fmt.Sprintf("invalid key %q: %s", key, err)}
+		}
+		if err := validateLabelValue(labels[key]); err != nil {
+			return &client.ValidationError{Field: labelsField, Message: fmt.Sprintf("invalid value for key %q: %s", key, err)}
+		}
+	}
+	return nil
+}
+
+// validateQualifiedName checks a label or annotation key: an optional DNS subdomain
+// prefix followed by "/" and a name of at most 63 alphanumeric, '-', '_' or '.' characters
+// that starts and ends with an alphanumeric character
+func validateQualifiedName(key string) error {
+	name := key
+	if prefix, rest, found := strings.Cut(key, "/"); found {
+		if prefix == "" {
+			return fmt.Errorf("prefix cannot be empty")
+		}
+		if len(prefix) > maxLabelPrefixLength {
+			return fmt.Errorf("prefix must be at most %d characters", maxLabelPrefixLength)
+		}
+		if !labelPrefixPattern.MatchString(prefix) {
+			return fmt.Errorf("prefix must be a lowercase DNS subdomain")
+		}
+		name = rest
+	}
+
+	if name == "" {
+		return fmt.Errorf("name cannot be empty")
+	}
+	if len(name) > maxLabelNameLength {
+		return fmt.Errorf("name must be at most %d characters", maxLabelNameLength)
+	}
+	if !labelNamePattern.MatchString(name) {
+		return fmt.Errorf("name must consist of alphanumeric characters, '-', '_' or '.', and start and end with an alphanumeric character")
+	}
+	return nil
+}
+
+// validateLabelValue checks a label value: empty, or at most 63 characters with the same
+// character rules as a key name
+func validateLabelValue(value string) error {
+	if value == "" {
+		return nil
+	}
+	if len(value) > maxLabelNameLength {
+		return fmt.Errorf("must be at most %d characters", maxLabelNameLength)
+	}
+	if !labelNamePattern.MatchString(value) {
+		return fmt.Errorf("must consist of alphanumeric characters, '-', '_' or '.', and start and end with an alphanumeric character")
+	}
+	return nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
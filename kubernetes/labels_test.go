@@ -0,0 +1,67 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+)
+
+func TestValidateLabels(t *testing.T) {
+	tests := []struct {
+		name    string
+		labels  map[string]string
+		wantErr string
+	}{
+		{name: "nil labels", labels: nil},
+		{name: "simple key", labels: map[string]string{"env": "prod"}},
+		{name: "prefixed key", labels: map[string]string{"app.kubernetes.io/name": "web"}},
+		{name: "empty value", labels: map[string]string{"tier": ""}},
+		{name: "dots dashes underscores", labels: map[string]string{"my_label.v-1": "a.b_c-d"}},
+		{name: "empty key", labels: map[string]string{"": "x"}, wantErr: `invalid key ""`},
+		{name: "empty prefix", labels: map[string]string{"/name": "x"}, wantErr: `invalid key "/name"`},
+		{name: "empty name", labels: map[string]string{"example.com/": "x"}, wantErr: `invalid key "example.com/"`},
+		{name: "uppercase prefix", labels: map[string]string{"Example.com/name": "x"}, wantErr: `invalid key "Example.com/name"`},
+		{name: "name starts with dash", labels: map[string]string{"-env": "x"}, wantErr: `invalid key "-env"`},
+		{name: "name with space", labels: map[string]string{"my env": "x"}, wantErr: `invalid key "my env"`},
+		{name: "name too long", labels: map[string]string{strings.Repeat("a", 64): "x"}, wantErr: "at most 63"},
+		{name: "value too long", labels: map[string]string{"env": strings.Repeat("a", 64)}, wantErr: `invalid value for key "env"`},
+		{name: "value with slash", labels: map[string]string{"env": "a/b"}, wantErr: `invalid value for key "env"`},
+		{name: "value ends with dot", labels: map[string]string{"env": "prod."}, wantErr: `invalid value for key "env"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateLabels(tt.labels)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validateLabels() unexpected error: %v", err)
+				}
+				return
+			}
+
+			var validationErr *client.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("validateLabels() expected *client.ValidationError, got %v", err)
+			}
+			if validationErr.Field != labelsField {
+				t.Errorf("expected field %q, got %q", labelsField, validationErr.Field)
+			}
+			if !strings.Contains(validationErr.Message, tt.wantErr) {
+				t.Errorf("expected message containing %q, got %q", tt.wantErr, validationErr.Message)
+			}
+		})
+	}
+}
+
+func TestNodePoolService_Update_InvalidLabels(t *testing.T) {
+	_, err := testClient("http://localhost").Nodepools().Update(context.Background(), "cluster-123", "pool-1",
+		PatchNodePoolRequest{Labels: map[string]string{"bad key": "x"}})
+
+	var validationErr *client.ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != labelsField {
+		t.Errorf("expected labels validation error, got %v", err)
+	}
+}
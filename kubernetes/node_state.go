@@ -0,0 +1,22 @@
+package kubernetes
+
+import "strings"
+
+// IsReady reports whether the node can run pods. Besides NodeStateReady, nodes reported as
+// Running (NodePoolStateRunning) are ready, since the API uses both. The comparison ignores case.
+func (n Node) IsReady() bool {
+	return strings.EqualFold(n.Status.State, NodeStateReady) || strings.EqualFold(n.Status.State, NodePoolStateRunning)
+}
+
+// IsNotReady reports whether the node is known to be unable to run pods, that is its state is
+// NodeStateNotReady or NodeStateUnknown. Nodes still being provisioned are neither ready nor
+// not ready.
+func (n Node) IsNotReady() bool {
+	return strings.EqualFold(n.Status.State, NodeStateNotReady) || strings.EqualFold(n.Status.State, NodeStateUnknown)
+}
+
+// StatusReason returns the message the API gives for the node's state, such as why it is not
+// ready, or "" when there is none.
+func (n Node) StatusReason() string {
+	return strings.TrimSpace(n.Status.Message)
+}
@@ -0,0 +1,40 @@
+package kubernetes
+
+import "testing"
+
+func TestNode_StatePredicates(t *testing.T) {
+	tests := []struct {
+		state        string
+		message      string
+		wantReady    bool
+		wantNotReady bool
+		wantReason   string
+	}{
+		{state: NodeStateReady, wantReady: true},
+		{state: "ready", wantReady: true},
+		{state: NodePoolStateRunning, wantReady: true},
+		{state: NodeStateNotReady, message: " kubelet stopped posting node status ", wantNotReady: true, wantReason: "kubelet stopped posting node status"},
+		{state: NodeStateUnknown, wantNotReady: true},
+		{state: "Provisioning", message: "waiting for instance"},
+		{state: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.state, func(t *testing.T) {
+			node := Node{Status: MessageState{State: tt.state, Message: tt.message}}
+			if got := node.IsReady(); got != tt.wantReady {
+				t.Errorf("IsReady() = %v, want %v", got, tt.wantReady)
+			}
+			if got := node.IsNotReady(); got != tt.wantNotReady {
+				t.Errorf("IsNotReady() = %v, want %v", got, tt.wantNotReady)
+			}
+			wantReason := tt.wantReason
+			if wantReason == "" {
+				wantReason = tt.message
+			}
+			if got := node.StatusReason(); got != wantReason {
+				t.Errorf("StatusReason() = %q, want %q", got, wantReason)
+			}
+		})
+	}
+}
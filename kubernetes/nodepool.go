@@ -2,40 +2,99 @@ package kubernetes
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/MagaluCloud/mgc-sdk-go/client"
+	"github.com/MagaluCloud/mgc-sdk-go/helpers"
 	mgc_http "github.com/MagaluCloud/mgc-sdk-go/internal/http"
 	"github.com/MagaluCloud/mgc-sdk-go/internal/utils"
 )
 
 const (
-	nodePoolIdField    = "nodePoolID"
-	clusterIdField     = "clusterID"
-	clusterNodepoolURL = "/v0/clusters/%s/node_pools/%s"
+	nodePoolIdField        = "nodePoolID"
+	clusterIdField         = "clusterID"
+	availabilityZonesField = "availabilityZones"
+	replicasField          = "replicas"
+	diskTypeField          = "diskType"
+	clusterNodepoolURL     = "/v0/clusters/{cluster_id}/node_pools/{node_pool_id}"
+
+	// nodePoolFilterPageSize is the page size used to fetch every node pool
+	nodePoolFilterPageSize = 50
+	// defaultAllNodesConcurrency is the number of node pools AllNodes reads at once by default
+	defaultAllNodesConcurrency = 4
+	// defaultScaleManyConcurrency is the number of node pools ScaleMany updates at once by default
+	defaultScaleManyConcurrency = 4
+	// replacementCleanupTimeout bounds the delete UpdateInstanceTemplate sends for a failed replacement
+	replacementCleanupTimeout = 30 * time.Second
+
+	// NodePoolStateRunning is the state of a node pool whose nodes are ready
+	NodePoolStateRunning = "Running"
+	// NodeStateReady is the state of a node that can run pods
+	NodeStateReady = "Ready"
+	// NodeStateNotReady is the state of a node whose kubelet reports it cannot run pods
+	NodeStateNotReady = "NotReady"
+	// NodeStateUnknown is the state of a node the control plane has lost contact with
+	NodeStateUnknown = "Unknown"
+	// DefaultNodePoolWaitInterval is the default interval between polls in WaitUntilRunning and WaitForReplicas
+	DefaultNodePoolWaitInterval = 10 * time.Second
 )
 
 type (
-	// ListOptions provides options for listing resources
+	// ListOptions provides options for listing resources.
+	// LabelSelector, Tags, Fields and Status only apply to node pools: node pool List uses
+	// them all, node pool ListPage rejects the filters, and cluster and flavor List reject
+	// every one of them rather than ignoring it.
 	ListOptions struct {
 		Limit  *int
 		Offset *int
 		Sort   *string
 		Expand []string
+		// LabelSelector keeps node pools whose labels contain every key/value pair
+		LabelSelector map[string]string
+		// Tags keeps node pools that have every listed tag (e.g. "env=prod")
+		Tags []string
+		// Fields requests a partial response with only these JSON fields of each node pool,
+		// leaving the others zero. "id" is always included, plus "labels", "tags" and "status"
+		// when LabelSelector, Tags or Status need them for filtering.
+		Fields []string
+		// Status keeps node pools whose state is one of these (e.g. NodePoolStateRunning),
+		// ignoring case. The API has no state filter, so every page is fetched and filtered
+		// in the SDK, like LabelSelector and Tags.
+		Status []string
 	}
 
 	// NodePoolService provides methods for managing Kubernetes node pools
 	NodePoolService interface {
 		Nodes(ctx context.Context, clusterID, nodePoolID string) ([]Node, error)
+		AllNodes(ctx context.Context, clusterID string, concurrency int) ([]Node, error)
 		List(ctx context.Context, clusterID string, opts ListOptions) ([]NodePool, error)
+		ListPage(ctx context.Context, clusterID string, opts ListOptions) (*client.Page[NodePool], error)
+		ListNames(ctx context.Context, clusterID string) ([]NodePoolRef, error)
 		Create(ctx context.Context, clusterID string, req CreateNodePoolRequest) (*NodePool, error)
 		Get(ctx context.Context, clusterID, nodePoolID string) (*NodePool, error)
 		Update(ctx context.Context, clusterID, nodePoolID string, req PatchNodePoolRequest) (*NodePool, error)
 		Delete(ctx context.Context, clusterID, nodePoolID string) error
+		ClusterCapacitySummary(ctx context.Context, clusterID string) (*CapacitySummary, error)
+		WaitUntilRunning(ctx context.Context, clusterID, nodePoolID string, opts NodePoolWaitOptions) (*NodePool, error)
+		WaitForReplicas(ctx context.Context, clusterID, nodePoolID string, target int, opts NodePoolWaitOptions) (*NodePool, error)
+		UpdateInstanceTemplate(ctx context.Context, clusterID, nodePoolID string, req InstanceTemplateUpdate) (*NodePool, error)
+		ScaleMany(ctx context.Context, clusterID string, targets map[string]int, concurrency int) (map[string]*NodePool, []error)
+	}
+
+	// NodePoolRef identifies a node pool by ID and name, as returned by ListNames
+	NodePoolRef struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
 	}
 
 	// NodePoolList represents the response when listing node pools
@@ -69,6 +128,10 @@ type (
 		Flavor            string            `json:"flavor"`
 		MaxPodsPerNode    *int              `json:"max_pods_per_node,omitempty"`
 		AvailabilityZones *[]string         `json:"availability_zones,omitempty"`
+		// Preemptible reports whether the node pool runs on reclaimable spot capacity
+		Preemptible bool `json:"preemptible,omitempty"`
+		// RawExtra holds response fields the SDK does not model yet, keyed by their JSON name.
+		RawExtra map[string]json.RawMessage `json:"-"`
 	}
 
 	// Addresses represents network addresses
@@ -129,7 +192,10 @@ type (
 		Zone           *string           `json:"zone,omitempty"`
 	}
 
-	// CreateNodePoolRequest represents the request payload for creating a node pool
+	// CreateNodePoolRequest represents the request payload for creating a node pool.
+	// With AutoScale set, Replicas is the initial node count and must lie within
+	// [MinReplicas, MaxReplicas] when both bounds are given; the autoscaler then moves
+	// the count inside that range.
 	CreateNodePoolRequest struct {
 		Name              string     `json:"name"`
 		Flavor            string     `json:"flavor"`
@@ -139,12 +205,57 @@ type (
 		AutoScale         *AutoScale `json:"auto_scale,omitempty"`
 		MaxPodsPerNode    *int       `json:"max_pods_per_node,omitempty"`
 		AvailabilityZones *[]string  `json:"availability_zones,omitempty"`
+		// Preemptible requests spot capacity: cheaper nodes the platform may reclaim at any time,
+		// evicting their pods without the usual drain period. Use it for batch or stateless
+		// workloads that tolerate losing nodes. Whether a flavor offers preemptible capacity is
+		// not exposed by the flavors API, so unsupported combinations are rejected by the server.
+		Preemptible *bool `json:"preemptible,omitempty"`
+		// DiskType selects the disk backing the nodes; unset uses the platform default.
+		// Create sends it as given; use ValidateDiskType to check one against a region first.
+		DiskType *DiskType `json:"disk_type,omitempty"`
+		// UserData is a base64-encoded cloud-init script or other user data run by every node
+		// at first boot, e.g. to install agents. It is limited to MaxNodePoolUserDataSize bytes
+		// before encoding; EncodeUserData encodes a raw script.
+		UserData *string `json:"user_data,omitempty"`
 	}
 
-	// PatchNodePoolRequest represents the request payload for updating a node pool
+	// PatchNodePoolRequest represents the request payload for updating a node pool.
+	// AvailabilityZones changes the zones the node pool spreads its nodes across;
+	// when set it must list at least one zone and every entry must be non-empty.
+	// Labels replaces the Kubernetes labels of the node pool's nodes; keys and values
+	// must follow the Kubernetes label syntax.
 	PatchNodePoolRequest struct {
-		Replicas  *int       `json:"replicas,omitempty"`
-		AutoScale *AutoScale `json:"auto_scale,omitempty"`
+		Replicas          *int              `json:"replicas,omitempty"`
+		AutoScale         *AutoScale        `json:"auto_scale,omitempty"`
+		AvailabilityZones *[]string         `json:"availability_zones,omitempty"`
+		Labels            map[string]string `json:"labels,omitempty"`
+	}
+
+	// NodePoolWaitOptions configures how WaitUntilRunning polls a node pool
+	NodePoolWaitOptions struct {
+		// Interval is the time between polls. Defaults to DefaultNodePoolWaitInterval.
+		Interval time.Duration
+		// Label identifies the wait in progress callbacks, logs and errors, so concurrent
+		// waits can be told apart. Defaults to the node pool ID in errors.
+		Label string
+		// OnProgress, when set, is called with Label and the node pool returned by every poll
+		OnProgress func(label string, pool *NodePool)
+		// Backoff, when set, spaces the polls instead of the fixed Interval; a non-positive
+		// Initial starts from the Interval
+		Backoff *helpers.Backoff
+	}
+
+	// InstanceTemplateUpdate describes the replacement node pool created by UpdateInstanceTemplate.
+	// Disk size is chosen by the API from the flavor, since node pool creation does not accept it.
+	InstanceTemplateUpdate struct {
+		// Name of the replacement node pool; it must differ from the current name
+		Name string
+		// Flavor of the replacement node pool's nodes
+		Flavor string
+		// DiskType of the replacement node pool's nodes; unset keeps the current pool's disk type
+		DiskType *DiskType
+		// Wait configures how the replacement node pool is polled until it is running
+		Wait NodePoolWaitOptions
 	}
 
 	// Taint represents a node taint
@@ -180,9 +291,9 @@ func (s *nodePoolService) Nodes(ctx context.Context, clusterID, nodePoolID strin
 		Results []Node `json:"results"`
 	}
 
+	ctx, path := mgc_http.ExpandPathTemplate(ctx, DefaultBasePath, clusterNodepoolURL+"/nodes", clusterID, nodePoolID)
 	resp, err := mgc_http.ExecuteSimpleRequestWithRespBody[NodeList](ctx, s.client.newRequest,
-		s.client.GetConfig(), http.MethodGet,
-		fmt.Sprintf(clusterNodepoolURL+"/nodes", clusterID, nodePoolID), nil, nil)
+		s.client.GetConfig(), http.MethodGet, path, nil, nil)
 
 	if err != nil {
 		return nil, err
@@ -191,15 +302,204 @@ func (s *nodePoolService) Nodes(ctx context.Context, clusterID, nodePoolID strin
 	return resp.Results, nil
 }
 
-// List returns a list of node pools in a cluster with optional filtering and pagination
+// List returns a list of node pools in a cluster with optional filtering and pagination.
+// The API has no filter parameters, so when LabelSelector, Tags or Status are set the filter
+// is applied client-side: every page is fetched (starting at Offset, Limit items per page)
+// and only matching node pools are returned. An empty slice is returned when nothing matches.
 func (s *nodePoolService) List(ctx context.Context, clusterID string, opts ListOptions) ([]NodePool, error) {
 	if clusterID == "" {
 		return nil, &client.ValidationError{Field: clusterIdField, Message: utils.CannotBeEmpty}
 	}
 
+	if len(opts.LabelSelector) == 0 && len(opts.Tags) == 0 && len(opts.Status) == 0 {
+		return s.listPage(ctx, clusterID, opts)
+	}
+
+	pools, err := s.listAll(ctx, clusterID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := []NodePool{}
+	for _, pool := range pools {
+		if pool.matches(opts.LabelSelector, opts.Tags, opts.Status) {
+			filtered = append(filtered, pool)
+		}
+	}
+	return filtered, nil
+}
+
+// AllNodes returns the nodes of every node pool in a cluster, listing the pools and then
+// fetching the nodes of up to concurrency pools at once (defaultAllNodesConcurrency when not
+// positive). Nodes are grouped by pool in listing order, and NodepoolName is set to the pool's
+// name when the API leaves it empty. Pools whose nodes cannot be fetched are skipped; their
+// errors are joined into the returned error alongside the nodes that were fetched.
+func (s *nodePoolService) AllNodes(ctx context.Context, clusterID string, concurrency int) ([]Node, error) {
+	if clusterID == "" {
+		return nil, &client.ValidationError{Field: clusterIdField, Message: utils.CannotBeEmpty}
+	}
+	if concurrency <= 0 {
+		concurrency = defaultAllNodesConcurrency
+	}
+
+	pools, err := s.listAll(ctx, clusterID, ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	nodesByPool := make([][]Node, len(pools))
+	errs := make([]error, len(pools))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, pool := range pools {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			nodes, err := s.Nodes(ctx, clusterID, pool.ID)
+			if err != nil {
+				errs[i] = fmt.Errorf("list nodes of node pool %s: %w", pool.ID, err)
+				return
+			}
+			for j := range nodes {
+				if nodes[j].NodepoolName == "" {
+					nodes[j].NodepoolName = pool.Name
+				}
+			}
+			nodesByPool[i] = nodes
+		}()
+	}
+	wg.Wait()
+
+	all := []Node{}
+	for _, nodes := range nodesByPool {
+		all = append(all, nodes...)
+	}
+	return all, errors.Join(errs...)
+}
+
+// validateNoNodePoolOptions returns a ValidationError when opts sets one of the fields only
+// node pool listings use, for the List methods of the other services.
+func validateNoNodePoolOptions(opts ListOptions) error {
+	if len(opts.LabelSelector) > 0 || len(opts.Tags) > 0 || len(opts.Fields) > 0 || len(opts.Status) > 0 {
+		return &client.ValidationError{
+			Field:   "opts",
+			Message: "LabelSelector, Tags, Fields and Status are only supported by node pool listings",
+		}
+	}
+	return nil
+}
+
+// ListPage returns the page of node pools selected by opts.Offset and opts.Limit, whose Next
+// method fetches the following page. Limit defaults to the client's DefaultPageSize, or 50.
+// LabelSelector, Tags and Status are only applied client-side, which would not keep the API's
+// page boundaries, so setting any of them is a ValidationError; use List to filter.
+func (s *nodePoolService) ListPage(ctx context.Context, clusterID string, opts ListOptions) (*client.Page[NodePool], error) {
+	if clusterID == "" {
+		return nil, &client.ValidationError{Field: clusterIdField, Message: utils.CannotBeEmpty}
+	}
+	if len(opts.LabelSelector) > 0 || len(opts.Tags) > 0 || len(opts.Status) > 0 {
+		return nil, &client.ValidationError{
+			Field:   "opts",
+			Message: "LabelSelector, Tags and Status are not supported by ListPage, use List to filter",
+		}
+	}
+
+	var fetch client.PageFetcher[NodePool]
+	fetch = func(ctx context.Context, offset, limit int) (*client.Page[NodePool], error) {
+		pageOpts := opts
+		pageOpts.Offset = &offset
+		pageOpts.Limit = &limit
+		items, err := s.listPage(ctx, clusterID, pageOpts)
+		if err != nil {
+			return nil, err
+		}
+		meta := client.PaginationMeta{Limit: limit, Offset: offset, Count: len(items)}
+		return client.NewPage(items, meta, fetch), nil
+	}
+
+	offset, limit := 0, nodePoolFilterPageSize
+	if opts.Offset != nil {
+		offset = *opts.Offset
+	}
+	if l := s.client.GetConfig().PageLimit(opts.Limit); l != nil && *l > 0 {
+		limit = *l
+	}
+	return fetch(ctx, offset, limit)
+}
+
+// ListNames returns the ID and name of every node pool in a cluster, for pickers and other
+// callers that do not need the full NodePool. It walks every page asking the API for only the
+// id and name fields (see ListOptions.Fields), so the infrastructure details are not transferred.
+func (s *nodePoolService) ListNames(ctx context.Context, clusterID string) ([]NodePoolRef, error) {
+	if clusterID == "" {
+		return nil, &client.ValidationError{Field: clusterIdField, Message: utils.CannotBeEmpty}
+	}
+
+	pools, err := s.listAll(ctx, clusterID, ListOptions{Fields: []string{"name"}})
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make([]NodePoolRef, 0, len(pools))
+	for _, pool := range pools {
+		refs = append(refs, NodePoolRef{ID: pool.ID, Name: pool.Name})
+	}
+	return refs, nil
+}
+
+// nodePoolRequiredFields returns the fields a partial node pool listing must include
+// for opts to be applied
+func nodePoolRequiredFields(opts ListOptions) []string {
+	required := []string{"id"}
+	if len(opts.LabelSelector) > 0 {
+		required = append(required, "labels")
+	}
+	if len(opts.Tags) > 0 {
+		required = append(required, "tags")
+	}
+	if len(opts.Status) > 0 {
+		required = append(required, "status")
+	}
+	return required
+}
+
+// listAll fetches every page of node pools starting at opts.Offset, opts.Limit items per page
+func (s *nodePoolService) listAll(ctx context.Context, clusterID string, opts ListOptions) ([]NodePool, error) {
+	pageOpts := opts
+	pageOpts.Limit = s.client.GetConfig().PageLimit(opts.Limit)
+	if pageOpts.Limit == nil || *pageOpts.Limit <= 0 {
+		limit := nodePoolFilterPageSize
+		pageOpts.Limit = &limit
+	}
+	offset := 0
+	if pageOpts.Offset != nil {
+		offset = *pageOpts.Offset
+	}
+
+	var pools []NodePool
+	for {
+		pageOpts.Offset = &offset
+		page, err := s.listPage(ctx, clusterID, pageOpts)
+		if err != nil {
+			return nil, err
+		}
+		pools = append(pools, page...)
+
+		if len(page) < *pageOpts.Limit {
+			return pools, nil
+		}
+		offset += len(page)
+	}
+}
+
+// listPage fetches a single page of node pools
+func (s *nodePoolService) listPage(ctx context.Context, clusterID string, opts ListOptions) ([]NodePool, error) {
 	query := url.Values{}
-	if opts.Limit != nil {
-		query.Add("_limit", strconv.Itoa(*opts.Limit))
+	if limit := s.client.GetConfig().PageLimit(opts.Limit); limit != nil {
+		query.Add("_limit", strconv.Itoa(*limit))
 	}
 	if opts.Offset != nil {
 		query.Add("_offset", strconv.Itoa(*opts.Offset))
@@ -207,25 +507,71 @@ func (s *nodePoolService) List(ctx context.Context, clusterID string, opts ListO
 	if opts.Sort != nil {
 		query.Add("_sort", *opts.Sort)
 	}
+	if fields := utils.FieldsQuery(opts.Fields, nodePoolRequiredFields(opts)...); fields != "" {
+		query.Add(utils.FieldsQueryParam, fields)
+	}
 
+	ctx, path := mgc_http.ExpandPathTemplate(ctx, DefaultBasePath, "/v1alpha0/clusters/{cluster_id}/node-pools", clusterID)
 	resp, err := mgc_http.ExecuteSimpleRequestWithRespBody[NodePoolList](ctx, s.client.newRequest,
-		s.client.GetConfig(), http.MethodGet, fmt.Sprintf("/v1alpha0/clusters/%s/node-pools", clusterID), nil, query)
+		s.client.GetConfig(), http.MethodGet, path, nil, query)
 	if err != nil {
 		return nil, err
 	}
+	if resp.Results == nil {
+		resp.Results = []NodePool{}
+	}
 
 	return resp.Results, nil
 }
 
+// matches reports whether the node pool has every label and tag given and, when states
+// are given, is in one of them
+func (p NodePool) matches(labelSelector map[string]string, tags, states []string) bool {
+	for key, value := range labelSelector {
+		if got, ok := p.Labels[key]; !ok || got != value {
+			return false
+		}
+	}
+
+	if len(states) > 0 && !slices.ContainsFunc(states, func(state string) bool {
+		return strings.EqualFold(state, p.Status.State)
+	}) {
+		return false
+	}
+
+	if len(tags) == 0 {
+		return true
+	}
+	if p.Tags == nil {
+		return false
+	}
+	for _, tag := range tags {
+		if !slices.Contains(*p.Tags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
 // Create creates a new node pool in a cluster
 func (s *nodePoolService) Create(ctx context.Context, clusterID string, req CreateNodePoolRequest) (*NodePool, error) {
 	if clusterID == "" {
 		return nil, &client.ValidationError{Field: clusterIdField, Message: utils.CannotBeEmpty}
 	}
 
+	if err := validateReplicasInAutoScale(req.Replicas, req.AutoScale); err != nil {
+		return nil, err
+	}
+
+	if req.UserData != nil {
+		if err := validateUserData(*req.UserData); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, path := mgc_http.ExpandPathTemplate(ctx, DefaultBasePath, "/v0/clusters/{cluster_id}/node_pools", clusterID)
 	return mgc_http.ExecuteSimpleRequestWithRespBody[NodePool](ctx, s.client.newRequest,
-		s.client.GetConfig(), http.MethodPost,
-		fmt.Sprintf("/v0/clusters/%s/node_pools", clusterID), req, nil)
+		s.client.GetConfig(), http.MethodPost, path, req, nil)
 }
 
 // Get retrieves detailed information about a specific node pool
@@ -238,9 +584,9 @@ func (s *nodePoolService) Get(ctx context.Context, clusterID, nodePoolID string)
 		return nil, &client.ValidationError{Field: nodePoolIdField, Message: utils.CannotBeEmpty}
 	}
 
+	ctx, path := mgc_http.ExpandPathTemplate(ctx, DefaultBasePath, clusterNodepoolURL, clusterID, nodePoolID)
 	return mgc_http.ExecuteSimpleRequestWithRespBody[NodePool](ctx, s.client.newRequest,
-		s.client.GetConfig(), http.MethodGet,
-		fmt.Sprintf(clusterNodepoolURL, clusterID, nodePoolID), nil, nil)
+		s.client.GetConfig(), http.MethodGet, path, nil, nil)
 }
 
 // Update updates a node pool's properties
@@ -253,9 +599,280 @@ func (s *nodePoolService) Update(ctx context.Context, clusterID, nodePoolID stri
 		return nil, &client.ValidationError{Field: nodePoolIdField, Message: utils.CannotBeEmpty}
 	}
 
+	if err := validateAvailabilityZones(req.AvailabilityZones); err != nil {
+		return nil, err
+	}
+
+	if err := validateLabels(req.Labels); err != nil {
+		return nil, err
+	}
+
+	ctx, path := mgc_http.ExpandPathTemplate(ctx, DefaultBasePath, clusterNodepoolURL, clusterID, nodePoolID)
 	return mgc_http.ExecuteSimpleRequestWithRespBody[NodePool](ctx, s.client.newRequest,
-		s.client.GetConfig(), http.MethodPatch,
-		fmt.Sprintf(clusterNodepoolURL, clusterID, nodePoolID), req, nil)
+		s.client.GetConfig(), http.MethodPatch, path, req, nil)
+}
+
+// ScaleMany sets the replica count of several node pools of a cluster at once. targets maps
+// node pool IDs to their desired replicas, and up to concurrency pools (defaultScaleManyConcurrency
+// when not positive) are updated at a time. The updated pools are returned by ID; pools that
+// could not be scaled are missing from the map and have an error in the returned slice, ordered
+// by node pool ID. Negative targets are rejected with a *client.ValidationError without being
+// sent, and do not stop the other pools from scaling. Under a deadline on ctx, each update gets
+// a share of the time left, and pools not started when the deadline is near are not sent and
+// fail with an error wrapping context.DeadlineExceeded.
+func (s *nodePoolService) ScaleMany(ctx context.Context, clusterID string, targets map[string]int, concurrency int) (map[string]*NodePool, []error) {
+	if clusterID == "" {
+		return nil, []error{&client.ValidationError{Field: clusterIdField, Message: utils.CannotBeEmpty}}
+	}
+	if concurrency <= 0 {
+		concurrency = defaultScaleManyConcurrency
+	}
+
+	ids := slices.Sorted(maps.Keys(targets))
+	pools := make([]*NodePool, len(ids))
+	errs := make([]error, len(ids))
+	sem := make(chan struct{}, concurrency)
+	sendable := 0
+	for _, replicas := range targets {
+		if replicas >= 0 {
+			sendable++
+		}
+	}
+	budget := utils.NewBatchBudget(sendable, concurrency)
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		replicas := targets[id]
+		if replicas < 0 {
+			errs[i] = fmt.Errorf("scale node pool %s: %w", id,
+				&client.ValidationError{Field: replicasField, Message: fmt.Sprintf("%d cannot be negative", replicas)})
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			itemCtx, cancel, err := budget.Start(ctx)
+			if err != nil {
+				errs[i] = fmt.Errorf("scale node pool %s: %w", id, err)
+				return
+			}
+			defer cancel()
+
+			pool, err := s.Update(itemCtx, clusterID, id, PatchNodePoolRequest{Replicas: &replicas})
+			if err != nil {
+				errs[i] = fmt.Errorf("scale node pool %s: %w", id, err)
+				return
+			}
+			pools[i] = pool
+		}()
+	}
+	wg.Wait()
+
+	scaled := make(map[string]*NodePool, len(ids))
+	var failed []error
+	for i, id := range ids {
+		if errs[i] != nil {
+			failed = append(failed, errs[i])
+			continue
+		}
+		scaled[id] = pools[i]
+	}
+	return scaled, failed
+}
+
+// WaitUntilRunning polls a node pool until its state is Running. It returns an error if the
+// node pool reaches a failed state or ctx is done first.
+func (s *nodePoolService) WaitUntilRunning(ctx context.Context, clusterID, nodePoolID string, opts NodePoolWaitOptions) (*NodePool, error) {
+	return s.waitFor(ctx, clusterID, nodePoolID, opts, "be running", func(pool *NodePool) (bool, error) {
+		return strings.EqualFold(pool.Status.State, NodePoolStateRunning), nil
+	})
+}
+
+// WaitForReplicas polls a node pool until it wants target replicas and exactly target of its
+// nodes report a ready state, which confirms a scale operation has converged rather than only
+// been accepted. It returns an error if the node pool reaches a failed state or ctx is done first.
+func (s *nodePoolService) WaitForReplicas(ctx context.Context, clusterID, nodePoolID string, target int, opts NodePoolWaitOptions) (*NodePool, error) {
+	if target < 0 {
+		return nil, &client.ValidationError{Field: "target", Message: "cannot be negative"}
+	}
+
+	goal := fmt.Sprintf("have %d ready nodes", target)
+	return s.waitFor(ctx, clusterID, nodePoolID, opts, goal, func(pool *NodePool) (bool, error) {
+		if pool.Replicas != target {
+			return false, nil
+		}
+
+		nodes, err := s.Nodes(ctx, clusterID, nodePoolID)
+		if err != nil {
+			return false, err
+		}
+		ready := 0
+		for _, node := range nodes {
+			if node.IsReady() {
+				ready++
+			}
+		}
+		return len(nodes) == target && ready == target, nil
+	})
+}
+
+// waitFor polls a node pool until done reports true. goal completes "waiting for node pool X to"
+// in errors. It fails when the node pool reaches an error or failed state, when done returns an
+// error, or when ctx is done.
+func (s *nodePoolService) waitFor(ctx context.Context, clusterID, nodePoolID string, opts NodePoolWaitOptions,
+	goal string, done func(pool *NodePool) (bool, error)) (*NodePool, error) {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = DefaultNodePoolWaitInterval
+	}
+
+	subject := nodePoolID
+	if opts.Label != "" {
+		subject = fmt.Sprintf("%s (%s)", nodePoolID, opts.Label)
+	}
+
+	backoff := helpers.Backoff{Initial: interval}
+	if opts.Backoff != nil {
+		backoff = *opts.Backoff
+		if backoff.Initial <= 0 {
+			backoff.Initial = interval
+		}
+	}
+
+	clock := utils.ClockFrom(ctx)
+	for attempt := 0; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("waiting for node pool %s to %s: %w", subject, goal, err)
+		}
+
+		pool, err := s.Get(ctx, clusterID, nodePoolID)
+		if err != nil {
+			return nil, fmt.Errorf("waiting for node pool %s to %s: %w", subject, goal, err)
+		}
+
+		s.client.GetConfig().Logger.Debug("polled node pool",
+			"label", opts.Label,
+			"nodePoolID", nodePoolID,
+			"state", pool.Status.State)
+		if opts.OnProgress != nil {
+			opts.OnProgress(opts.Label, pool)
+		}
+
+		state := strings.ToLower(pool.Status.State)
+		if strings.Contains(state, "error") || strings.Contains(state, "fail") {
+			return nil, fmt.Errorf("node pool %s reached state %s: %s", subject, pool.Status.State,
+				strings.Join(pool.Status.Messages, "; "))
+		}
+
+		ok, err := done(pool)
+		if err != nil {
+			return nil, fmt.Errorf("waiting for node pool %s to %s: %w", subject, goal, err)
+		}
+		if ok {
+			return pool, nil
+		}
+
+		if err := clock.Sleep(ctx, backoff.NextDelay(attempt)); err != nil {
+			return nil, fmt.Errorf("waiting for node pool %s to %s (last state %s): %w",
+				subject, goal, pool.Status.State, err)
+		}
+	}
+}
+
+// UpdateInstanceTemplate changes the flavor of a node pool's nodes. The API cannot change the
+// instance template in place, so this performs a rolling replacement: a new node pool named
+// req.Name is created with the new flavor and the old pool's replicas, tags, taints, autoscaling,
+// max pods, availability zones, preemptible capacity and disk type (unless req.DiskType is set);
+// once it is running the old pool's labels are copied to it with Update and the old node pool
+// is deleted.
+// If the new node pool fails to become running or its labels cannot be set, the new pool is
+// deleted, the old one is kept and the error is returned; should that delete fail as well, the
+// error names the new pool so it can be removed by hand.
+func (s *nodePoolService) UpdateInstanceTemplate(ctx context.Context, clusterID, nodePoolID string, req InstanceTemplateUpdate) (*NodePool, error) {
+	if clusterID == "" {
+		return nil, &client.ValidationError{Field: clusterIdField, Message: utils.CannotBeEmpty}
+	}
+	if nodePoolID == "" {
+		return nil, &client.ValidationError{Field: nodePoolIdField, Message: utils.CannotBeEmpty}
+	}
+	if req.Name == "" {
+		return nil, &client.ValidationError{Field: "name", Message: utils.CannotBeEmpty}
+	}
+	if req.Flavor == "" {
+		return nil, &client.ValidationError{Field: "flavor", Message: utils.CannotBeEmpty}
+	}
+
+	current, err := s.Get(ctx, clusterID, nodePoolID)
+	if err != nil {
+		return nil, err
+	}
+	if current.Name == req.Name {
+		return nil, &client.ValidationError{Field: "name", Message: "must differ from the current node pool name"}
+	}
+
+	createReq := CreateNodePoolRequest{
+		Name:              req.Name,
+		Flavor:            req.Flavor,
+		Replicas:          current.Replicas,
+		Tags:              current.Tags,
+		Taints:            current.Taints,
+		AutoScale:         current.AutoScale,
+		MaxPodsPerNode:    current.MaxPodsPerNode,
+		AvailabilityZones: current.AvailabilityZones,
+	}
+	if current.Preemptible {
+		createReq.Preemptible = &current.Preemptible
+	}
+	switch {
+	case req.DiskType != nil:
+		createReq.DiskType = req.DiskType
+	case current.InstanceTemplate.DiskType != "":
+		diskType := DiskType(current.InstanceTemplate.DiskType)
+		createReq.DiskType = &diskType
+	}
+
+	replacement, err := s.Create(ctx, clusterID, createReq)
+	if err != nil {
+		return nil, fmt.Errorf("creating replacement node pool: %w", err)
+	}
+
+	running, err := s.WaitUntilRunning(ctx, clusterID, replacement.ID, req.Wait)
+	if err != nil {
+		return nil, s.discardReplacement(ctx, clusterID, replacement.ID, nodePoolID,
+			fmt.Errorf("replacement node pool %s did not become running: %w", replacement.ID, err))
+	}
+
+	if len(current.Labels) > 0 {
+		running, err = s.Update(ctx, clusterID, replacement.ID, PatchNodePoolRequest{Labels: current.Labels})
+		if err != nil {
+			return nil, s.discardReplacement(ctx, clusterID, replacement.ID, nodePoolID,
+				fmt.Errorf("copying labels to replacement node pool %s: %w", replacement.ID, err))
+		}
+	}
+
+	if err := s.Delete(ctx, clusterID, nodePoolID); err != nil {
+		return nil, fmt.Errorf("replacement node pool %s is running but deleting node pool %s failed: %w",
+			replacement.ID, nodePoolID, err)
+	}
+
+	return running, nil
+}
+
+// discardReplacement deletes the replacement node pool of a failed UpdateInstanceTemplate and
+// returns err noting that the old pool was kept, joined with the delete error if any.
+// ctx may already be done, so the delete runs on a context of its own.
+func (s *nodePoolService) discardReplacement(ctx context.Context, clusterID, replacementID, nodePoolID string, err error) error {
+	err = fmt.Errorf("node pool %s was kept: %w", nodePoolID, err)
+
+	cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), replacementCleanupTimeout)
+	defer cancel()
+	if cleanupErr := s.Delete(cleanupCtx, clusterID, replacementID); cleanupErr != nil {
+		return errors.Join(err, fmt.Errorf("delete replacement node pool %s: %w", replacementID, cleanupErr))
+	}
+	return err
 }
 
 // Delete removes a node pool from a cluster
@@ -268,7 +885,120 @@ func (s *nodePoolService) Delete(ctx context.Context, clusterID, nodePoolID stri
 		return &client.ValidationError{Field: nodePoolIdField, Message: utils.CannotBeEmpty}
 	}
 
+	ctx, path := mgc_http.ExpandPathTemplate(ctx, DefaultBasePath, clusterNodepoolURL, clusterID, nodePoolID)
 	return mgc_http.ExecuteSimpleRequest(ctx, s.client.newRequest,
-		s.client.GetConfig(), http.MethodDelete,
-		fmt.Sprintf(clusterNodepoolURL, clusterID, nodePoolID), nil, nil)
+		s.client.GetConfig(), http.MethodDelete, path, nil, nil)
+}
+
+// DiffNodePool computes the minimal patch that moves current towards desired and reports
+// whether any change is needed. It compares the fields PatchNodePoolRequest can change:
+//   - Replicas, only when desired has no AutoScale, since the autoscaler owns replicas otherwise
+//   - AutoScale, when desired sets it and its bounds differ from current
+//   - AvailabilityZones, when desired sets them and they differ from current, ignoring order
+//
+// Fields desired leaves nil are not changed; removing autoscaling cannot be expressed as a patch.
+func DiffNodePool(current *NodePool, desired CreateNodePoolRequest) (PatchNodePoolRequest, bool) {
+	var patch PatchNodePoolRequest
+	changed := false
+
+	if desired.AutoScale == nil && current.Replicas != desired.Replicas {
+		replicas := desired.Replicas
+		patch.Replicas = &replicas
+		changed = true
+	}
+
+	if desired.AutoScale != nil && !sameAutoScale(current.AutoScale, desired.AutoScale) {
+		autoScale := *desired.AutoScale
+		patch.AutoScale = &autoScale
+		changed = true
+	}
+
+	if desired.AvailabilityZones != nil && !sameZones(current.AvailabilityZones, *desired.AvailabilityZones) {
+		zones := slices.Clone(*desired.AvailabilityZones)
+		patch.AvailabilityZones = &zones
+		changed = true
+	}
+
+	return patch, changed
+}
+
+// sameAutoScale reports whether current already has the bounds of desired
+func sameAutoScale(current, desired *AutoScale) bool {
+	if current == nil {
+		return false
+	}
+	return equalIntPtr(current.MinReplicas, desired.MinReplicas) &&
+		equalIntPtr(current.MaxReplicas, desired.MaxReplicas)
+}
+
+func equalIntPtr(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// sameZones reports whether current holds exactly the desired zones, in any order
+func sameZones(current *[]string, desired []string) bool {
+	if current == nil {
+		return len(desired) == 0
+	}
+	a := slices.Clone(*current)
+	b := slices.Clone(desired)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
+
+// validateAvailabilityZones checks that an optional zone list is not empty and has no blank entries
+func validateAvailabilityZones(zones *[]string) error {
+	if zones == nil {
+		return nil
+	}
+
+	if len(*zones) == 0 {
+		return &client.ValidationError{Field: availabilityZonesField, Message: utils.CannotBeEmpty}
+	}
+
+	for i, zone := range *zones {
+		if strings.TrimSpace(zone) == "" {
+			return &client.ValidationError{
+				Field:   fmt.Sprintf("%s[%d]", availabilityZonesField, i),
+				Message: utils.CannotBeEmpty,
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateReplicasInAutoScale checks that replicas lies within the autoscale range when
+// both bounds are set, since a count outside it leaves the intended size ambiguous
+func validateReplicasInAutoScale(replicas int, autoScale *AutoScale) error {
+	if autoScale == nil || autoScale.MinReplicas == nil || autoScale.MaxReplicas == nil {
+		return nil
+	}
+
+	minReplicas, maxReplicas := *autoScale.MinReplicas, *autoScale.MaxReplicas
+	if replicas < minReplicas || replicas > maxReplicas {
+		return &client.ValidationError{
+			Field:   replicasField,
+			Message: fmt.Sprintf("must be between auto scale min_replicas (%d) and max_replicas (%d), got %d", minReplicas, maxReplicas, replicas),
+		}
+	}
+
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, keeping unknown fields in RawExtra.
+func (r *NodePool) UnmarshalJSON(data []byte) error {
+	type nodePool NodePool
+	var decoded nodePool
+	extra, err := utils.UnmarshalWithExtra(data, &decoded)
+	if err != nil {
+		return err
+	}
+	*r = NodePool(decoded)
+	r.RawExtra = extra
+	return nil
 }
@@ -2,11 +2,21 @@ package kubernetes
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/MagaluCloud/mgc-sdk-go/client"
 	"github.com/MagaluCloud/mgc-sdk-go/helpers"
+	"github.com/MagaluCloud/mgc-sdk-go/internal/utils"
 )
 
 func TestNodePoolService_List(t *testing.T) {
@@ -80,6 +90,249 @@ func TestNodePoolService_List(t *testing.T) {
 	}
 }
 
+func TestNodePoolService_List_Filters(t *testing.T) {
+	pages := map[string]string{
+		"0": `{"results": [
+			{"id": "pool1", "tags": ["env=prod", "team=a"], "labels": {"role": "web"}, "status": {"state": "Running"}},
+			{"id": "pool2", "tags": ["env=dev"], "labels": {"role": "web"}, "status": {"state": "Error"}}
+		]}`,
+		"2": `{"results": [
+			{"id": "pool3", "tags": ["env=prod"], "labels": {"role": "db"}, "status": {"state": "Provisioning"}}
+		]}`,
+	}
+
+	tests := []struct {
+		name    string
+		opts    ListOptions
+		wantIDs []string
+	}{
+		{
+			name:    "filter by tag across pages",
+			opts:    ListOptions{Limit: helpers.IntPtr(2), Tags: []string{"env=prod"}},
+			wantIDs: []string{"pool1", "pool3"},
+		},
+		{
+			name:    "filter by label",
+			opts:    ListOptions{Limit: helpers.IntPtr(2), LabelSelector: map[string]string{"role": "web"}},
+			wantIDs: []string{"pool1", "pool2"},
+		},
+		{
+			name: "filter by label and tag",
+			opts: ListOptions{
+				Limit:         helpers.IntPtr(2),
+				LabelSelector: map[string]string{"role": "web"},
+				Tags:          []string{"env=prod", "team=a"},
+			},
+			wantIDs: []string{"pool1"},
+		},
+		{
+			name:    "nothing matches",
+			opts:    ListOptions{Limit: helpers.IntPtr(2), Tags: []string{"env=staging"}},
+			wantIDs: []string{},
+		},
+		{
+			name:    "filter by status ignoring case",
+			opts:    ListOptions{Limit: helpers.IntPtr(2), Status: []string{"error", "provisioning"}},
+			wantIDs: []string{"pool2", "pool3"},
+		},
+		{
+			name:    "filter by status and tag",
+			opts:    ListOptions{Limit: helpers.IntPtr(2), Tags: []string{"env=prod"}, Status: []string{NodePoolStateRunning}},
+			wantIDs: []string{"pool1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("_limit") != "2" {
+					t.Errorf("expected _limit=2, got %q", r.URL.Query().Get("_limit"))
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(pages[r.URL.Query().Get("_offset")]))
+			}))
+			defer server.Close()
+
+			client := testClient(server.URL)
+			result, err := client.Nodepools().List(context.Background(), "cluster-123", tt.opts)
+			if err != nil {
+				t.Fatalf("List() unexpected error: %v", err)
+			}
+			if result == nil {
+				t.Fatal("List() expected a non-nil slice")
+			}
+
+			var ids []string
+			for _, pool := range result {
+				ids = append(ids, pool.ID)
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.wantIDs, ",") {
+				t.Errorf("List() got = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestNodePoolService_ListNames(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("_fields"); got != "id,name" {
+			t.Errorf("expected _fields=id,name, got %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"results": [
+			{"id": "pool1", "name": "web"},
+			{"id": "pool2", "name": "db"}
+		]}`))
+	}))
+	defer server.Close()
+
+	refs, err := testClient(server.URL).Nodepools().ListNames(context.Background(), "cluster-123")
+	if err != nil {
+		t.Fatalf("ListNames() unexpected error: %v", err)
+	}
+	want := []NodePoolRef{{ID: "pool1", Name: "web"}, {ID: "pool2", Name: "db"}}
+	if !slices.Equal(refs, want) {
+		t.Errorf("ListNames() = %+v, want %+v", refs, want)
+	}
+
+	_, err = testClient(server.URL).Nodepools().ListNames(context.Background(), "")
+	var validationErr *client.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Errorf("expected a ValidationError for an empty cluster ID, got %v", err)
+	}
+}
+
+func TestNodePoolService_List_Fields(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       ListOptions
+		wantFields string
+		wantIDs    string
+	}{
+		{
+			name:       "selected fields",
+			opts:       ListOptions{Fields: []string{"name", "replicas"}},
+			wantFields: "id,name,replicas",
+			wantIDs:    "pool1,pool2",
+		},
+		{
+			name:       "filter fields are added",
+			opts:       ListOptions{Limit: helpers.IntPtr(5), Fields: []string{"name"}, Tags: []string{"env=prod"}},
+			wantFields: "id,tags,name",
+			wantIDs:    "pool1",
+		},
+		{
+			name:       "status field is added",
+			opts:       ListOptions{Fields: []string{"name"}, Status: []string{"Running"}},
+			wantFields: "id,status,name",
+			wantIDs:    "",
+		},
+		{
+			name:    "full objects by default",
+			wantIDs: "pool1,pool2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.URL.Query().Get("_fields"); got != tt.wantFields {
+					t.Errorf("expected _fields=%q, got %q", tt.wantFields, got)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"results": [
+					{"id": "pool1", "name": "web", "replicas": 3, "tags": ["env=prod"]},
+					{"id": "pool2", "name": "db", "replicas": 1}
+				]}`))
+			}))
+			defer server.Close()
+
+			pools, err := testClient(server.URL).Nodepools().List(context.Background(), "cluster-123", tt.opts)
+			if err != nil {
+				t.Fatalf("List() unexpected error: %v", err)
+			}
+
+			var ids []string
+			for _, pool := range pools {
+				ids = append(ids, pool.ID)
+				if pool.CreatedAt != nil || pool.InstanceTemplate.Flavor.Name != "" {
+					t.Errorf("expected unselected fields to stay zero, got %+v", pool)
+				}
+			}
+			if strings.Join(ids, ",") != tt.wantIDs {
+				t.Errorf("List() got %v, want %s", ids, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestNodePoolService_ListPage(t *testing.T) {
+	pages := map[string]string{
+		"0": `{"results": [{"id": "pool1", "tags": ["env=prod"]}, {"id": "pool2"}]}`,
+		"2": `{"results": [{"id": "pool3"}, {"id": "pool4"}]}`,
+		"4": `{"results": []}`,
+	}
+
+	var offsets []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("_limit") != "2" {
+			t.Errorf("expected _limit=2, got %q", r.URL.Query().Get("_limit"))
+		}
+		offsets = append(offsets, r.URL.Query().Get("_offset"))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(pages[r.URL.Query().Get("_offset")]))
+	}))
+	defer server.Close()
+
+	pools := testClient(server.URL).Nodepools()
+	page, err := pools.ListPage(context.Background(), "cluster-123",
+		ListOptions{Limit: helpers.IntPtr(2)})
+	if err != nil {
+		t.Fatalf("ListPage() unexpected error: %v", err)
+	}
+
+	var ids []string
+	var pageCount int
+	for page != nil {
+		pageCount++
+		for _, pool := range page.Items {
+			ids = append(ids, pool.ID)
+		}
+		page, err = page.Next(context.Background())
+		if err != nil {
+			t.Fatalf("Next() unexpected error: %v", err)
+		}
+	}
+
+	if strings.Join(ids, ",") != "pool1,pool2,pool3,pool4" {
+		t.Errorf("expected pool1..pool4, got %v", ids)
+	}
+	if pageCount != 3 || strings.Join(offsets, ",") != "0,2,4" {
+		t.Errorf("expected 3 pages at offsets 0,2,4, got %d at %v", pageCount, offsets)
+	}
+
+	if _, err := pools.ListPage(context.Background(), "", ListOptions{}); err == nil {
+		t.Error("ListPage() expected an error for an empty cluster ID")
+	}
+
+	for name, opts := range map[string]ListOptions{
+		"label selector": {LabelSelector: map[string]string{"team": "data"}},
+		"tags":           {Tags: []string{"env=prod"}},
+		"status":         {Status: []string{"Running"}},
+	} {
+		offsets = nil
+		var validationErr *client.ValidationError
+		if _, err := pools.ListPage(context.Background(), "cluster-123", opts); !errors.As(err, &validationErr) {
+			t.Errorf("ListPage() with %s: expected a validation error, got %v", name, err)
+		}
+		if len(offsets) != 0 {
+			t.Errorf("ListPage() with %s: expected no request, got %d", name, len(offsets))
+		}
+	}
+}
+
 func TestNodePoolService_Create(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -249,6 +502,82 @@ func TestNodePoolService_Create_ValidationError(t *testing.T) {
 	}
 }
 
+func TestNodePoolService_Create_AutoScaleReplicas(t *testing.T) {
+	tests := []struct {
+		name      string
+		replicas  int
+		autoScale *AutoScale
+		wantErr   bool
+	}{
+		{
+			name:      "replicas within range",
+			replicas:  3,
+			autoScale: &AutoScale{MinReplicas: helpers.IntPtr(1), MaxReplicas: helpers.IntPtr(5)},
+		},
+		{
+			name:      "replicas at bounds",
+			replicas:  5,
+			autoScale: &AutoScale{MinReplicas: helpers.IntPtr(5), MaxReplicas: helpers.IntPtr(5)},
+		},
+		{
+			name:      "replicas below min",
+			replicas:  1,
+			autoScale: &AutoScale{MinReplicas: helpers.IntPtr(2), MaxReplicas: helpers.IntPtr(5)},
+			wantErr:   true,
+		},
+		{
+			name:      "replicas above max",
+			replicas:  6,
+			autoScale: &AutoScale{MinReplicas: helpers.IntPtr(2), MaxReplicas: helpers.IntPtr(5)},
+			wantErr:   true,
+		},
+		{
+			name:      "only min set",
+			replicas:  10,
+			autoScale: &AutoScale{MinReplicas: helpers.IntPtr(2)},
+		},
+		{
+			name:     "no autoscale",
+			replicas: 10,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var called bool
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"id": "pool-new"}`))
+			}))
+			defer server.Close()
+
+			svc := testClient(server.URL).Nodepools()
+			_, err := svc.Create(context.Background(), "cluster-123", CreateNodePoolRequest{
+				Name:      "pool",
+				Flavor:    "gp1.small",
+				Replicas:  tt.replicas,
+				AutoScale: tt.autoScale,
+			})
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				var validationErr *client.ValidationError
+				if !errors.As(err, &validationErr) || validationErr.Field != "replicas" {
+					t.Errorf("expected a replicas ValidationError, got %v", err)
+				}
+				if called {
+					t.Error("expected no request to be sent")
+				}
+			}
+		})
+	}
+}
+
 func TestNodePoolService_Scale(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -347,11 +676,52 @@ func TestNodePoolService_Update(t *testing.T) {
 			response:   `{"replica`,
 			wantErr:    true,
 		},
+		{
+			name:       "update availability zones",
+			clusterID:  "cluster-123",
+			nodePoolID: "pool-456",
+			request: PatchNodePoolRequest{
+				AvailabilityZones: &[]string{"br-se1-a", "br-se1-b"},
+			},
+			response:     `{"replicas": 2, "availability_zones": ["br-se1-a", "br-se1-b"]}`,
+			statusCode:   http.StatusOK,
+			wantReplicas: 2,
+			wantErr:      false,
+		},
+		{
+			name:       "empty availability zones",
+			clusterID:  "cluster-123",
+			nodePoolID: "pool-456",
+			request: PatchNodePoolRequest{
+				AvailabilityZones: &[]string{},
+			},
+			statusCode: http.StatusOK,
+			response:   `{"replicas": 2}`,
+			wantErr:    true,
+		},
+		{
+			name:       "blank availability zone entry",
+			clusterID:  "cluster-123",
+			nodePoolID: "pool-456",
+			request: PatchNodePoolRequest{
+				AvailabilityZones: &[]string{"br-se1-a", " "},
+			},
+			statusCode: http.StatusOK,
+			response:   `{"replicas": 2}`,
+			wantErr:    true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.request.AvailabilityZones != nil {
+					var body map[string]any
+					json.NewDecoder(r.Body).Decode(&body)
+					if _, ok := body["availability_zones"]; !ok {
+						t.Error("expected availability_zones in request body")
+					}
+				}
 				w.WriteHeader(tt.statusCode)
 				w.Write([]byte(tt.response))
 			}))
@@ -607,3 +977,677 @@ func TestNodeService_Nodes(t *testing.T) {
 		})
 	}
 }
+
+func TestNodePoolService_WaitUntilRunning(t *testing.T) {
+	tests := []struct {
+		name      string
+		states    []string
+		wantErr   bool
+		wantPolls int
+	}{
+		{name: "running after provisioning", states: []string{"Provisioning", "Provisioning", "Running"}, wantPolls: 3},
+		{name: "failed state", states: []string{"Provisioning", "Failed"}, wantErr: true, wantPolls: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			polls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				state := tt.states[polls]
+				polls++
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"id": "pool-1", "status": {"state": "` + state + `"}}`))
+			}))
+			defer server.Close()
+
+			var seen []string
+			pool, err := testClient(server.URL).Nodepools().WaitUntilRunning(context.Background(), "cluster-123", "pool-1",
+				NodePoolWaitOptions{
+					Interval:   time.Millisecond,
+					OnProgress: func(_ string, p *NodePool) { seen = append(seen, p.Status.State) },
+				})
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("WaitUntilRunning() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && pool.Status.State != NodePoolStateRunning {
+				t.Errorf("expected running pool, got %s", pool.Status.State)
+			}
+			if polls != tt.wantPolls || len(seen) != tt.wantPolls {
+				t.Errorf("expected %d polls, got %d (progress calls %d)", tt.wantPolls, polls, len(seen))
+			}
+		})
+	}
+}
+
+func TestNodePoolService_WaitUntilRunning_Timeout(t *testing.T) {
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": "pool-1", "status": {"state": "Provisioning"}}`))
+	}))
+	defer server.Close()
+
+	start := time.Now()
+	clock := utils.NewFakeClock(start)
+	ctx, cancel := context.WithDeadline(utils.WithClock(context.Background(), clock), start.Add(time.Minute))
+	defer cancel()
+
+	backoff := &helpers.Backoff{Initial: 5 * time.Second, Factor: 2}
+	_, err := testClient(server.URL).Nodepools().WaitUntilRunning(ctx, "cluster-123", "pool-1",
+		NodePoolWaitOptions{Backoff: backoff})
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "Provisioning") {
+		t.Errorf("WaitUntilRunning() error = %v, want context.DeadlineExceeded naming the last state", err)
+	}
+	// polls at 0s, 5s, 15s and 35s; the 40s sleep after the last one reaches the deadline
+	if polls != 4 {
+		t.Errorf("expected 4 polls, got %d", polls)
+	}
+	want := []time.Duration{5 * time.Second, 10 * time.Second, 20 * time.Second, 40 * time.Second}
+	if got := clock.Sleeps(); !slices.Equal(got, want) {
+		t.Errorf("expected sleeps %v, got %v", want, got)
+	}
+	if elapsed := clock.Now().Sub(start); elapsed != time.Minute {
+		t.Errorf("expected the wait to end at the deadline, got %v", elapsed)
+	}
+}
+
+func TestNodePoolService_WaitUntilRunning_Label(t *testing.T) {
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": "pool-1", "status": {"state": "Provisioning"}}`))
+	}))
+	defer server.Close()
+
+	var labels []string
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+
+	_, err := testClient(server.URL).Nodepools().WaitUntilRunning(ctx, "cluster-123", "pool-1",
+		NodePoolWaitOptions{
+			Label:      "batch-3/gpu",
+			Interval:   5 * time.Millisecond,
+			OnProgress: func(label string, _ *NodePool) { labels = append(labels, label) },
+		})
+
+	if len(labels) == 0 || len(labels) != polls {
+		t.Fatalf("expected a progress call per poll, got %d calls for %d polls", len(labels), polls)
+	}
+	for _, label := range labels {
+		if label != "batch-3/gpu" {
+			t.Errorf("progress label = %q, want %q", label, "batch-3/gpu")
+		}
+	}
+	if err == nil || !strings.Contains(err.Error(), "batch-3/gpu") {
+		t.Errorf("expected timeout error to name the label, got %v", err)
+	}
+}
+
+func TestNodePoolService_WaitUntilRunning_Cancelled(t *testing.T) {
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": "pool-1", "status": {"state": "Provisioning"}}`))
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := testClient(server.URL).Nodepools().WaitUntilRunning(ctx, "cluster-123", "pool-1",
+		NodePoolWaitOptions{Interval: time.Hour})
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("WaitUntilRunning() returned after %v, want under 500ms", elapsed)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("WaitUntilRunning() error = %v, want context.Canceled", err)
+	}
+	if polls != 1 {
+		t.Errorf("expected 1 poll, got %d", polls)
+	}
+
+	polls = 0
+	if _, err := testClient(server.URL).Nodepools().WaitUntilRunning(ctx, "cluster-123", "pool-1",
+		NodePoolWaitOptions{Interval: time.Millisecond}); !errors.Is(err, context.Canceled) {
+		t.Errorf("WaitUntilRunning() error = %v, want context.Canceled", err)
+	}
+	if polls != 0 {
+		t.Errorf("expected no poll with an already cancelled context, got %d", polls)
+	}
+}
+
+func TestNodePoolService_UpdateInstanceTemplate(t *testing.T) {
+	hdd := DiskTypeCloudHDD
+	tests := []struct {
+		name              string
+		diskType          *DiskType
+		newPoolState      string
+		patchStatus       int
+		cleanupStatus     int
+		wantErr           bool
+		wantDeleted       bool
+		wantDiscarded     bool
+		wantErrNamesNew   bool
+		wantDiskType      string
+		wantLabelsPatched bool
+	}{
+		{name: "rolling replacement", newPoolState: "Running", wantDeleted: true, wantDiskType: "cloud_nvme", wantLabelsPatched: true},
+		{name: "replacement with a new disk type", diskType: &hdd, newPoolState: "Running", wantDeleted: true, wantDiskType: "cloud_hdd", wantLabelsPatched: true},
+		{name: "replacement fails and is deleted", newPoolState: "Failed", wantErr: true, wantDiscarded: true, wantDiskType: "cloud_nvme"},
+		{name: "replacement cleanup fails", newPoolState: "Failed", cleanupStatus: http.StatusInternalServerError, wantErr: true, wantDiscarded: true, wantErrNamesNew: true, wantDiskType: "cloud_nvme"},
+		{name: "label copy fails and replacement is deleted", newPoolState: "Running", patchStatus: http.StatusBadRequest, wantErr: true, wantDiscarded: true, wantDiskType: "cloud_nvme", wantLabelsPatched: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deleted, discarded := false, false
+			var created, patched map[string]any
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/node_pools/old-pool"):
+					w.Write([]byte(`{"id": "old-pool", "name": "workers", "flavor": "cloud-k8s.gp1.small",
+						"replicas": 3, "tags": ["env=prod"], "labels": {"team": "payments"}, "max_pods_per_node": 110,
+						"availability_zones": ["br-se1-a"], "instance_template": {"disk_type": "cloud_nvme"},
+						"status": {"state": "Running"}}`))
+				case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/node_pools"):
+					json.NewDecoder(r.Body).Decode(&created)
+					w.Write([]byte(`{"id": "new-pool", "name": "workers-large", "status": {"state": "Provisioning"}}`))
+				case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/node_pools/new-pool"):
+					w.Write([]byte(`{"id": "new-pool", "name": "workers-large", "status": {"state": "` + tt.newPoolState + `"}}`))
+				case r.Method == http.MethodPatch && strings.HasSuffix(r.URL.Path, "/node_pools/new-pool"):
+					json.NewDecoder(r.Body).Decode(&patched)
+					if tt.patchStatus != 0 {
+						w.WriteHeader(tt.patchStatus)
+						w.Write([]byte(`{"message": "invalid labels"}`))
+						return
+					}
+					w.Write([]byte(`{"id": "new-pool", "name": "workers-large", "labels": {"team": "payments"}, "status": {"state": "Running"}}`))
+				case r.Method == http.MethodDelete && strings.HasSuffix(r.URL.Path, "/node_pools/new-pool"):
+					discarded = true
+					if tt.cleanupStatus != 0 {
+						w.WriteHeader(tt.cleanupStatus)
+						w.Write([]byte(`{"message": "internal error"}`))
+						return
+					}
+					w.WriteHeader(http.StatusNoContent)
+				case r.Method == http.MethodDelete && strings.HasSuffix(r.URL.Path, "/node_pools/old-pool"):
+					deleted = true
+					w.WriteHeader(http.StatusNoContent)
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			pool, err := testClient(server.URL).Nodepools().UpdateInstanceTemplate(context.Background(), "cluster-123", "old-pool",
+				InstanceTemplateUpdate{
+					Name:     "workers-large",
+					Flavor:   "cloud-k8s.gp1.large",
+					DiskType: tt.diskType,
+					Wait:     NodePoolWaitOptions{Interval: time.Millisecond},
+				})
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UpdateInstanceTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if deleted != tt.wantDeleted {
+				t.Errorf("expected old pool deleted = %v, got %v", tt.wantDeleted, deleted)
+			}
+			if discarded != tt.wantDiscarded {
+				t.Errorf("expected replacement pool deleted = %v, got %v", tt.wantDiscarded, discarded)
+			}
+			if created["flavor"] != "cloud-k8s.gp1.large" || created["replicas"] != float64(3) ||
+				created["max_pods_per_node"] != float64(110) || created["disk_type"] != tt.wantDiskType {
+				t.Errorf("unexpected replacement pool request %v", created)
+			}
+			if tt.wantLabelsPatched {
+				if labels, _ := patched["labels"].(map[string]any); labels["team"] != "payments" {
+					t.Errorf("expected the old pool's labels copied, got %v", patched)
+				}
+			} else if patched != nil {
+				t.Errorf("unexpected update of the replacement pool %v", patched)
+			}
+			if tt.wantErrNamesNew && !strings.Contains(err.Error(), "delete replacement node pool new-pool") {
+				t.Errorf("expected the error to name the replacement pool, got %v", err)
+			}
+			if !tt.wantErr && (pool.ID != "new-pool" || pool.Labels["team"] != "payments") {
+				t.Errorf("expected new-pool with the copied labels, got %+v", pool)
+			}
+		})
+	}
+}
+
+func TestNodePoolService_UpdateInstanceTemplate_Validation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": "pool-1", "name": "workers"}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name string
+		req  InstanceTemplateUpdate
+	}{
+		{name: "empty name", req: InstanceTemplateUpdate{Flavor: "cloud-k8s.gp1.large"}},
+		{name: "empty flavor", req: InstanceTemplateUpdate{Name: "workers-large"}},
+		{name: "same name", req: InstanceTemplateUpdate{Name: "workers", Flavor: "cloud-k8s.gp1.large"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := testClient(server.URL).Nodepools().UpdateInstanceTemplate(context.Background(), "cluster-123", "pool-1", tt.req)
+			var validationErr *client.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Errorf("expected validation error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestDiffNodePool(t *testing.T) {
+	current := &NodePool{
+		Replicas:          3,
+		AutoScale:         &AutoScale{MinReplicas: helpers.IntPtr(1), MaxReplicas: helpers.IntPtr(5)},
+		AvailabilityZones: &[]string{"br-se1-a", "br-se1-b"},
+	}
+
+	tests := []struct {
+		name        string
+		desired     CreateNodePoolRequest
+		wantChanged bool
+		wantPatch   string
+	}{
+		{
+			name:        "no change",
+			desired:     CreateNodePoolRequest{Replicas: 3, AvailabilityZones: &[]string{"br-se1-b", "br-se1-a"}},
+			wantChanged: false,
+			wantPatch:   `{}`,
+		},
+		{
+			name:        "replica change only",
+			desired:     CreateNodePoolRequest{Replicas: 5},
+			wantChanged: true,
+			wantPatch:   `{"replicas":5}`,
+		},
+		{
+			name: "autoscale change",
+			desired: CreateNodePoolRequest{
+				Replicas:  10,
+				AutoScale: &AutoScale{MinReplicas: helpers.IntPtr(2), MaxReplicas: helpers.IntPtr(5)},
+			},
+			wantChanged: true,
+			wantPatch:   `{"auto_scale":{"min_replicas":2,"max_replicas":5}}`,
+		},
+		{
+			name: "same autoscale ignores replicas",
+			desired: CreateNodePoolRequest{
+				Replicas:  10,
+				AutoScale: &AutoScale{MinReplicas: helpers.IntPtr(1), MaxReplicas: helpers.IntPtr(5)},
+			},
+			wantChanged: false,
+			wantPatch:   `{}`,
+		},
+		{
+			name:        "availability zones change",
+			desired:     CreateNodePoolRequest{Replicas: 3, AvailabilityZones: &[]string{"br-se1-a"}},
+			wantChanged: true,
+			wantPatch:   `{"availability_zones":["br-se1-a"]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch, changed := DiffNodePool(current, tt.desired)
+			if changed != tt.wantChanged {
+				t.Errorf("DiffNodePool() changed = %v, want %v", changed, tt.wantChanged)
+			}
+			got, _ := json.Marshal(patch)
+			if string(got) != tt.wantPatch {
+				t.Errorf("DiffNodePool() patch = %s, want %s", got, tt.wantPatch)
+			}
+		})
+	}
+}
+
+func TestNodePool_UnmarshalJSON_RawExtra(t *testing.T) {
+	var pool NodePool
+	data := `{"id": "np-1", "name": "pool", "replicas": 3, "gpu_profile": "a100"}`
+	if err := json.Unmarshal([]byte(data), &pool); err != nil {
+		t.Fatalf("Unmarshal() unexpected error: %v", err)
+	}
+
+	if pool.ID != "np-1" || pool.Replicas != 3 {
+		t.Errorf("known fields not decoded: %+v", pool)
+	}
+	if len(pool.RawExtra) != 1 || string(pool.RawExtra["gpu_profile"]) != `"a100"` {
+		t.Errorf("unexpected extra fields %v", pool.RawExtra)
+	}
+}
+
+func TestCreateNodePoolRequest_Preemptible(t *testing.T) {
+	req := CreateNodePoolRequest{Name: "batch", Flavor: "cloud-k8s.gp1.small", Replicas: 2}
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("Marshal() unexpected error: %v", err)
+	}
+	if strings.Contains(string(data), "preemptible") {
+		t.Errorf("expected preemptible to be omitted when unset, got %s", data)
+	}
+
+	preemptible := true
+	req.Preemptible = &preemptible
+	data, _ = json.Marshal(req)
+	if !strings.Contains(string(data), `"preemptible":true`) {
+		t.Errorf("expected preemptible to be sent when set, got %s", data)
+	}
+
+	var pool NodePool
+	if err := json.Unmarshal([]byte(`{"id": "np-1", "preemptible": true}`), &pool); err != nil {
+		t.Fatalf("Unmarshal() unexpected error: %v", err)
+	}
+	if !pool.Preemptible || pool.RawExtra != nil {
+		t.Errorf("expected preemptible node pool without extra fields, got %+v", pool)
+	}
+}
+
+func TestNodePoolService_WaitForReplicas(t *testing.T) {
+	nodes := func(states ...string) string {
+		items := make([]string, len(states))
+		for i, state := range states {
+			items[i] = fmt.Sprintf(`{"id": "node-%d", "status": {"state": %q}}`, i, state)
+		}
+		return `{"results": [` + strings.Join(items, ",") + `]}`
+	}
+
+	tests := []struct {
+		name      string
+		poolState []string
+		nodes     []string
+		wantErr   string
+		wantPolls int
+	}{
+		{
+			name:      "converges over several polls",
+			poolState: []string{"Scaling", "Scaling", "Running", "Running"},
+			nodes: []string{
+				nodes("Ready", "Ready"),
+				nodes("Ready", "Ready", "Provisioning"),
+				nodes("Ready", "Ready", "Ready", "Terminating"),
+				nodes("Ready", "Ready", "Ready"),
+			},
+			wantPolls: 4,
+		},
+		{
+			name:      "fails fast on error state",
+			poolState: []string{"Scaling", "Error"},
+			nodes:     []string{nodes("Ready"), nodes("Ready")},
+			wantErr:   "reached state Error",
+			wantPolls: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			polls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if strings.HasSuffix(r.URL.Path, "/nodes") {
+					w.Write([]byte(tt.nodes[polls-1]))
+					return
+				}
+				state := tt.poolState[polls]
+				polls++
+				w.Write([]byte(fmt.Sprintf(`{"id": "pool-1", "replicas": 3, "status": {"state": %q}}`, state)))
+			}))
+			defer server.Close()
+
+			pool, err := testClient(server.URL).Nodepools().WaitForReplicas(context.Background(), "cluster-123", "pool-1", 3,
+				NodePoolWaitOptions{Interval: time.Millisecond})
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("WaitForReplicas() error = %v, want %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("WaitForReplicas() unexpected error: %v", err)
+			} else if pool.Replicas != 3 {
+				t.Errorf("expected 3 replicas, got %d", pool.Replicas)
+			}
+			if polls != tt.wantPolls {
+				t.Errorf("expected %d polls, got %d", tt.wantPolls, polls)
+			}
+		})
+	}
+}
+
+func TestNodePoolService_AllNodes(t *testing.T) {
+	var current, peak int
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/node-pools") {
+			w.Write([]byte(`{"results": [
+				{"id": "pool-a", "name": "web"},
+				{"id": "pool-b", "name": "db"},
+				{"id": "pool-c", "name": "batch"}
+			]}`))
+			return
+		}
+
+		mu.Lock()
+		current++
+		peak = max(peak, current)
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		defer func() {
+			mu.Lock()
+			current--
+			mu.Unlock()
+		}()
+
+		switch {
+		case strings.Contains(r.URL.Path, "/pool-a/"):
+			w.Write([]byte(`{"results": [{"id": "a1"}, {"id": "a2"}]}`))
+		case strings.Contains(r.URL.Path, "/pool-b/"):
+			w.Write([]byte(`{"results": [{"id": "b1", "nodepool_name": "db-pool"}, {"id": "b2"}, {"id": "b3"}]}`))
+		default:
+			w.Write([]byte(`{"results": [{"id": "c1"}]}`))
+		}
+	}))
+	defer server.Close()
+
+	nodes, err := testClient(server.URL).Nodepools().AllNodes(context.Background(), "cluster-123", 2)
+	if err != nil {
+		t.Fatalf("AllNodes() unexpected error: %v", err)
+	}
+
+	var got []string
+	for _, node := range nodes {
+		got = append(got, node.ID+"@"+node.NodepoolName)
+	}
+	want := "a1@web,a2@web,b1@db-pool,b2@db,b3@db,c1@batch"
+	if strings.Join(got, ",") != want {
+		t.Errorf("AllNodes() got %v, want %s", got, want)
+	}
+	if peak > 2 {
+		t.Errorf("expected at most 2 concurrent node requests, got %d", peak)
+	}
+}
+
+func TestNodePoolService_AllNodes_PartialFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/node-pools"):
+			w.Write([]byte(`{"results": [{"id": "pool-a", "name": "web"}, {"id": "pool-b", "name": "db"}]}`))
+		case strings.Contains(r.URL.Path, "/pool-b/"):
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "not found"}`))
+		default:
+			w.Write([]byte(`{"results": [{"id": "a1"}]}`))
+		}
+	}))
+	defer server.Close()
+
+	nodes, err := testClient(server.URL).Nodepools().AllNodes(context.Background(), "cluster-123", 0)
+	if err == nil || !strings.Contains(err.Error(), "pool-b") {
+		t.Errorf("expected an error naming pool-b, got %v", err)
+	}
+	if len(nodes) != 1 || nodes[0].ID != "a1" {
+		t.Errorf("expected the nodes of pool-a, got %+v", nodes)
+	}
+}
+
+func TestNodePoolService_ScaleMany(t *testing.T) {
+	var mu sync.Mutex
+	sent := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("expected PATCH, got %s", r.Method)
+		}
+		id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		var req PatchNodePoolRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Replicas == nil {
+			t.Errorf("expected a replicas patch for %s, got %+v (%v)", id, req, err)
+			return
+		}
+		mu.Lock()
+		sent[id] = *req.Replicas
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		if id == "pool-b" {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"message": "node pool is being updated"}`))
+			return
+		}
+		w.Write([]byte(fmt.Sprintf(`{"id": %q, "replicas": %d}`, id, *req.Replicas)))
+	}))
+	defer server.Close()
+
+	pools, errs := testClient(server.URL).Nodepools().ScaleMany(context.Background(), "cluster-123",
+		map[string]int{"pool-a": 5, "pool-b": 2, "pool-c": -1, "pool-d": 0}, 2)
+
+	if len(pools) != 2 || pools["pool-a"].Replicas != 5 || pools["pool-d"] == nil || pools["pool-d"].Replicas != 0 {
+		t.Errorf("expected pool-a and pool-d to be scaled, got %+v", pools)
+	}
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %v", errs)
+	}
+	if !strings.Contains(errs[0].Error(), "pool-b") || !strings.Contains(errs[0].Error(), "409") {
+		t.Errorf("expected the pool-b API error first, got %v", errs[0])
+	}
+	var validationErr *client.ValidationError
+	if !errors.As(errs[1], &validationErr) || validationErr.Field != "replicas" || !strings.Contains(errs[1].Error(), "pool-c") {
+		t.Errorf("expected a replicas ValidationError for pool-c, got %v", errs[1])
+	}
+	if _, ok := sent["pool-c"]; ok {
+		t.Error("expected the negative target not to be sent")
+	}
+}
+
+func TestNodePoolService_DefaultPageSize(t *testing.T) {
+	var limits []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limits = append(limits, r.URL.Query().Get("_limit"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"results": [{"id": "pool-a", "tags": ["env=prod"]}]}`))
+	}))
+	defer server.Close()
+
+	core := client.NewMgcClient("test-api",
+		client.WithBaseURL(client.MgcUrl(server.URL)),
+		client.WithDefaultPageSize(20))
+	pools := New(core).Nodepools()
+
+	if _, err := pools.List(context.Background(), "cluster-123", ListOptions{}); err != nil {
+		t.Fatalf("List() unexpected error: %v", err)
+	}
+	if _, err := pools.List(context.Background(), "cluster-123", ListOptions{Tags: []string{"env=prod"}}); err != nil {
+		t.Fatalf("List() with tags unexpected error: %v", err)
+	}
+	if _, err := pools.ListPage(context.Background(), "cluster-123", ListOptions{}); err != nil {
+		t.Fatalf("ListPage() unexpected error: %v", err)
+	}
+	if _, err := pools.List(context.Background(), "cluster-123", ListOptions{Limit: helpers.IntPtr(5)}); err != nil {
+		t.Fatalf("List() unexpected error: %v", err)
+	}
+
+	want := "20,20,20,5"
+	if got := strings.Join(limits, ","); got != want {
+		t.Errorf("expected limits %s, got %s", want, got)
+	}
+}
+
+func TestNodePoolService_List_EmptyResults(t *testing.T) {
+	for _, body := range []string{`{"results": null}`, `{"results": []}`, `{}`} {
+		t.Run(body, func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(body))
+			}))
+			defer server.Close()
+
+			result, err := testClient(server.URL).Nodepools().List(context.Background(), "cluster-123", ListOptions{})
+			if err != nil {
+				t.Fatalf("List() unexpected error: %v", err)
+			}
+			if result == nil || len(result) != 0 {
+				t.Errorf("List() = %#v, want empty non-nil slice", result)
+			}
+		})
+	}
+}
+
+func TestNodePoolService_ScaleMany_Deadline(t *testing.T) {
+	var mu sync.Mutex
+	sent := map[string]bool{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		sent[r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]] = true
+		mu.Unlock()
+		io.Copy(io.Discard, r.Body)
+		// never answers in time, so each update takes its whole share of the budget
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	targets := map[string]int{}
+	for i := range 8 {
+		targets[fmt.Sprintf("pool-%d", i)] = 1
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 4*utils.MinBatchItemBudget-utils.MinBatchItemBudget/2)
+	defer cancel()
+
+	pools, errs := testClient(server.URL).Nodepools().ScaleMany(ctx, "cluster-123", targets, 1)
+
+	if len(pools) != 0 || len(errs) != len(targets) {
+		t.Fatalf("expected every pool to fail, got %d scaled and %v", len(pools), errs)
+	}
+	unattempted := 0
+	for _, err := range errs {
+		if strings.Contains(err.Error(), "not started") {
+			unattempted++
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("expected an unattempted pool to fail with context.DeadlineExceeded, got %v", err)
+			}
+		}
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if unattempted == 0 || len(sent) == 0 {
+		t.Errorf("expected some pools to be sent and the rest left unattempted, got %d sent and %d unattempted", len(sent), unattempted)
+	}
+	if len(sent)+unattempted != len(targets) {
+		t.Errorf("expected the unattempted pools not to be sent, got %d sent and %d unattempted", len(sent), unattempted)
+	}
+}
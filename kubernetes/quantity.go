@@ -0,0 +1,197 @@
+package kubernetes
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+	"github.com/MagaluCloud/mgc-sdk-go/internal/utils"
+)
+
+type (
+	// ResourceQuantities represents node resources converted to comparable numbers
+	ResourceQuantities struct {
+		CPUMillis             int64
+		MemoryBytes           int64
+		EphemeralStorageBytes int64
+		Hugepages1GiBytes     int64
+		Hugepages2MiBytes     int64
+		Pods                  int64
+	}
+)
+
+// quantitySuffixes maps Kubernetes quantity suffixes to their multipliers.
+// Binary suffixes are listed first so "Mi" is not mistaken for "M".
+var quantitySuffixes = []struct {
+	suffix     string
+	multiplier float64
+}{
+	{"Ki", 1 << 10},
+	{"Mi", 1 << 20},
+	{"Gi", 1 << 30},
+	{"Ti", 1 << 40},
+	{"Pi", 1 << 50},
+	{"Ei", 1 << 60},
+	{"k", 1e3},
+	{"M", 1e6},
+	{"G", 1e9},
+	{"T", 1e12},
+	{"P", 1e15},
+	{"E", 1e18},
+}
+
+// maxQuantity is 2^63, the first value that does not fit in an int64
+const maxQuantity = float64(1 << 63)
+
+// ParseCPUMillis converts a Kubernetes CPU quantity ("16000m", "4", "0.5") to millicores.
+// Invalid, negative, NaN, infinite or overflowing quantities are reported as a ValidationError.
+func ParseCPUMillis(s string) (int64, error) {
+	return parseCPUMillis("cpu", s)
+}
+
+// ParseMemoryBytes converts a Kubernetes memory quantity ("32Gi", "512M", "1024") to bytes.
+// Invalid, negative, NaN, infinite or overflowing quantities are reported as a ValidationError.
+func ParseMemoryBytes(s string) (int64, error) {
+	return parseBytes("memory", s)
+}
+
+// parseCPUMillis converts the CPU quantity s of field to millicores
+func parseCPUMillis(field, s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, &client.ValidationError{Field: field, Message: utils.CannotBeEmpty}
+	}
+
+	var (
+		value float64
+		err   error
+	)
+	if strings.HasSuffix(s, "m") {
+		value, err = parseQuantityValue(strings.TrimSuffix(s, "m"))
+	} else {
+		value, err = parseQuantity(s)
+		value *= 1000
+	}
+	if err != nil {
+		return 0, invalidQuantity(field, s, err)
+	}
+	return quantityToInt64(field, s, value)
+}
+
+// parseBytes converts the byte quantity s of field to bytes
+func parseBytes(field, s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, &client.ValidationError{Field: field, Message: utils.CannotBeEmpty}
+	}
+
+	value, err := parseQuantity(s)
+	if err != nil {
+		return 0, invalidQuantity(field, s, err)
+	}
+	return quantityToInt64(field, s, value)
+}
+
+// quantityToInt64 rounds value up to a whole number, rejecting values beyond int64
+func quantityToInt64(field, s string, value float64) (int64, error) {
+	value = math.Ceil(value)
+	if value >= maxQuantity {
+		return 0, invalidQuantity(field, s, fmt.Errorf("overflows int64"))
+	}
+	return int64(value), nil
+}
+
+// invalidQuantity reports the quantity s of field as invalid because of err
+func invalidQuantity(field, s string, err error) error {
+	return &client.ValidationError{Field: field, Message: fmt.Sprintf("invalid quantity %q: %v", s, err)}
+}
+
+// parseQuantity parses a quantity with an optional binary or decimal suffix
+func parseQuantity(s string) (float64, error) {
+	multiplier := 1.0
+	for _, qs := range quantitySuffixes {
+		if strings.HasSuffix(s, qs.suffix) {
+			s = strings.TrimSuffix(s, qs.suffix)
+			multiplier = qs.multiplier
+			break
+		}
+	}
+
+	value, err := parseQuantityValue(s)
+	if err != nil {
+		return 0, err
+	}
+	return value * multiplier, nil
+}
+
+// parseQuantityValue parses the number of a quantity, which must be finite and not negative
+func parseQuantityValue(s string) (float64, error) {
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil && !errors.Is(err, strconv.ErrRange) {
+		return 0, fmt.Errorf("not a number")
+	}
+	switch {
+	case math.IsNaN(value):
+		return 0, fmt.Errorf("not a number")
+	case math.IsInf(value, 0):
+		return 0, fmt.Errorf("out of range")
+	case value < 0:
+		return 0, fmt.Errorf("cannot be negative")
+	}
+	return value, nil
+}
+
+// resourceQuantities converts the raw resource strings shared by Allocatable and Capacity.
+// Empty fields are reported as zero.
+func resourceQuantities(cpu, memory, ephemeralStorage, hugepages1Gi, hugepages2Mi, pods string) (*ResourceQuantities, error) {
+	var (
+		q   ResourceQuantities
+		err error
+	)
+
+	if cpu != "" {
+		if q.CPUMillis, err = parseCPUMillis("cpu", cpu); err != nil {
+			return nil, err
+		}
+	}
+
+	memoryFields := []struct {
+		field string
+		raw   string
+		dst   *int64
+	}{
+		{"memory", memory, &q.MemoryBytes},
+		{"ephemeral_storage", ephemeralStorage, &q.EphemeralStorageBytes},
+		{"hugepages_1Gi", hugepages1Gi, &q.Hugepages1GiBytes},
+		{"hugepages_2Mi", hugepages2Mi, &q.Hugepages2MiBytes},
+	}
+	for _, f := range memoryFields {
+		if f.raw == "" {
+			continue
+		}
+		if *f.dst, err = parseBytes(f.field, f.raw); err != nil {
+			return nil, err
+		}
+	}
+
+	if pods != "" {
+		if q.Pods, err = strconv.ParseInt(strings.TrimSpace(pods), 10, 64); err != nil || q.Pods < 0 {
+			return nil, &client.ValidationError{Field: "pods", Message: fmt.Sprintf("invalid quantity %q", pods)}
+		}
+	}
+
+	return &q, nil
+}
+
+// Quantities returns the allocatable resources as comparable numbers
+func (a Allocatable) Quantities() (*ResourceQuantities, error) {
+	return resourceQuantities(a.CPU, a.Memory, a.EphemeralStorage, a.Hugepages1Gi, a.Hugepages2Mi, a.Pods)
+}
+
+// Quantities returns the total capacity as comparable numbers
+func (c Capacity) Quantities() (*ResourceQuantities, error) {
+	return resourceQuantities(c.CPU, c.Memory, c.EphemeralStorage, c.Hugepages1Gi, c.Hugepages2Mi, c.Pods)
+}
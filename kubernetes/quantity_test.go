@@ -0,0 +1,143 @@
+package kubernetes
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+)
+
+func TestParseCPUMillis(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    int64
+		wantErr bool
+	}{
+		{name: "millicores", input: "16000m", want: 16000},
+		{name: "whole cores", input: "4", want: 4000},
+		{name: "fractional cores", input: "0.5", want: 500},
+		{name: "decimal kilo suffix", input: "1k", want: 1000000},
+		{name: "empty string", input: "", wantErr: true},
+		{name: "invalid value", input: "abc", wantErr: true},
+		{name: "negative value", input: "-1", wantErr: true},
+		{name: "not a number", input: "NaN", wantErr: true},
+		{name: "not a number in millicores", input: "NaNm", wantErr: true},
+		{name: "infinity", input: "Inf", wantErr: true},
+		{name: "infinite millicores", input: "+Infm", wantErr: true},
+		{name: "overflowing cores", input: "1e16", wantErr: true},
+		{name: "out of float range", input: "1e400m", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCPUMillis(tt.input)
+			var validationErr *client.ValidationError
+			if (err != nil) != tt.wantErr || (err != nil && !errors.As(err, &validationErr)) {
+				t.Errorf("ParseCPUMillis() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseCPUMillis() got = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMemoryBytes(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    int64
+		wantErr bool
+	}{
+		{name: "plain bytes", input: "1024", want: 1024},
+		{name: "kibibytes", input: "1Ki", want: 1024},
+		{name: "mebibytes", input: "512Mi", want: 512 * 1024 * 1024},
+		{name: "gibibytes", input: "32Gi", want: 32 * 1024 * 1024 * 1024},
+		{name: "kilobytes", input: "2k", want: 2000},
+		{name: "megabytes", input: "5M", want: 5000000},
+		{name: "gigabytes", input: "1G", want: 1000000000},
+		{name: "fractional gibibytes", input: "1.5Gi", want: 1536 * 1024 * 1024},
+		{name: "empty string", input: "", wantErr: true},
+		{name: "unknown suffix", input: "10Xi", wantErr: true},
+		{name: "not a number", input: "NaNGi", wantErr: true},
+		{name: "negative infinity", input: "-Inf", wantErr: true},
+		{name: "overflowing exbibytes", input: "8Ei", wantErr: true},
+		{name: "largest exbibytes", input: "7Ei", want: 7 << 60},
+		{name: "overflowing bytes", input: "1e19", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMemoryBytes(tt.input)
+			var validationErr *client.ValidationError
+			if (err != nil) != tt.wantErr || (err != nil && !errors.As(err, &validationErr)) {
+				t.Errorf("ParseMemoryBytes() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseMemoryBytes() got = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllocatable_Quantities(t *testing.T) {
+	a := Allocatable{
+		CPU:              "3920m",
+		EphemeralStorage: "100Gi",
+		Hugepages1Gi:     "0",
+		Hugepages2Mi:     "",
+		Memory:           "7Gi",
+		Pods:             "110",
+	}
+
+	got, err := a.Quantities()
+	if err != nil {
+		t.Fatalf("Quantities() unexpected error: %v", err)
+	}
+
+	want := ResourceQuantities{
+		CPUMillis:             3920,
+		MemoryBytes:           7 << 30,
+		EphemeralStorageBytes: 100 << 30,
+		Pods:                  110,
+	}
+	if *got != want {
+		t.Errorf("Quantities() got = %+v, want %+v", *got, want)
+	}
+
+	a.Pods = "many"
+	if _, err := a.Quantities(); err == nil {
+		t.Error("Quantities() expected error for invalid pods")
+	}
+}
+
+func TestCapacity_Quantities(t *testing.T) {
+	c := Capacity{
+		CPU:    "4",
+		Memory: "8Gi",
+		Pods:   "110",
+	}
+
+	got, err := c.Quantities()
+	if err != nil {
+		t.Fatalf("Quantities() unexpected error: %v", err)
+	}
+	if got.CPUMillis != 4000 || got.MemoryBytes != 8<<30 || got.Pods != 110 {
+		t.Errorf("Quantities() got = %+v", *got)
+	}
+
+	c.Memory = "8Qi"
+	if _, err := c.Quantities(); err == nil {
+		t.Error("Quantities() expected error for invalid memory")
+	}
+
+	c.Memory = "8Gi"
+	c.EphemeralStorage = "Inf"
+	var validationErr *client.ValidationError
+	if _, err := c.Quantities(); !errors.As(err, &validationErr) || validationErr.Field != "ephemeral_storage" {
+		t.Errorf("Quantities() error = %v, want a ValidationError on ephemeral_storage", err)
+	}
+}
@@ -0,0 +1,42 @@
+package kubernetes
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+)
+
+const (
+	userDataField = "userData"
+
+	// MaxNodePoolUserDataSize is the largest user data, in bytes before base64 encoding,
+	// accepted for a node pool. It matches the user data limit of virtual machines.
+	MaxNodePoolUserDataSize = 16 * 1024
+)
+
+// EncodeUserData base64-encodes a cloud-init script or other raw user data for
+// CreateNodePoolRequest.UserData.
+func EncodeUserData(raw string) *string {
+	encoded := base64.StdEncoding.EncodeToString([]byte(raw))
+	return &encoded
+}
+
+// validateUserData checks that userData is standard base64 and decodes to at most
+// MaxNodePoolUserDataSize bytes
+func validateUserData(userData string) error {
+	decoded, err := base64.StdEncoding.DecodeString(userData)
+	if err != nil {
+		return &client.ValidationError{Field: userDataField, Message: "must be base64 encoded, see EncodeUserData"}
+	}
+	if len(decoded) == 0 {
+		return &client.ValidationError{Field: userDataField, Message: "cannot be empty when set"}
+	}
+	if len(decoded) > MaxNodePoolUserDataSize {
+		return &client.ValidationError{
+			Field:   userDataField,
+			Message: fmt.Sprintf("is %d bytes, the limit is %d bytes before encoding", len(decoded), MaxNodePoolUserDataSize),
+		}
+	}
+	return nil
+}
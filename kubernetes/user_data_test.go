@@ -0,0 +1,68 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+)
+
+func TestNodePoolService_Create_UserData(t *testing.T) {
+	script := "#cloud-config\nruncmd:\n  - echo hello\n"
+
+	tests := []struct {
+		name     string
+		userData *string
+		wantErr  string
+	}{
+		{name: "encoded script", userData: EncodeUserData(script)},
+		{name: "not base64", userData: strPtr(script), wantErr: "base64"},
+		{name: "empty", userData: strPtr(""), wantErr: "cannot be empty"},
+		{name: "too large", userData: EncodeUserData(strings.Repeat("a", MaxNodePoolUserDataSize+1)), wantErr: "limit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.wantErr != "" {
+					t.Error("expected no request to be sent")
+				}
+				var body map[string]any
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Errorf("decode request: %v", err)
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				if body["user_data"] != *tt.userData {
+					t.Errorf("expected user_data %q, got %v", *tt.userData, body["user_data"])
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"id": "pool-new"}`))
+			}))
+			defer server.Close()
+
+			_, err := testClient(server.URL).Nodepools().Create(context.Background(), "cluster-123", CreateNodePoolRequest{
+				Name:     "pool",
+				Flavor:   "gp1.small",
+				Replicas: 1,
+				UserData: tt.userData,
+			})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Create() unexpected error: %v", err)
+				}
+				return
+			}
+			var validationErr *client.ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Field != userDataField || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected a userData ValidationError containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
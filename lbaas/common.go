@@ -1,23 +1,62 @@
 package lbaas
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"net/url"
 	"strings"
+	"time"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+	"github.com/MagaluCloud/mgc-sdk-go/helpers"
+	"github.com/MagaluCloud/mgc-sdk-go/internal/utils"
 )
 
-// urlNetworkLoadBalancer constructs the URL path for network load balancer operations
-// If lbID is provided, it appends the ID to the base path
-// Additional path segments can be provided via extraPath parameter
-func urlNetworkLoadBalancer(lbID *string, extraPath ...string) string {
-	result := "/v0beta1/network-load-balancers"
+// urlNetworkLoadBalancer builds the path of a network load balancer operation and returns
+// a context recording its path template (see mgc_http.ExpandPathTemplate).
+// A nil lbID without extraPath yields the load balancer collection. Otherwise lbID must be
+// set and non-empty, or a ValidationError for LoadBalancerID is returned. extraPath
+// alternates child collection names and child IDs ("backends", backendID, "targets"), so
+// the template reads ".../{load_balancer_id}/backends/{backend_id}/targets". The IDs are
+// percent-encoded; an empty child ID is kept (yielding "//" or a trailing "/") so it fails
+// on the server instead of silently targeting the parent collection.
+func urlNetworkLoadBalancer(ctx context.Context, lbID *string, extraPath ...string) (context.Context, string, error) {
+	path := "/v0beta1/network-load-balancers"
+	if lbID == nil && len(extraPath) == 0 {
+		return client.WithPathTemplate(ctx, DefaultBasePath+path), path, nil
+	}
 	if lbID == nil || *lbID == "" {
-		return result
+		return ctx, "", &client.ValidationError{Field: "LoadBalancerID", Message: utils.CannotBeEmpty}
+	}
+
+	template := path + "/{load_balancer_id}"
+	path += "/" + url.PathEscape(*lbID)
+	for i, segment := range extraPath {
+		if i%2 == 0 {
+			template += "/" + segment
+		} else {
+			template += "/{" + strings.ReplaceAll(strings.TrimSuffix(extraPath[i-1], "s"), "-", "_") + "_id}"
+		}
+		path += "/" + url.PathEscape(segment)
 	}
-	result += "/" + *lbID
-	if len(extraPath) > 0 {
-		result += "/" + strings.Join(extraPath, "/")
+	return client.WithPathTemplate(ctx, DefaultBasePath+template), path, nil
+}
+
+// parseTimestamp converts a raw API timestamp to UTC so values can be compared and sorted.
+// It accepts RFC 3339 with a "Z" suffix or a numeric offset ("2024-01-02T12:00:00-03:00"),
+// with optional fractional seconds; values without a zone are assumed to be UTC.
+// An empty value yields the zero time.
+func parseTimestamp(field, raw string) (time.Time, error) {
+	if raw == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := helpers.ParseAPITime(raw)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse %s %q: %w", field, raw, err)
 	}
-	return result
+	return t.Time().UTC(), nil
 }
 
 // UnmarshalJSON implements custom JSON unmarshaling for TargetsRawOrInstancesRequest
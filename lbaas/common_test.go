@@ -1,8 +1,14 @@
 package lbaas
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
 )
 
 func TestTargetsRawOrInstancesRequest_MarshalJSON(t *testing.T) {
@@ -383,54 +389,89 @@ func TestUrlNetworkLoadBalancer(t *testing.T) {
 		lbID      *string
 		extraPath []string
 		expected  string
+		template  string
+		wantErr   bool
 	}{
 		{
 			name:      "nil lbID without extra path",
 			lbID:      nil,
 			extraPath: nil,
 			expected:  "/v0beta1/network-load-balancers",
+			template:  "/load-balancer/v0beta1/network-load-balancers",
 		},
 		{
 			name:      "nil lbID with extra path",
 			lbID:      nil,
 			extraPath: []string{"listeners", "123"},
-			expected:  "/v0beta1/network-load-balancers",
+			wantErr:   true,
 		},
 		{
 			name:      "valid lbID without extra path",
 			lbID:      stringPtr("lb-123"),
 			extraPath: nil,
 			expected:  "/v0beta1/network-load-balancers/lb-123",
+			template:  "/load-balancer/v0beta1/network-load-balancers/{load_balancer_id}",
 		},
 		{
 			name:      "valid lbID with single extra path",
 			lbID:      stringPtr("lb-456"),
 			extraPath: []string{"listeners"},
 			expected:  "/v0beta1/network-load-balancers/lb-456/listeners",
+			template:  "/load-balancer/v0beta1/network-load-balancers/{load_balancer_id}/listeners",
 		},
 		{
 			name:      "valid lbID with multiple extra paths",
 			lbID:      stringPtr("lb-789"),
 			extraPath: []string{"backends", "backend-123", "targets"},
 			expected:  "/v0beta1/network-load-balancers/lb-789/backends/backend-123/targets",
+			template:  "/load-balancer/v0beta1/network-load-balancers/{load_balancer_id}/backends/{backend_id}/targets",
+		},
+		{
+			name:      "hyphenated collection names the child ID",
+			lbID:      stringPtr("lb-789"),
+			extraPath: []string{"health-checks", "hc-1"},
+			expected:  "/v0beta1/network-load-balancers/lb-789/health-checks/hc-1",
+			template:  "/load-balancer/v0beta1/network-load-balancers/{load_balancer_id}/health-checks/{health_check_id}",
 		},
 		{
 			name:      "empty lbID without extra path",
 			lbID:      stringPtr(""),
 			extraPath: nil,
-			expected:  "/v0beta1/network-load-balancers",
+			wantErr:   true,
 		},
 		{
 			name:      "empty lbID with extra path",
 			lbID:      stringPtr(""),
 			extraPath: []string{"health-checks"},
-			expected:  "/v0beta1/network-load-balancers",
+			wantErr:   true,
 		},
 		{
 			name:      "valid lbID with empty extra path slice",
 			lbID:      stringPtr("lb-abc"),
 			extraPath: []string{},
 			expected:  "/v0beta1/network-load-balancers/lb-abc",
+			template:  "/load-balancer/v0beta1/network-load-balancers/{load_balancer_id}",
+		},
+		{
+			name:      "empty extra segment is kept",
+			lbID:      stringPtr("lb-abc"),
+			extraPath: []string{"listeners", ""},
+			expected:  "/v0beta1/network-load-balancers/lb-abc/listeners/",
+			template:  "/load-balancer/v0beta1/network-load-balancers/{load_balancer_id}/listeners/{listener_id}",
+		},
+		{
+			name:      "lbID with special characters is escaped",
+			lbID:      stringPtr("lb/123?x=1"),
+			extraPath: nil,
+			expected:  "/v0beta1/network-load-balancers/lb%2F123%3Fx=1",
+			template:  "/load-balancer/v0beta1/network-load-balancers/{load_balancer_id}",
+		},
+		{
+			name:      "extra path segments with special characters are escaped",
+			lbID:      stringPtr("lb-abc"),
+			extraPath: []string{"backends", "be 1#frag", "targets", "../admin"},
+			expected:  "/v0beta1/network-load-balancers/lb-abc/backends/be%201%23frag/targets/..%2Fadmin",
+			template:  "/load-balancer/v0beta1/network-load-balancers/{load_balancer_id}/backends/{backend_id}/targets/{target_id}",
 		},
 	}
 
@@ -438,8 +479,66 @@ func TestUrlNetworkLoadBalancer(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			result := urlNetworkLoadBalancer(tt.lbID, tt.extraPath...)
+			ctx, result, err := urlNetworkLoadBalancer(context.Background(), tt.lbID, tt.extraPath...)
+			if tt.wantErr {
+				var validationErr *client.ValidationError
+				if !errors.As(err, &validationErr) || validationErr.Field != "LoadBalancerID" {
+					t.Fatalf("expected LoadBalancerID validation error, got %v", err)
+				}
+				return
+			}
+			assertNoError(t, err)
 			assertEqual(t, tt.expected, result)
+			assertEqual(t, tt.template, client.PathTemplate(ctx))
 		})
 	}
 }
+
+func TestParseTimestamp(t *testing.T) {
+	t.Parallel()
+	want := time.Date(2024, 3, 10, 15, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		raw     string
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "utc with Z suffix", raw: "2024-03-10T15:04:05Z", want: want},
+		{name: "negative offset", raw: "2024-03-10T12:04:05-03:00", want: want},
+		{name: "positive offset", raw: "2024-03-10T17:04:05+02:00", want: want},
+		{name: "fractional seconds", raw: "2024-03-10T15:04:05.250Z", want: want.Add(250 * time.Millisecond)},
+		{name: "no zone assumed utc", raw: "2024-03-10T15:04:05.000000", want: want},
+		{name: "empty", raw: "", want: time.Time{}},
+		{name: "invalid", raw: "10/03/2024 15:04", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseTimestamp("created_at", tt.raw)
+			if tt.wantErr {
+				assertError(t, err)
+				assertEqual(t, true, strings.Contains(err.Error(), tt.raw))
+				assertEqual(t, true, strings.Contains(err.Error(), "created_at"))
+				return
+			}
+			assertNoError(t, err)
+			assertEqual(t, true, got.Equal(tt.want))
+			if !got.IsZero() {
+				assertEqual(t, time.UTC, got.Location())
+			}
+		})
+	}
+}
+
+func TestNetworkHealthCheckResponse_Times(t *testing.T) {
+	t.Parallel()
+	hc := NetworkHealthCheckResponse{CreatedAt: "2024-03-10T12:00:00-03:00", UpdatedAt: "2024-03-10T16:00:00Z"}
+
+	created, err := hc.CreatedTime()
+	assertNoError(t, err)
+	updated, err := hc.UpdatedTime()
+	assertNoError(t, err)
+	assertEqual(t, time.Hour, updated.Sub(created))
+}
@@ -40,6 +40,14 @@ const (
 	HealthCheckProtocolHTTP HealthCheckProtocol = "http"
 )
 
+// HealthCheckStatus represents the health state reported for a target
+type HealthCheckStatus string
+
+const (
+	HealthCheckStatusHealthy   HealthCheckStatus = "healthy"
+	HealthCheckStatusUnhealthy HealthCheckStatus = "unhealthy"
+)
+
 // LoadBalancerStatus represents the status of a load balancer
 type LoadBalancerStatus string
 
@@ -0,0 +1,92 @@
+package lbaas
+
+import (
+	"fmt"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+)
+
+// StatusCodeRange is an inclusive range of HTTP status codes a health check accepts as healthy.
+// A single code is a range with Min equal to Max.
+type StatusCodeRange struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+// StatusCodes returns the range from min to max, e.g. StatusCodes(200, 299) for any 2xx
+func StatusCodes(min, max int) StatusCodeRange {
+	return StatusCodeRange{Min: min, Max: max}
+}
+
+// Contains reports whether code lies within the range
+func (r StatusCodeRange) Contains(code int) bool {
+	return code >= r.Min && code <= r.Max
+}
+
+// HealthyCodes returns the status codes the health check accepts, mapping the single
+// HealthyStatusCode to a one-code range when the API does not report HealthyStatusCodes
+func (r NetworkHealthCheckResponse) HealthyCodes() []StatusCodeRange {
+	if len(r.HealthyStatusCodes) > 0 {
+		return r.HealthyStatusCodes
+	}
+	if r.HealthyStatusCode != 0 {
+		return []StatusCodeRange{StatusCodes(r.HealthyStatusCode, r.HealthyStatusCode)}
+	}
+	return nil
+}
+
+// AcceptsStatus reports whether a response with code counts as healthy
+func (r NetworkHealthCheckResponse) AcceptsStatus(code int) bool {
+	for _, codes := range r.HealthyCodes() {
+		if codes.Contains(code) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeHealthyStatusCodes validates the healthy status codes of a request and keeps the
+// single-code and range fields consistent: ranges must lie within 100-599 with Min <= Max,
+// a lone one-code range also fills single, and a single code set alongside ranges must be
+// one of them
+func normalizeHealthyStatusCodes(single **int, ranges []StatusCodeRange) error {
+	if *single != nil && !validStatusCode(**single) {
+		return &client.ValidationError{Field: "healthy_status_code", Message: "must be between 100 and 599"}
+	}
+
+	for i, codes := range ranges {
+		field := fmt.Sprintf("healthy_status_codes[%d]", i)
+		if !validStatusCode(codes.Min) || !validStatusCode(codes.Max) {
+			return &client.ValidationError{Field: field, Message: "must be between 100 and 599"}
+		}
+		if codes.Min > codes.Max {
+			return &client.ValidationError{Field: field, Message: fmt.Sprintf("min %d is greater than max %d", codes.Min, codes.Max)}
+		}
+	}
+
+	if len(ranges) == 0 {
+		return nil
+	}
+	if *single == nil {
+		if len(ranges) == 1 && ranges[0].Min == ranges[0].Max {
+			code := ranges[0].Min
+			*single = &code
+		}
+		return nil
+	}
+
+	for _, codes := range ranges {
+		if codes.Contains(**single) {
+			return nil
+		}
+	}
+	return &client.ValidationError{
+		Field:   "healthy_status_code",
+		Message: fmt.Sprintf("%d is not within healthy_status_codes", **single),
+	}
+}
+
+// validStatusCode reports whether code is a valid HTTP status code
+func validStatusCode(code int) bool {
+	return code >= 100 && code <= 599
+}
@@ -0,0 +1,151 @@
+package lbaas
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+	"github.com/MagaluCloud/mgc-sdk-go/helpers"
+)
+
+func TestNetworkHealthCheckService_Create_HealthyStatusCodes(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		single       *int
+		ranges       []StatusCodeRange
+		wantSingle   any
+		wantRanges   int
+		wantErrField string
+	}{
+		{
+			name:       "single code",
+			single:     helpers.IntPtr(200),
+			wantSingle: float64(200),
+		},
+		{
+			name:       "range of 2xx",
+			ranges:     []StatusCodeRange{StatusCodes(200, 299)},
+			wantRanges: 1,
+		},
+		{
+			name:       "one-code range fills the single field",
+			ranges:     []StatusCodeRange{StatusCodes(204, 204)},
+			wantSingle: float64(204),
+			wantRanges: 1,
+		},
+		{
+			name:       "single code within the ranges",
+			single:     helpers.IntPtr(301),
+			ranges:     []StatusCodeRange{StatusCodes(200, 299), StatusCodes(301, 302)},
+			wantSingle: float64(301),
+			wantRanges: 2,
+		},
+		{
+			name:         "single code outside the ranges",
+			single:       helpers.IntPtr(404),
+			ranges:       []StatusCodeRange{StatusCodes(200, 299)},
+			wantErrField: "healthy_status_code",
+		},
+		{
+			name:         "code out of bounds",
+			ranges:       []StatusCodeRange{StatusCodes(200, 600)},
+			wantErrField: "healthy_status_codes[0]",
+		},
+		{
+			name:         "inverted range",
+			ranges:       []StatusCodeRange{StatusCodes(200, 299), StatusCodes(399, 300)},
+			wantErrField: "healthy_status_codes[1]",
+		},
+		{
+			name:         "invalid single code",
+			single:       helpers.IntPtr(99),
+			wantErrField: "healthy_status_code",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.wantErrField != "" {
+					t.Error("expected no request to be sent")
+				}
+				var body map[string]any
+				json.NewDecoder(r.Body).Decode(&body)
+				assertEqual(t, tt.wantSingle, body["healthy_status_code"])
+				ranges, _ := body["healthy_status_codes"].([]any)
+				assertEqual(t, tt.wantRanges, len(ranges))
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"id": "hc-123"}`))
+			}))
+			defer server.Close()
+
+			req := DefaultHTTPHealthCheck("hc", "/health", 80)
+			req.LoadBalancerID = "lb-123"
+			req.HealthyStatusCode = tt.single
+			req.HealthyStatusCodes = tt.ranges
+			_, err := testHealthCheckClient(server.URL).Create(context.Background(), req)
+
+			if tt.wantErrField != "" {
+				var validationErr *client.ValidationError
+				assertEqual(t, true, errors.As(err, &validationErr))
+				if validationErr != nil {
+					assertEqual(t, tt.wantErrField, validationErr.Field)
+				}
+				return
+			}
+			assertNoError(t, err)
+		})
+	}
+}
+
+func TestNetworkHealthCheckResponse_HealthyCodes(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		response string
+		accepts  []int
+		rejects  []int
+	}{
+		{
+			name:     "single code",
+			response: `{"healthy_status_code": 200}`,
+			accepts:  []int{200},
+			rejects:  []int{201, 500},
+		},
+		{
+			name:     "ranges",
+			response: `{"healthy_status_code": 200, "healthy_status_codes": [{"min": 200, "max": 299}, {"min": 302, "max": 302}]}`,
+			accepts:  []int{200, 250, 299, 302},
+			rejects:  []int{300, 301, 404},
+		},
+		{
+			name:     "nothing reported",
+			response: `{}`,
+			rejects:  []int{200},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var hc NetworkHealthCheckResponse
+			assertNoError(t, json.Unmarshal([]byte(tt.response), &hc))
+			for _, code := range tt.accepts {
+				assertEqual(t, true, hc.AcceptsStatus(code), code)
+			}
+			for _, code := range tt.rejects {
+				assertEqual(t, false, hc.AcceptsStatus(code), code)
+			}
+		})
+	}
+}
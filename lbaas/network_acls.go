@@ -51,7 +51,10 @@ type (
 
 // Create creates a new network ACL rule
 func (s *networkACLService) Create(ctx context.Context, req CreateNetworkACLRequest) (string, error) {
-	path := urlNetworkLoadBalancer(&req.LoadBalancerID, acls)
+	ctx, path, err := urlNetworkLoadBalancer(ctx, &req.LoadBalancerID, acls)
+	if err != nil {
+		return "", err
+	}
 	body := CreateNetworkACLRequest{
 		Name:           req.Name,
 		Ethertype:      req.Ethertype,
@@ -78,7 +81,10 @@ func (s *networkACLService) Create(ctx context.Context, req CreateNetworkACLRequ
 
 // Delete removes a network ACL rule
 func (s *networkACLService) Delete(ctx context.Context, req DeleteNetworkACLRequest) error {
-	path := urlNetworkLoadBalancer(&req.LoadBalancerID, acls, req.ID)
+	ctx, path, err := urlNetworkLoadBalancer(ctx, &req.LoadBalancerID, acls, req.ID)
+	if err != nil {
+		return err
+	}
 
 	httpReq, err := s.client.newRequest(ctx, http.MethodDelete, path, nil)
 	if err != nil {
@@ -149,7 +149,10 @@ func (s *networkBackendService) Targets() *networkBackendTargetService {
 
 // Create creates a new network backend
 func (s *networkBackendService) Create(ctx context.Context, req CreateNetworkBackendRequest) (string, error) {
-	path := urlNetworkLoadBalancer(&req.LoadBalancerID, backends)
+	ctx, path, err := urlNetworkLoadBalancer(ctx, &req.LoadBalancerID, backends)
+	if err != nil {
+		return "", err
+	}
 
 	httpReq, err := s.client.newRequest(ctx, http.MethodPost, path, req)
 	if err != nil {
@@ -168,7 +171,10 @@ func (s *networkBackendService) Create(ctx context.Context, req CreateNetworkBac
 
 // Delete removes a network backend
 func (s *networkBackendService) Delete(ctx context.Context, req DeleteNetworkBackendRequest) error {
-	path := urlNetworkLoadBalancer(&req.LoadBalancerID, backends, req.BackendID)
+	ctx, path, err := urlNetworkLoadBalancer(ctx, &req.LoadBalancerID, backends, req.BackendID)
+	if err != nil {
+		return err
+	}
 
 	httpReq, err := s.client.newRequest(ctx, http.MethodDelete, path, nil)
 	if err != nil {
@@ -181,7 +187,10 @@ func (s *networkBackendService) Delete(ctx context.Context, req DeleteNetworkBac
 
 // Get retrieves detailed information about a specific backend
 func (s *networkBackendService) Get(ctx context.Context, req GetNetworkBackendRequest) (*NetworkBackendResponse, error) {
-	path := urlNetworkLoadBalancer(&req.LoadBalancerID, backends, req.BackendID)
+	ctx, path, err := urlNetworkLoadBalancer(ctx, &req.LoadBalancerID, backends, req.BackendID)
+	if err != nil {
+		return nil, err
+	}
 
 	httpReq, err := s.client.newRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
@@ -198,7 +207,10 @@ func (s *networkBackendService) Get(ctx context.Context, req GetNetworkBackendRe
 
 // List returns a list of network backends
 func (s *networkBackendService) List(ctx context.Context, req ListNetworkBackendRequest) ([]NetworkBackendResponse, error) {
-	path := urlNetworkLoadBalancer(&req.LoadBalancerID, backends)
+	ctx, path, err := urlNetworkLoadBalancer(ctx, &req.LoadBalancerID, backends)
+	if err != nil {
+		return nil, err
+	}
 
 	httpReq, err := s.client.newRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
@@ -215,7 +227,10 @@ func (s *networkBackendService) List(ctx context.Context, req ListNetworkBackend
 
 // Update updates a network backend's properties
 func (s *networkBackendService) Update(ctx context.Context, req UpdateNetworkBackendRequest) error {
-	path := urlNetworkLoadBalancer(&req.LoadBalancerID, backends, req.BackendID)
+	ctx, path, err := urlNetworkLoadBalancer(ctx, &req.LoadBalancerID, backends, req.BackendID)
+	if err != nil {
+		return err
+	}
 
 	httpReq, err := s.client.newRequest(ctx, http.MethodPut, path, req)
 	if err != nil {
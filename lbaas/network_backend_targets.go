@@ -39,7 +39,10 @@ type (
 
 // Create adds new targets to a backend
 func (s *networkBackendTargetService) Create(ctx context.Context, req CreateNetworkBackendTargetRequest) (string, error) {
-	path := urlNetworkLoadBalancer(&req.LoadBalancerID, backends, req.NetworkBackendID, targets)
+	ctx, path, err := urlNetworkLoadBalancer(ctx, &req.LoadBalancerID, backends, req.NetworkBackendID, targets)
+	if err != nil {
+		return "", err
+	}
 
 	httpReq, err := s.client.newRequest(ctx, http.MethodPost, path, req)
 	if err != nil {
@@ -58,7 +61,10 @@ func (s *networkBackendTargetService) Create(ctx context.Context, req CreateNetw
 
 // Delete removes a target from a backend
 func (s *networkBackendTargetService) Delete(ctx context.Context, req DeleteNetworkBackendTargetRequest) error {
-	path := urlNetworkLoadBalancer(&req.LoadBalancerID, backends, req.NetworkBackendID, targets, req.TargetID)
+	ctx, path, err := urlNetworkLoadBalancer(ctx, &req.LoadBalancerID, backends, req.NetworkBackendID, targets, req.TargetID)
+	if err != nil {
+		return err
+	}
 
 	httpReq, err := s.client.newRequest(ctx, http.MethodDelete, path, nil)
 	if err != nil {
@@ -432,3 +432,20 @@ func TestNetworkBackendService_Create_NewRequestError(t *testing.T) {
 		t.Error("expected error due to canceled context, got nil")
 	}
 }
+
+func TestNetworkBackendService_Get_EmptyLoadBalancerID(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	client := testBackendClient(server.URL)
+	_, err := client.Get(context.Background(), GetNetworkBackendRequest{BackendID: "backend-123"})
+
+	assertError(t, err)
+	if !strings.Contains(err.Error(), "LoadBalancerID") {
+		t.Errorf("expected a LoadBalancerID validation error, got %v", err)
+	}
+}
@@ -83,7 +83,10 @@ type (
 
 // Create creates a new network TLS certificate
 func (s *networkCertificateService) Create(ctx context.Context, req CreateNetworkCertificateRequest) (*NetworkTLSCertificateResponse, error) {
-	path := urlNetworkLoadBalancer(&req.LoadBalancerID, tls_certificates)
+	ctx, path, err := urlNetworkLoadBalancer(ctx, &req.LoadBalancerID, tls_certificates)
+	if err != nil {
+		return nil, err
+	}
 
 	// Validate if certificate and private key are base64 encoded
 	if _, err := base64.StdEncoding.DecodeString(req.Certificate); err != nil {
@@ -108,7 +111,10 @@ func (s *networkCertificateService) Create(ctx context.Context, req CreateNetwor
 
 // Delete removes a network TLS certificate
 func (s *networkCertificateService) Delete(ctx context.Context, req DeleteNetworkCertificateRequest) error {
-	path := urlNetworkLoadBalancer(&req.LoadBalancerID, tls_certificates, req.TLSCertificateID)
+	ctx, path, err := urlNetworkLoadBalancer(ctx, &req.LoadBalancerID, tls_certificates, req.TLSCertificateID)
+	if err != nil {
+		return err
+	}
 
 	httpReq, err := s.client.newRequest(ctx, http.MethodDelete, path, nil)
 	if err != nil {
@@ -121,7 +127,10 @@ func (s *networkCertificateService) Delete(ctx context.Context, req DeleteNetwor
 
 // Get retrieves detailed information about a specific TLS certificate
 func (s *networkCertificateService) Get(ctx context.Context, req GetNetworkCertificateRequest) (*NetworkTLSCertificateResponse, error) {
-	path := urlNetworkLoadBalancer(&req.LoadBalancerID, tls_certificates, req.TLSCertificateID)
+	ctx, path, err := urlNetworkLoadBalancer(ctx, &req.LoadBalancerID, tls_certificates, req.TLSCertificateID)
+	if err != nil {
+		return nil, err
+	}
 
 	httpReq, err := s.client.newRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
@@ -138,7 +147,10 @@ func (s *networkCertificateService) Get(ctx context.Context, req GetNetworkCerti
 
 // List returns a list of network TLS certificates with optional filtering and pagination
 func (s *networkCertificateService) List(ctx context.Context, req ListNetworkCertificateRequest) ([]NetworkTLSCertificateResponse, error) {
-	path := urlNetworkLoadBalancer(&req.LoadBalancerID, tls_certificates)
+	ctx, path, err := urlNetworkLoadBalancer(ctx, &req.LoadBalancerID, tls_certificates)
+	if err != nil {
+		return nil, err
+	}
 
 	httpReq, err := s.client.newRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
@@ -160,7 +172,10 @@ func (s *networkCertificateService) List(ctx context.Context, req ListNetworkCer
 
 // Update updates a network TLS certificate's properties
 func (s *networkCertificateService) Update(ctx context.Context, req UpdateNetworkCertificateRequest) error {
-	path := urlNetworkLoadBalancer(&req.LoadBalancerID, tls_certificates, req.TLSCertificateID)
+	ctx, path, err := urlNetworkLoadBalancer(ctx, &req.LoadBalancerID, tls_certificates, req.TLSCertificateID)
+	if err != nil {
+		return err
+	}
 
 	httpReq, err := s.client.newRequest(ctx, http.MethodPut, path, req)
 	if err != nil {
@@ -2,13 +2,45 @@ package lbaas
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
 	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/MagaluCloud/mgc-sdk-go/client"
 	"github.com/MagaluCloud/mgc-sdk-go/helpers"
 	mgc_http "github.com/MagaluCloud/mgc-sdk-go/internal/http"
+	"github.com/MagaluCloud/mgc-sdk-go/internal/pagination"
+	"github.com/MagaluCloud/mgc-sdk-go/internal/utils"
 )
 
-const health_checks = "health-checks"
+// ErrHealthCheckInUse is returned by Delete when a backend still uses the health check.
+var ErrHealthCheckInUse = errors.New("health check is in use by a backend")
+
+// ErrDuplicateName is returned by Create with FailOnDuplicate when the load balancer
+// already has a health check with the requested name.
+var ErrDuplicateName = errors.New("health check name is already in use")
+
+const (
+	health_checks = "health-checks"
+	// maxConcurrentHealthCheckCreates bounds the create requests CreateBatch sends at once
+	maxConcurrentHealthCheckCreates = 4
+)
+
+// Recommended health check values used by DefaultHTTPHealthCheck and DefaultTCPHealthCheck
+const (
+	DefaultHealthCheckIntervalSeconds     = 10
+	DefaultHealthCheckTimeoutSeconds      = 5
+	DefaultHealthCheckInitialDelaySeconds = 15
+	DefaultHealthyThresholdCount          = 3
+	DefaultUnhealthyThresholdCount        = 3
+	DefaultHealthyStatusCode              = http.StatusOK
+)
 
 type (
 	// CreateNetworkHealthCheckRequest represents the request payload for creating a network health check
@@ -25,12 +57,25 @@ type (
 		InitialDelaySeconds     *int                `json:"initial_delay_seconds,omitempty"`
 		HealthyThresholdCount   *int                `json:"healthy_threshold_count,omitempty"`
 		UnhealthyThresholdCount *int                `json:"unhealthy_threshold_count,omitempty"`
+		// HealthyStatusCodes accepts any code within these ranges as healthy, e.g.
+		// []StatusCodeRange{StatusCodes(200, 299)} for any 2xx. Codes must be between 100
+		// and 599. HealthyStatusCode is kept for compatibility: a single one-code range also
+		// fills it, and when both are set it must fall within one of the ranges.
+		HealthyStatusCodes []StatusCodeRange `json:"healthy_status_codes,omitempty"`
+		// FailOnDuplicate lists the load balancer's health checks before creating and returns
+		// ErrDuplicateName when one already has Name, at the cost of the extra list requests
+		FailOnDuplicate bool `json:"-"`
 	}
 
 	// DeleteNetworkHealthCheckRequest represents the request payload for deleting a network health check
 	DeleteNetworkHealthCheckRequest struct {
 		LoadBalancerID string `json:"-"`
 		HealthCheckID  string `json:"-"`
+		// CheckInUse lists the backends of the load balancer before deleting and returns
+		// ErrHealthCheckInUse, naming them, when any uses the health check. Only the
+		// backends returned by a single list request are checked; the API's own conflict
+		// response is mapped to ErrHealthCheckInUse whether or not this is set
+		CheckInUse bool `json:"-"`
 	}
 
 	// GetNetworkHealthCheckRequest represents the request payload for getting a network health check
@@ -60,6 +105,8 @@ type (
 		InitialDelaySeconds     *int                `json:"initial_delay_seconds,omitempty"`
 		HealthyThresholdCount   *int                `json:"healthy_threshold_count,omitempty"`
 		UnhealthyThresholdCount *int                `json:"unhealthy_threshold_count,omitempty"`
+		// HealthyStatusCodes works as in CreateNetworkHealthCheckRequest
+		HealthyStatusCodes []StatusCodeRange `json:"healthy_status_codes,omitempty"`
 	}
 
 	// NetworkHealthCheckResponse represents a network health check response
@@ -78,6 +125,36 @@ type (
 		UnhealthyThresholdCount int                 `json:"unhealthy_threshold_count"`
 		CreatedAt               string              `json:"created_at"`
 		UpdatedAt               string              `json:"updated_at"`
+		// HealthyStatusCodes are the accepted status code ranges when the API reports them,
+		// see HealthyCodes for a view that also covers HealthyStatusCode
+		HealthyStatusCodes []StatusCodeRange `json:"healthy_status_codes,omitempty"`
+		// RawExtra holds response fields the SDK does not model yet, keyed by their JSON name.
+		RawExtra map[string]json.RawMessage `json:"-"`
+	}
+
+	// HealthCheckCreateResult is the outcome of creating one health check in CreateBatch
+	HealthCheckCreateResult struct {
+		Request CreateNetworkHealthCheckRequest
+		// HealthCheck is the created health check, nil when Err is set
+		HealthCheck *NetworkHealthCheckResponse
+		Err         error
+	}
+
+	// HealthCheckSummary aggregates the health checks of a load balancer, see Summary
+	HealthCheckSummary struct {
+		Total int
+		// ByProtocol counts the health checks of each protocol, keyed in lower case
+		ByProtocol      map[HealthCheckProtocol]int
+		IntervalSeconds HealthCheckStats
+		TimeoutSeconds  HealthCheckStats
+	}
+
+	// HealthCheckStats holds the minimum, maximum and mean of a health check setting, all
+	// zero when there are no health checks
+	HealthCheckStats struct {
+		Min  int
+		Max  int
+		Mean float64
 	}
 
 	// NetworkPaginatedHealthCheckResponse represents a paginated health check response
@@ -86,13 +163,21 @@ type (
 		Results []NetworkHealthCheckResponse `json:"results"`
 	}
 
-	// NetworkHealthCheckService provides methods for managing network health checks
+	// NetworkHealthCheckService provides methods for managing network health checks.
+	// The API does not expose health check events (state transitions of the backend
+	// targets); use Get or Summary to read the current health.
 	NetworkHealthCheckService interface {
 		Create(ctx context.Context, req CreateNetworkHealthCheckRequest) (*NetworkHealthCheckResponse, error)
+		CreateBatch(ctx context.Context, reqs []CreateNetworkHealthCheckRequest) ([]HealthCheckCreateResult, error)
 		Delete(ctx context.Context, req DeleteNetworkHealthCheckRequest) error
 		Get(ctx context.Context, req GetNetworkHealthCheckRequest) (*NetworkHealthCheckResponse, error)
 		List(ctx context.Context, req ListNetworkHealthCheckRequest) ([]NetworkHealthCheckResponse, error)
+		ListIter(ctx context.Context, req ListNetworkHealthCheckRequest) iter.Seq2[NetworkHealthCheckResponse, error]
 		Update(ctx context.Context, req UpdateNetworkHealthCheckRequest) error
+		GetEffectiveConfig(ctx context.Context, req GetNetworkHealthCheckRequest) (*NetworkHealthCheckResponse, error)
+		CreateEffective(ctx context.Context, req CreateNetworkHealthCheckRequest) (*NetworkHealthCheckResponse, error)
+		UpdateEffective(ctx context.Context, req UpdateNetworkHealthCheckRequest) (*NetworkHealthCheckResponse, error)
+		Summary(ctx context.Context, loadBalancerID string) (*HealthCheckSummary, error)
 	}
 
 	// networkHealthCheckService implements the NetworkHealthCheckService interface
@@ -101,9 +186,56 @@ type (
 	}
 )
 
-// Create creates a new network health check
+// DefaultHTTPHealthCheck returns an HTTP health check request with the recommended
+// interval, timeout, thresholds and initial delay, expecting a 200 response on path.
+// Set LoadBalancerID before creating it; every other field can be overridden.
+func DefaultHTTPHealthCheck(name, path string, port int) CreateNetworkHealthCheckRequest {
+	req := defaultHealthCheck(name, HealthCheckProtocolHTTP, port)
+	req.Path = &path
+	req.HealthyStatusCode = helpers.IntPtr(DefaultHealthyStatusCode)
+	return req
+}
+
+// DefaultTCPHealthCheck returns a TCP health check request with the recommended
+// interval, timeout, thresholds and initial delay.
+// Set LoadBalancerID before creating it; every other field can be overridden.
+func DefaultTCPHealthCheck(name string, port int) CreateNetworkHealthCheckRequest {
+	return defaultHealthCheck(name, HealthCheckProtocolTCP, port)
+}
+
+// defaultHealthCheck builds the fields shared by the health check presets
+func defaultHealthCheck(name string, protocol HealthCheckProtocol, port int) CreateNetworkHealthCheckRequest {
+	return CreateNetworkHealthCheckRequest{
+		Name:                    name,
+		Protocol:                protocol,
+		Port:                    port,
+		IntervalSeconds:         helpers.IntPtr(DefaultHealthCheckIntervalSeconds),
+		TimeoutSeconds:          helpers.IntPtr(DefaultHealthCheckTimeoutSeconds),
+		InitialDelaySeconds:     helpers.IntPtr(DefaultHealthCheckInitialDelaySeconds),
+		HealthyThresholdCount:   helpers.IntPtr(DefaultHealthyThresholdCount),
+		UnhealthyThresholdCount: helpers.IntPtr(DefaultUnhealthyThresholdCount),
+	}
+}
+
+// Create creates a new network health check. With req.FailOnDuplicate, the health checks of
+// the load balancer are listed first and ErrDuplicateName is returned when one has the same
+// name. The check is not atomic: creates racing with it, including those of one CreateBatch
+// call, can still produce duplicates.
 func (s *networkHealthCheckService) Create(ctx context.Context, req CreateNetworkHealthCheckRequest) (*NetworkHealthCheckResponse, error) {
-	path := urlNetworkLoadBalancer(&req.LoadBalancerID, health_checks)
+	if err := normalizeHealthyStatusCodes(&req.HealthyStatusCode, req.HealthyStatusCodes); err != nil {
+		return nil, err
+	}
+
+	if req.FailOnDuplicate {
+		if err := s.checkNameUnused(ctx, req.LoadBalancerID, req.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, path, err := urlNetworkLoadBalancer(ctx, &req.LoadBalancerID, health_checks)
+	if err != nil {
+		return nil, err
+	}
 
 	httpReq, err := s.client.newRequest(ctx, http.MethodPost, path, req)
 	if err != nil {
@@ -118,9 +250,104 @@ func (s *networkHealthCheckService) Create(ctx context.Context, req CreateNetwor
 	return result, nil
 }
 
-// Delete removes a network health check
+// CreateBatch creates several health checks concurrently, with at most four requests in flight.
+// Results follow the order of reqs. Requests failing validation are not sent and are reported
+// through their result's Err. Under a deadline on ctx, each create gets a share of the time left,
+// and requests not started when the deadline is near are not sent; their Err wraps
+// context.DeadlineExceeded. The returned error is non-nil only when every request failed.
+func (s *networkHealthCheckService) CreateBatch(ctx context.Context, reqs []CreateNetworkHealthCheckRequest) ([]HealthCheckCreateResult, error) {
+	results := make([]HealthCheckCreateResult, len(reqs))
+	sendable := 0
+	for i, req := range reqs {
+		results[i].Request = req
+		if results[i].Err = validateCreateHealthCheck(req); results[i].Err == nil {
+			sendable++
+		}
+	}
+
+	sem := make(chan struct{}, maxConcurrentHealthCheckCreates)
+	budget := utils.NewBatchBudget(sendable, maxConcurrentHealthCheckCreates)
+	var wg sync.WaitGroup
+	for i, req := range reqs {
+		if results[i].Err != nil {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			itemCtx, cancel, err := budget.Start(ctx)
+			if err != nil {
+				results[i].Err = err
+				return
+			}
+			defer cancel()
+
+			results[i].HealthCheck, results[i].Err = s.Create(itemCtx, req)
+		}()
+	}
+	wg.Wait()
+
+	errs := make([]error, 0, len(results))
+	for i, result := range results {
+		if result.Err == nil {
+			return results, nil
+		}
+		errs = append(errs, fmt.Errorf("create health check %d (%q): %w", i, result.Request.Name, result.Err))
+	}
+	return results, errors.Join(errs...)
+}
+
+// checkNameUnused returns ErrDuplicateName when a health check of the load balancer is named name
+func (s *networkHealthCheckService) checkNameUnused(ctx context.Context, loadBalancerID, name string) error {
+	for healthCheck, err := range s.ListIter(ctx, ListNetworkHealthCheckRequest{LoadBalancerID: loadBalancerID}) {
+		if err != nil {
+			return fmt.Errorf("list health checks to check name %q is unused: %w", name, err)
+		}
+		if healthCheck.Name == name {
+			return fmt.Errorf("%w: load balancer %s already has health check %q (%s)", ErrDuplicateName, loadBalancerID, name, healthCheck.ID)
+		}
+	}
+	return nil
+}
+
+// validateCreateHealthCheck checks the fields the API requires before a create request is sent
+func validateCreateHealthCheck(req CreateNetworkHealthCheckRequest) error {
+	switch {
+	case strings.TrimSpace(req.LoadBalancerID) == "":
+		return &client.ValidationError{Field: "load_balancer_id", Message: utils.CannotBeEmpty}
+	case strings.TrimSpace(req.Name) == "":
+		return &client.ValidationError{Field: "name", Message: utils.CannotBeEmpty}
+	case !strings.EqualFold(string(req.Protocol), string(HealthCheckProtocolTCP)) &&
+		!strings.EqualFold(string(req.Protocol), string(HealthCheckProtocolHTTP)):
+		return &client.ValidationError{Field: "protocol", Message: fmt.Sprintf("unsupported protocol %q", req.Protocol)}
+	case req.Port < 1 || req.Port > 65535:
+		return &client.ValidationError{Field: "port", Message: "must be between 1 and 65535"}
+	}
+	return normalizeHealthyStatusCodes(&req.HealthyStatusCode, req.HealthyStatusCodes)
+}
+
+// Delete removes a network health check. A conflict reported by the API, returned when a
+// backend still uses the health check, is returned as ErrHealthCheckInUse. With
+// req.CheckInUse the backends of the load balancer are listed first so the error can name
+// them without sending the delete.
 func (s *networkHealthCheckService) Delete(ctx context.Context, req DeleteNetworkHealthCheckRequest) error {
-	path := urlNetworkLoadBalancer(&req.LoadBalancerID, health_checks, req.HealthCheckID)
+	if req.HealthCheckID == "" {
+		return &client.ValidationError{Field: "HealthCheckID", Message: utils.CannotBeEmpty}
+	}
+	ctx, path, err := urlNetworkLoadBalancer(ctx, &req.LoadBalancerID, health_checks, req.HealthCheckID)
+	if err != nil {
+		return err
+	}
+
+	if req.CheckInUse {
+		if err := s.checkNotInUse(ctx, req.LoadBalancerID, req.HealthCheckID); err != nil {
+			return err
+		}
+	}
 
 	httpReq, err := s.client.newRequest(ctx, http.MethodDelete, path, nil)
 	if err != nil {
@@ -128,12 +355,38 @@ func (s *networkHealthCheckService) Delete(ctx context.Context, req DeleteNetwor
 	}
 
 	_, err = mgc_http.Do[any](s.client.GetConfig(), ctx, httpReq, nil)
+	var httpErr *client.HTTPError
+	if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusConflict {
+		return fmt.Errorf("%w: %w", ErrHealthCheckInUse, err)
+	}
 	return err
 }
 
+// checkNotInUse returns ErrHealthCheckInUse when a backend of the load balancer uses the health check
+func (s *networkHealthCheckService) checkNotInUse(ctx context.Context, loadBalancerID, healthCheckID string) error {
+	backends, err := s.client.NetworkBackends().List(ctx, ListNetworkBackendRequest{LoadBalancerID: loadBalancerID})
+	if err != nil {
+		return fmt.Errorf("list backends to check health check %s is unused: %w", healthCheckID, err)
+	}
+
+	var users []string
+	for _, backend := range backends {
+		if backend.HealthCheckID != nil && *backend.HealthCheckID == healthCheckID {
+			users = append(users, backend.Name)
+		}
+	}
+	if len(users) > 0 {
+		return fmt.Errorf("%w: health check %s is used by %s; detach it first", ErrHealthCheckInUse, healthCheckID, strings.Join(users, ", "))
+	}
+	return nil
+}
+
 // Get retrieves detailed information about a specific health check
 func (s *networkHealthCheckService) Get(ctx context.Context, req GetNetworkHealthCheckRequest) (*NetworkHealthCheckResponse, error) {
-	path := urlNetworkLoadBalancer(&req.LoadBalancerID, health_checks, req.HealthCheckID)
+	ctx, path, err := urlNetworkLoadBalancer(ctx, &req.LoadBalancerID, health_checks, req.HealthCheckID)
+	if err != nil {
+		return nil, err
+	}
 
 	httpReq, err := s.client.newRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
@@ -150,7 +403,10 @@ func (s *networkHealthCheckService) Get(ctx context.Context, req GetNetworkHealt
 
 // List returns a list of network health checks with optional filtering and pagination
 func (s *networkHealthCheckService) List(ctx context.Context, req ListNetworkHealthCheckRequest) ([]NetworkHealthCheckResponse, error) {
-	path := urlNetworkLoadBalancer(&req.LoadBalancerID, health_checks)
+	ctx, path, err := urlNetworkLoadBalancer(ctx, &req.LoadBalancerID, health_checks)
+	if err != nil {
+		return nil, err
+	}
 
 	httpReq, err := s.client.newRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
@@ -159,7 +415,7 @@ func (s *networkHealthCheckService) List(ctx context.Context, req ListNetworkHea
 
 	query := helpers.NewQueryParams(httpReq)
 	query.AddReflect("_offset", req.Offset)
-	query.AddReflect("_limit", req.Limit)
+	query.AddReflect("_limit", s.client.GetConfig().PageLimit(req.Limit))
 	query.Add("_sort", req.Sort)
 	httpReq.URL.RawQuery = query.Encode()
 
@@ -168,12 +424,44 @@ func (s *networkHealthCheckService) List(ctx context.Context, req ListNetworkHea
 	if err != nil {
 		return nil, err
 	}
+	if result.Results == nil {
+		result.Results = []NetworkHealthCheckResponse{}
+	}
 	return result.Results, nil
 }
 
+// ListIter returns an iterator over all health checks of a load balancer, fetching
+// pages of req.Limit items (the client's DefaultPageSize or 50 by default) starting at
+// req.Offset only as they are consumed.
+// Breaking out of the loop stops fetching; an error is yielded once and ends the iteration.
+func (s *networkHealthCheckService) ListIter(ctx context.Context, req ListNetworkHealthCheckRequest) iter.Seq2[NetworkHealthCheckResponse, error] {
+	p := pagination.Paginator[NetworkHealthCheckResponse]{
+		Fetch: func(ctx context.Context, offset, limit int) ([]NetworkHealthCheckResponse, error) {
+			pageReq := req
+			pageReq.Offset = &offset
+			pageReq.Limit = &limit
+			return s.List(ctx, pageReq)
+		},
+	}
+	if limit := s.client.GetConfig().PageLimit(req.Limit); limit != nil {
+		p.PageSize = *limit
+	}
+	if req.Offset != nil {
+		p.Offset = *req.Offset
+	}
+	return p.All(ctx)
+}
+
 // Update updates a network health check's properties
 func (s *networkHealthCheckService) Update(ctx context.Context, req UpdateNetworkHealthCheckRequest) error {
-	path := urlNetworkLoadBalancer(&req.LoadBalancerID, health_checks, req.HealthCheckID)
+	if err := normalizeHealthyStatusCodes(&req.HealthyStatusCode, req.HealthyStatusCodes); err != nil {
+		return err
+	}
+
+	ctx, path, err := urlNetworkLoadBalancer(ctx, &req.LoadBalancerID, health_checks, req.HealthCheckID)
+	if err != nil {
+		return err
+	}
 
 	httpReq, err := s.client.newRequest(ctx, http.MethodPut, path, req)
 	if err != nil {
@@ -183,3 +471,94 @@ func (s *networkHealthCheckService) Update(ctx context.Context, req UpdateNetwor
 	_, err = mgc_http.Do[any](s.client.GetConfig(), ctx, httpReq, nil)
 	return err
 }
+
+// GetEffectiveConfig returns the configuration the server applies to a health check, with
+// every optional field omitted on create or update resolved to its server-side default
+// (interval, timeout, thresholds and so on). It reads the health check like Get; the
+// response of Create may not reflect defaults filled in after the request was accepted.
+func (s *networkHealthCheckService) GetEffectiveConfig(ctx context.Context, req GetNetworkHealthCheckRequest) (*NetworkHealthCheckResponse, error) {
+	return s.Get(ctx, req)
+}
+
+// CreateEffective creates a health check like Create and then returns its effective
+// configuration, see GetEffectiveConfig. It costs one extra request.
+func (s *networkHealthCheckService) CreateEffective(ctx context.Context, req CreateNetworkHealthCheckRequest) (*NetworkHealthCheckResponse, error) {
+	created, err := s.Create(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetEffectiveConfig(ctx, GetNetworkHealthCheckRequest{LoadBalancerID: req.LoadBalancerID, HealthCheckID: created.ID})
+}
+
+// UpdateEffective updates a health check like Update and then returns its effective
+// configuration, see GetEffectiveConfig. It costs one extra request.
+func (s *networkHealthCheckService) UpdateEffective(ctx context.Context, req UpdateNetworkHealthCheckRequest) (*NetworkHealthCheckResponse, error) {
+	if err := s.Update(ctx, req); err != nil {
+		return nil, err
+	}
+	return s.GetEffectiveConfig(ctx, GetNetworkHealthCheckRequest{LoadBalancerID: req.LoadBalancerID, HealthCheckID: req.HealthCheckID})
+}
+
+// Summary lists every health check of a load balancer and returns their count by protocol
+// along with interval and timeout statistics. The aggregation is done client side.
+func (s *networkHealthCheckService) Summary(ctx context.Context, loadBalancerID string) (*HealthCheckSummary, error) {
+	if strings.TrimSpace(loadBalancerID) == "" {
+		return nil, &client.ValidationError{Field: "load_balancer_id", Message: utils.CannotBeEmpty}
+	}
+
+	summary := &HealthCheckSummary{ByProtocol: map[HealthCheckProtocol]int{}}
+	var intervals, timeouts []int
+	for healthCheck, err := range s.ListIter(ctx, ListNetworkHealthCheckRequest{LoadBalancerID: loadBalancerID}) {
+		if err != nil {
+			return nil, fmt.Errorf("list health checks of load balancer %s: %w", loadBalancerID, err)
+		}
+		summary.Total++
+		summary.ByProtocol[HealthCheckProtocol(strings.ToLower(string(healthCheck.Protocol)))]++
+		intervals = append(intervals, healthCheck.IntervalSeconds)
+		timeouts = append(timeouts, healthCheck.TimeoutSeconds)
+	}
+
+	summary.IntervalSeconds = newHealthCheckStats(intervals)
+	summary.TimeoutSeconds = newHealthCheckStats(timeouts)
+	return summary, nil
+}
+
+// newHealthCheckStats computes the statistics of values
+func newHealthCheckStats(values []int) HealthCheckStats {
+	if len(values) == 0 {
+		return HealthCheckStats{}
+	}
+
+	sum := 0
+	for _, value := range values {
+		sum += value
+	}
+	return HealthCheckStats{
+		Min:  slices.Min(values),
+		Max:  slices.Max(values),
+		Mean: float64(sum) / float64(len(values)),
+	}
+}
+
+// CreatedTime parses CreatedAt, see parseTimestamp
+func (r NetworkHealthCheckResponse) CreatedTime() (time.Time, error) {
+	return parseTimestamp("created_at", r.CreatedAt)
+}
+
+// UpdatedTime parses UpdatedAt, see parseTimestamp
+func (r NetworkHealthCheckResponse) UpdatedTime() (time.Time, error) {
+	return parseTimestamp("updated_at", r.UpdatedAt)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, keeping unknown fields in RawExtra.
+func (r *NetworkHealthCheckResponse) UnmarshalJSON(data []byte) error {
+	type healthCheckResponse NetworkHealthCheckResponse
+	var decoded healthCheckResponse
+	extra, err := utils.UnmarshalWithExtra(data, &decoded)
+	if err != nil {
+		return err
+	}
+	*r = NetworkHealthCheckResponse(decoded)
+	r.RawExtra = extra
+	return nil
+}
@@ -2,14 +2,22 @@ package lbaas
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/MagaluCloud/mgc-sdk-go/client"
+	"github.com/MagaluCloud/mgc-sdk-go/helpers"
+	"github.com/MagaluCloud/mgc-sdk-go/internal/utils"
 )
 
 func testHealthCheckClient(baseURL string) NetworkHealthCheckService {
@@ -425,6 +433,11 @@ func TestNetworkHealthCheckService_Delete(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/backends") {
+					w.Header().Set("Content-Type", "application/json")
+					w.Write([]byte(`{"results": []}`))
+					return
+				}
 				assertEqual(t, fmt.Sprintf("/load-balancer/v0beta1/network-load-balancers/%s/health-checks/%s", tt.lbID, tt.hcID), r.URL.Path)
 				assertEqual(t, http.MethodDelete, r.Method)
 				w.WriteHeader(tt.statusCode)
@@ -470,3 +483,455 @@ func TestNetworkHealthCheckService_Create_NewRequestError(t *testing.T) {
 		t.Error("expected error due to canceled context, got nil")
 	}
 }
+
+func TestDefaultHealthCheckPresets(t *testing.T) {
+	t.Parallel()
+
+	httpCheck := DefaultHTTPHealthCheck("web", "/healthz", 8080)
+	assertEqual(t, "web", httpCheck.Name)
+	assertEqual(t, HealthCheckProtocolHTTP, httpCheck.Protocol)
+	assertEqual(t, 8080, httpCheck.Port)
+	assertEqual(t, "/healthz", *httpCheck.Path)
+	assertEqual(t, 200, *httpCheck.HealthyStatusCode)
+	assertEqual(t, 10, *httpCheck.IntervalSeconds)
+	assertEqual(t, 5, *httpCheck.TimeoutSeconds)
+	assertEqual(t, 15, *httpCheck.InitialDelaySeconds)
+	assertEqual(t, 3, *httpCheck.HealthyThresholdCount)
+	assertEqual(t, 3, *httpCheck.UnhealthyThresholdCount)
+
+	tcpCheck := DefaultTCPHealthCheck("db", 5432)
+	assertEqual(t, "db", tcpCheck.Name)
+	assertEqual(t, HealthCheckProtocolTCP, tcpCheck.Protocol)
+	assertEqual(t, 5432, tcpCheck.Port)
+	assertEqual(t, true, tcpCheck.Path == nil)
+	assertEqual(t, true, tcpCheck.HealthyStatusCode == nil)
+	assertEqual(t, 10, *tcpCheck.IntervalSeconds)
+	assertEqual(t, 5, *tcpCheck.TimeoutSeconds)
+	assertEqual(t, 15, *tcpCheck.InitialDelaySeconds)
+	assertEqual(t, 3, *tcpCheck.HealthyThresholdCount)
+	assertEqual(t, 3, *tcpCheck.UnhealthyThresholdCount)
+
+	// Overriding a preset must not leak into other presets
+	*tcpCheck.IntervalSeconds = 30
+	assertEqual(t, 10, *DefaultTCPHealthCheck("other", 80).IntervalSeconds)
+}
+
+func TestNetworkHealthCheckService_ListIter(t *testing.T) {
+	t.Parallel()
+
+	var offsets []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assertEqual(t, "/load-balancer/v0beta1/network-load-balancers/lb-123/health-checks", r.URL.Path)
+		assertEqual(t, "2", r.URL.Query().Get("_limit"))
+		offset := r.URL.Query().Get("_offset")
+		offsets = append(offsets, offset)
+
+		w.Header().Set("Content-Type", "application/json")
+		switch offset {
+		case "0":
+			w.Write([]byte(`{"results": [{"id": "hc-1"}, {"id": "hc-2"}]}`))
+		case "2":
+			w.Write([]byte(`{"results": [{"id": "hc-3"}, {"id": "hc-4"}]}`))
+		default:
+			w.Write([]byte(`{"results": [{"id": "hc-5"}]}`))
+		}
+	}))
+	defer server.Close()
+
+	svc := testHealthCheckClient(server.URL)
+	req := ListNetworkHealthCheckRequest{LoadBalancerID: "lb-123", Limit: helpers.IntPtr(2)}
+
+	var ids []string
+	for hc, err := range svc.ListIter(context.Background(), req) {
+		assertNoError(t, err)
+		ids = append(ids, hc.ID)
+	}
+	assertEqual(t, "hc-1,hc-2,hc-3,hc-4,hc-5", strings.Join(ids, ","))
+	assertEqual(t, "0,2,4", strings.Join(offsets, ","))
+
+	offsets = nil
+	for hc := range svc.ListIter(context.Background(), req) {
+		if hc.ID == "hc-2" {
+			break
+		}
+	}
+	assertEqual(t, "0", strings.Join(offsets, ","))
+}
+
+func TestNetworkHealthCheckResponse_UnmarshalJSON_RawExtra(t *testing.T) {
+	var hc NetworkHealthCheckResponse
+	data := `{"id": "hc-1", "protocol": "http", "port": 80, "grpc_service": "health"}`
+	if err := json.Unmarshal([]byte(data), &hc); err != nil {
+		t.Fatalf("Unmarshal() unexpected error: %v", err)
+	}
+
+	assertEqual(t, "hc-1", hc.ID)
+	assertEqual(t, 80, hc.Port)
+	assertEqual(t, 1, len(hc.RawExtra))
+	assertEqual(t, `"health"`, string(hc.RawExtra["grpc_service"]))
+}
+
+func TestNetworkHealthCheckService_CreateBatch(t *testing.T) {
+	t.Parallel()
+	var mu sync.Mutex
+	var created []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req CreateNetworkHealthCheckRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if req.Name == "conflict" {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error": "already exists"}`))
+			return
+		}
+		mu.Lock()
+		created = append(created, req.Name)
+		mu.Unlock()
+		w.Write([]byte(fmt.Sprintf(`{"id": "hc-%s", "name": %q}`, req.Name, req.Name)))
+	}))
+	defer server.Close()
+
+	svc := testHealthCheckClient(server.URL)
+	reqs := []CreateNetworkHealthCheckRequest{
+		DefaultHTTPHealthCheck("web", "/healthz", 80),
+		DefaultTCPHealthCheck("conflict", 443),
+		DefaultTCPHealthCheck("bad-port", 0),
+		DefaultTCPHealthCheck("db", 5432),
+	}
+	for i := range reqs {
+		reqs[i].LoadBalancerID = "lb-123"
+	}
+
+	results, err := svc.CreateBatch(context.Background(), reqs)
+	assertNoError(t, err)
+	assertEqual(t, 4, len(results))
+	assertEqual(t, "hc-web", results[0].HealthCheck.ID)
+	assertError(t, results[1].Err)
+	assertEqual(t, true, strings.Contains(results[1].Err.Error(), "409"))
+
+	var validationErr *client.ValidationError
+	assertEqual(t, true, errors.As(results[2].Err, &validationErr))
+	assertEqual(t, "port", validationErr.Field)
+	assertEqual(t, "hc-db", results[3].HealthCheck.ID)
+	assertEqual(t, "bad-port", results[2].Request.Name)
+
+	slices.Sort(created)
+	assertEqual(t, "db,web", strings.Join(created, ","))
+
+	results, err = svc.CreateBatch(context.Background(), []CreateNetworkHealthCheckRequest{
+		{LoadBalancerID: "lb-123", Name: "conflict", Protocol: HealthCheckProtocolTCP, Port: 80},
+		{LoadBalancerID: "lb-123", Protocol: HealthCheckProtocolTCP, Port: 80},
+	})
+	assertError(t, err)
+	assertEqual(t, 2, len(results))
+	assertEqual(t, true, strings.Contains(err.Error(), "409"))
+	assertEqual(t, true, strings.Contains(err.Error(), "name"))
+}
+
+func TestNetworkHealthCheckService_CreateBatch_Deadline(t *testing.T) {
+	t.Parallel()
+	var sent atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent.Add(1)
+		io.Copy(io.Discard, r.Body)
+		// never answers in time, so each create takes its whole share of the budget
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	reqs := make([]CreateNetworkHealthCheckRequest, 4*maxConcurrentHealthCheckCreates)
+	for i := range reqs {
+		reqs[i] = DefaultTCPHealthCheck(fmt.Sprintf("hc-%d", i), 80)
+		reqs[i].LoadBalancerID = "lb-123"
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 4*utils.MinBatchItemBudget-utils.MinBatchItemBudget/2)
+	defer cancel()
+
+	results, err := testHealthCheckClient(server.URL).CreateBatch(ctx, reqs)
+	assertError(t, err)
+
+	unattempted := 0
+	for _, result := range results {
+		assertError(t, result.Err)
+		if strings.Contains(result.Err.Error(), "not started") {
+			unattempted++
+			assertEqual(t, true, errors.Is(result.Err, context.DeadlineExceeded))
+		}
+	}
+	assertEqual(t, true, unattempted > 0 && sent.Load() > 0)
+	assertEqual(t, len(reqs), int(sent.Load())+unattempted)
+}
+
+func TestNetworkHealthCheckService_Summary(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assertEqual(t, "/load-balancer/v0beta1/network-load-balancers/lb-123/health-checks", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("_offset") != "0" {
+			w.Write([]byte(`{"results": []}`))
+			return
+		}
+		w.Write([]byte(`{"results": [
+			{"id": "hc-1", "protocol": "http", "interval_seconds": 10, "timeout_seconds": 5},
+			{"id": "hc-2", "protocol": "TCP", "interval_seconds": 30, "timeout_seconds": 10},
+			{"id": "hc-3", "protocol": "tcp", "interval_seconds": 20, "timeout_seconds": 3},
+			{"id": "hc-4", "protocol": "HTTP", "interval_seconds": 20, "timeout_seconds": 6}
+		]}`))
+	}))
+	defer server.Close()
+
+	summary, err := testHealthCheckClient(server.URL).Summary(context.Background(), "lb-123")
+	assertNoError(t, err)
+	assertEqual(t, 4, summary.Total)
+	assertEqual(t, 2, summary.ByProtocol[HealthCheckProtocolHTTP])
+	assertEqual(t, 2, summary.ByProtocol[HealthCheckProtocolTCP])
+	assertEqual(t, HealthCheckStats{Min: 10, Max: 30, Mean: 20}, summary.IntervalSeconds)
+	assertEqual(t, HealthCheckStats{Min: 3, Max: 10, Mean: 6}, summary.TimeoutSeconds)
+}
+
+func TestNetworkHealthCheckService_Summary_Empty(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"results": []}`))
+	}))
+	defer server.Close()
+
+	summary, err := testHealthCheckClient(server.URL).Summary(context.Background(), "lb-123")
+	assertNoError(t, err)
+	assertEqual(t, 0, summary.Total)
+	assertEqual(t, 0, len(summary.ByProtocol))
+	assertEqual(t, HealthCheckStats{}, summary.IntervalSeconds)
+
+	_, err = testHealthCheckClient(server.URL).Summary(context.Background(), " ")
+	assertError(t, err)
+}
+
+func TestNetworkHealthCheckService_EffectiveConfig(t *testing.T) {
+	t.Parallel()
+	// the server fills the fields the requests omit with its defaults
+	effective := `{"id": "hc-1", "name": "web", "protocol": "tcp", "port": 80, "healthy_status_code": 200,
+		"interval_seconds": 30, "timeout_seconds": 10, "initial_delay_seconds": 20,
+		"healthy_threshold_count": 2, "unhealthy_threshold_count": 4}`
+	var gets int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/load-balancer/v0beta1/network-load-balancers/lb-123/health-checks":
+			var body map[string]any
+			json.NewDecoder(r.Body).Decode(&body)
+			if _, ok := body["interval_seconds"]; ok {
+				t.Errorf("expected interval_seconds to be omitted, got %v", body)
+			}
+			w.Write([]byte(`{"id": "hc-1", "name": "web", "protocol": "tcp", "port": 80}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/load-balancer/v0beta1/network-load-balancers/lb-123/health-checks/hc-1":
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && r.URL.Path == "/load-balancer/v0beta1/network-load-balancers/lb-123/health-checks/hc-1":
+			gets++
+			w.Write([]byte(effective))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	svc := testHealthCheckClient(server.URL)
+
+	created, err := svc.CreateEffective(context.Background(), CreateNetworkHealthCheckRequest{
+		LoadBalancerID: "lb-123",
+		Name:           "web",
+		Protocol:       HealthCheckProtocolTCP,
+		Port:           80,
+	})
+	assertNoError(t, err)
+	assertEqual(t, "hc-1", created.ID)
+	assertEqual(t, 30, created.IntervalSeconds)
+	assertEqual(t, 10, created.TimeoutSeconds)
+	assertEqual(t, 20, created.InitialDelaySeconds)
+	assertEqual(t, 2, created.HealthyThresholdCount)
+	assertEqual(t, 4, created.UnhealthyThresholdCount)
+
+	updated, err := svc.UpdateEffective(context.Background(), UpdateNetworkHealthCheckRequest{
+		LoadBalancerID: "lb-123",
+		HealthCheckID:  "hc-1",
+		Protocol:       HealthCheckProtocolTCP,
+		Port:           80,
+	})
+	assertNoError(t, err)
+	assertEqual(t, 30, updated.IntervalSeconds)
+	assertEqual(t, 2, gets)
+
+	_, err = svc.UpdateEffective(context.Background(), UpdateNetworkHealthCheckRequest{
+		LoadBalancerID:     "lb-123",
+		HealthCheckID:      "hc-1",
+		HealthyStatusCodes: []StatusCodeRange{StatusCodes(600, 700)},
+	})
+	assertError(t, err)
+	assertEqual(t, 2, gets)
+}
+
+func TestNetworkHealthCheckService_Delete_InUse(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		checkInUse  bool
+		deleteCode  int
+		wantListed  bool
+		wantDeleted bool
+		wantInUse   bool
+	}{
+		{name: "blocked by the check", checkInUse: true, wantListed: true, wantInUse: true},
+		{name: "deleted without the check", deleteCode: http.StatusNoContent, wantDeleted: true},
+		{name: "server conflict without the check", deleteCode: http.StatusConflict, wantDeleted: true, wantInUse: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			listed, deleted := false, false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch r.Method {
+				case http.MethodGet:
+					listed = true
+					assertEqual(t, "/load-balancer/v0beta1/network-load-balancers/lb-123/backends", r.URL.Path)
+					w.Write([]byte(`{"results": [
+						{"id": "be-1", "name": "web", "health_check_id": "hc-123"},
+						{"id": "be-2", "name": "api", "health_check_id": "hc-other"}
+					]}`))
+				case http.MethodDelete:
+					deleted = true
+					w.WriteHeader(tt.deleteCode)
+					if tt.deleteCode == http.StatusConflict {
+						w.Write([]byte(`{"message": "health check is attached to a backend"}`))
+					}
+				}
+			}))
+			defer server.Close()
+
+			err := testHealthCheckClient(server.URL).Delete(context.Background(), DeleteNetworkHealthCheckRequest{
+				LoadBalancerID: "lb-123",
+				HealthCheckID:  "hc-123",
+				CheckInUse:     tt.checkInUse,
+			})
+
+			assertEqual(t, tt.wantListed, listed)
+			assertEqual(t, tt.wantDeleted, deleted)
+			assertEqual(t, tt.wantInUse, errors.Is(err, ErrHealthCheckInUse))
+			if !tt.wantInUse {
+				assertNoError(t, err)
+			}
+			if tt.wantInUse && tt.checkInUse {
+				assertEqual(t, true, strings.Contains(err.Error(), "web"))
+				assertEqual(t, false, strings.Contains(err.Error(), "api"))
+			}
+		})
+	}
+}
+
+func TestNetworkHealthCheckService_Delete_EmptyIDs(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		req       DeleteNetworkHealthCheckRequest
+		wantField string
+	}{
+		{name: "empty load balancer ID", req: DeleteNetworkHealthCheckRequest{HealthCheckID: "hc-123", CheckInUse: true}, wantField: "LoadBalancerID"},
+		{name: "empty health check ID", req: DeleteNetworkHealthCheckRequest{LoadBalancerID: "lb-123", CheckInUse: true}, wantField: "HealthCheckID"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			}))
+			defer server.Close()
+
+			err := testHealthCheckClient(server.URL).Delete(context.Background(), tt.req)
+
+			var validationErr *client.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("expected a ValidationError, got %v", err)
+			}
+			assertEqual(t, tt.wantField, validationErr.Field)
+		})
+	}
+}
+
+func TestNetworkHealthCheckService_Create_FailOnDuplicate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name            string
+		hcName          string
+		failOnDuplicate bool
+		wantCreated     bool
+		wantDuplicate   bool
+	}{
+		{name: "duplicate name is rejected", hcName: "http-check", failOnDuplicate: true, wantDuplicate: true},
+		{name: "unique name is created", hcName: "tcp-check", failOnDuplicate: true, wantCreated: true},
+		{name: "duplicate allowed without the option", hcName: "http-check", wantCreated: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			listed, created := false, false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assertEqual(t, "/load-balancer/v0beta1/network-load-balancers/lb-123/health-checks", r.URL.Path)
+				w.Header().Set("Content-Type", "application/json")
+				switch r.Method {
+				case http.MethodGet:
+					listed = true
+					w.Write([]byte(`{"results": [{"id": "hc-1", "name": "http-check"}, {"id": "hc-2", "name": "HTTP-CHECK-2"}]}`))
+				case http.MethodPost:
+					created = true
+					w.Write([]byte(`{"id": "hc-new", "name": "` + tt.hcName + `"}`))
+				}
+			}))
+			defer server.Close()
+
+			req := DefaultTCPHealthCheck(tt.hcName, 8080)
+			req.LoadBalancerID = "lb-123"
+			req.FailOnDuplicate = tt.failOnDuplicate
+			_, err := testHealthCheckClient(server.URL).Create(context.Background(), req)
+
+			assertEqual(t, tt.failOnDuplicate, listed)
+			assertEqual(t, tt.wantCreated, created)
+			assertEqual(t, tt.wantDuplicate, errors.Is(err, ErrDuplicateName))
+			if tt.wantDuplicate {
+				assertEqual(t, true, strings.Contains(err.Error(), "hc-1"))
+			} else {
+				assertNoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNetworkHealthCheckService_List_EmptyResults(t *testing.T) {
+	t.Parallel()
+	for _, body := range []string{`{"results": null}`, `{"results": []}`, `{}`} {
+		body := body
+		t.Run(body, func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(body))
+			}))
+			defer server.Close()
+
+			hcs, err := testHealthCheckClient(server.URL).List(context.Background(), ListNetworkHealthCheckRequest{
+				LoadBalancerID: "lb-123",
+			})
+			assertNoError(t, err)
+			assertEqual(t, true, hcs != nil)
+			assertEqual(t, 0, len(hcs))
+		})
+	}
+}
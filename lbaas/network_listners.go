@@ -85,7 +85,10 @@ type (
 
 // Create creates a new network listener
 func (s *networkListenerService) Create(ctx context.Context, req CreateNetworkListenerRequest) (*NetworkListenerResponse, error) {
-	path := urlNetworkLoadBalancer(&req.LoadBalancerID, listeners)
+	ctx, path, err := urlNetworkLoadBalancer(ctx, &req.LoadBalancerID, listeners)
+	if err != nil {
+		return nil, err
+	}
 
 	httpReq, err := s.client.newRequest(ctx, http.MethodPost, path, req)
 	if err != nil {
@@ -107,7 +110,10 @@ func (s *networkListenerService) Create(ctx context.Context, req CreateNetworkLi
 
 // Delete removes a network listener
 func (s *networkListenerService) Delete(ctx context.Context, req DeleteNetworkListenerRequest) error {
-	path := urlNetworkLoadBalancer(&req.LoadBalancerID, listeners, req.ListenerID)
+	ctx, path, err := urlNetworkLoadBalancer(ctx, &req.LoadBalancerID, listeners, req.ListenerID)
+	if err != nil {
+		return err
+	}
 
 	httpReq, err := s.client.newRequest(ctx, http.MethodDelete, path, nil)
 	if err != nil {
@@ -120,7 +126,10 @@ func (s *networkListenerService) Delete(ctx context.Context, req DeleteNetworkLi
 
 // Get retrieves detailed information about a specific listener
 func (s *networkListenerService) Get(ctx context.Context, req GetNetworkListenerRequest) (*NetworkListenerResponse, error) {
-	path := urlNetworkLoadBalancer(&req.LoadBalancerID, listeners, req.ListenerID)
+	ctx, path, err := urlNetworkLoadBalancer(ctx, &req.LoadBalancerID, listeners, req.ListenerID)
+	if err != nil {
+		return nil, err
+	}
 
 	httpReq, err := s.client.newRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
@@ -137,7 +146,10 @@ func (s *networkListenerService) Get(ctx context.Context, req GetNetworkListener
 
 // List returns a list of network listeners with optional filtering and pagination
 func (s *networkListenerService) List(ctx context.Context, req ListNetworkListenerRequest) ([]NetworkListenerResponse, error) {
-	path := urlNetworkLoadBalancer(&req.LoadBalancerID, listeners)
+	ctx, path, err := urlNetworkLoadBalancer(ctx, &req.LoadBalancerID, listeners)
+	if err != nil {
+		return nil, err
+	}
 
 	httpReq, err := s.client.newRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
@@ -160,7 +172,10 @@ func (s *networkListenerService) List(ctx context.Context, req ListNetworkListen
 
 // Update updates a network listener's properties
 func (s *networkListenerService) Update(ctx context.Context, req UpdateNetworkListenerRequest) error {
-	path := urlNetworkLoadBalancer(&req.LoadBalancerID, listeners, req.ListenerID)
+	ctx, path, err := urlNetworkLoadBalancer(ctx, &req.LoadBalancerID, listeners, req.ListenerID)
+	if err != nil {
+		return err
+	}
 
 	httpReq, err := s.client.newRequest(ctx, http.MethodPut, path, req)
 	if err != nil {
@@ -200,7 +200,10 @@ type (
 
 // Create creates a new network load balancer
 func (s *networkLoadBalancerService) Create(ctx context.Context, req CreateNetworkLoadBalancerRequest) (string, error) {
-	path := urlNetworkLoadBalancer(nil)
+	ctx, path, err := urlNetworkLoadBalancer(ctx, nil)
+	if err != nil {
+		return "", err
+	}
 
 	httpReq, err := s.client.newRequest(ctx, http.MethodPost, path, req)
 	if err != nil {
@@ -219,7 +222,10 @@ func (s *networkLoadBalancerService) Create(ctx context.Context, req CreateNetwo
 
 // Delete removes a network load balancer
 func (s *networkLoadBalancerService) Delete(ctx context.Context, req DeleteNetworkLoadBalancerRequest) error {
-	path := urlNetworkLoadBalancer(&req.LoadBalancerID)
+	ctx, path, err := urlNetworkLoadBalancer(ctx, &req.LoadBalancerID)
+	if err != nil {
+		return err
+	}
 
 	httpReq, err := s.client.newRequest(ctx, http.MethodDelete, path, nil)
 	if err != nil {
@@ -238,7 +244,10 @@ func (s *networkLoadBalancerService) Delete(ctx context.Context, req DeleteNetwo
 
 // Get retrieves detailed information about a specific load balancer
 func (s *networkLoadBalancerService) Get(ctx context.Context, req GetNetworkLoadBalancerRequest) (*NetworkLoadBalancerResponse, error) {
-	path := urlNetworkLoadBalancer(&req.LoadBalancerID)
+	ctx, path, err := urlNetworkLoadBalancer(ctx, &req.LoadBalancerID)
+	if err != nil {
+		return nil, err
+	}
 
 	httpReq, err := s.client.newRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
@@ -255,7 +264,10 @@ func (s *networkLoadBalancerService) Get(ctx context.Context, req GetNetworkLoad
 
 // List returns a list of network load balancers with optional filtering and pagination
 func (s *networkLoadBalancerService) List(ctx context.Context, req ListNetworkLoadBalancerRequest) ([]NetworkLoadBalancerResponse, error) {
-	path := urlNetworkLoadBalancer(nil)
+	ctx, path, err := urlNetworkLoadBalancer(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
 
 	httpReq, err := s.client.newRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
@@ -278,7 +290,10 @@ func (s *networkLoadBalancerService) List(ctx context.Context, req ListNetworkLo
 
 // Update updates a network load balancer's properties
 func (s *networkLoadBalancerService) Update(ctx context.Context, req UpdateNetworkLoadBalancerRequest) error {
-	path := urlNetworkLoadBalancer(&req.LoadBalancerID)
+	ctx, path, err := urlNetworkLoadBalancer(ctx, &req.LoadBalancerID)
+	if err != nil {
+		return err
+	}
 
 	httpReq, err := s.client.newRequest(ctx, http.MethodPut, path, req)
 	if err != nil {
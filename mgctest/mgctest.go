@@ -0,0 +1,215 @@
+// Package mgctest provides a fake MagaluCloud API for testing code built on the SDK.
+//
+// A Server is an httptest server that answers with canned responses registered per
+// method and path, records every request it receives and fails the test on requests
+// nobody registered. Clients pointed at it are built with Server.Client:
+//
+//	srv := mgctest.NewServer(t)
+//	srv.Handle(http.MethodGet, "/compute/v1/snapshots/snap-1", http.StatusOK, map[string]any{"id": "snap-1"})
+//	computeClient := compute.New(srv.Client())
+//
+// Paths include the product prefix (e.g. "/kubernetes", "/compute") and are matched
+// exactly, without the query string.
+package mgctest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+)
+
+// Response is a canned response returned by the fake server.
+type Response struct {
+	Status int
+	// Body is written as-is when it is a string or []byte, and JSON-encoded otherwise.
+	// A nil body writes no content.
+	Body   any
+	Header http.Header
+}
+
+// Request is a request received by the fake server.
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// DecodeBody decodes the JSON body of the request into v.
+func (r Request) DecodeBody(v any) error {
+	return json.Unmarshal(r.Body, v)
+}
+
+// Server is a fake MagaluCloud API backed by httptest.
+type Server struct {
+	*httptest.Server
+
+	t        testing.TB
+	mu       sync.Mutex
+	routes   map[string][]Response
+	handlers map[string]http.HandlerFunc
+	requests []Request
+}
+
+// NewServer starts a fake API that is closed when the test finishes.
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+
+	s := &Server{
+		t:        t,
+		routes:   make(map[string][]Response),
+		handlers: make(map[string]http.HandlerFunc),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Handle registers a response for method and path. Registering several responses for
+// the same route returns them in order, repeating the last one once they run out,
+// which is convenient to simulate resources changing state while being polled.
+func (s *Server) Handle(method, path string, status int, body any) {
+	s.HandleResponse(method, path, Response{Status: status, Body: body})
+}
+
+// HandleResponse registers a response with custom headers for method and path.
+func (s *Server) HandleResponse(method, path string, resp Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := routeKey(method, path)
+	s.routes[key] = append(s.routes[key], resp)
+}
+
+// HandleFunc registers a handler for method and path, taking precedence over canned responses.
+func (s *Server) HandleFunc(method, path string, handler http.HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[routeKey(method, path)] = handler
+}
+
+// Client returns a core client pointed at the fake server. Retries are disabled so
+// error responses are returned immediately; opts are applied after the defaults.
+func (s *Server) Client(opts ...client.Option) *client.CoreClient {
+	defaults := []client.Option{
+		client.WithBaseURL(client.MgcUrl(s.URL)),
+		client.WithHTTPClient(s.Server.Client()),
+		client.WithRetryConfig(1, 0, 0, 1),
+	}
+	return client.NewMgcClient("mgctest-api-key", append(defaults, opts...)...)
+}
+
+// Requests returns the requests received so far, in order.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// RequestsTo returns the requests received for method and path, in order.
+func (s *Server) RequestsTo(method, path string) []Request {
+	var matched []Request
+	for _, r := range s.Requests() {
+		if r.Method == method && r.Path == path {
+			matched = append(matched, r)
+		}
+	}
+	return matched
+}
+
+// AssertCalled fails the test unless method and path were requested exactly times times.
+func (s *Server) AssertCalled(method, path string, times int) {
+	s.t.Helper()
+	if got := len(s.RequestsTo(method, path)); got != times {
+		s.t.Errorf("mgctest: expected %d %s %s requests, got %d", times, method, path, got)
+	}
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	key := routeKey(r.Method, r.URL.Path)
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+	handler := s.handlers[key]
+	resp, ok := s.nextResponse(key)
+	s.mu.Unlock()
+
+	if handler != nil {
+		handler(w, r)
+		return
+	}
+	if !ok {
+		s.t.Errorf("mgctest: unexpected request %s", key)
+		http.Error(w, fmt.Sprintf(`{"error": "no response registered for %s"}`, key), http.StatusNotFound)
+		return
+	}
+
+	writeResponse(s.t, w, resp)
+}
+
+// nextResponse pops the next canned response for key, keeping the last one. Callers hold s.mu.
+func (s *Server) nextResponse(key string) (Response, bool) {
+	queue := s.routes[key]
+	if len(queue) == 0 {
+		return Response{}, false
+	}
+	resp := queue[0]
+	if len(queue) > 1 {
+		s.routes[key] = queue[1:]
+	}
+	return resp, true
+}
+
+func writeResponse(t testing.TB, w http.ResponseWriter, resp Response) {
+	var payload []byte
+	switch body := resp.Body.(type) {
+	case nil:
+	case string:
+		payload = []byte(body)
+	case []byte:
+		payload = body
+	default:
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			t.Errorf("mgctest: encoding response body: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	for k, values := range resp.Header {
+		for _, v := range values {
+			w.Header().Add(k, v)
+		}
+	}
+	if payload != nil && w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "application/json")
+	}
+
+	status := resp.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+	w.Write(payload)
+}
+
+func routeKey(method, path string) string {
+	return method + " " + path
+}
@@ -0,0 +1,119 @@
+package mgctest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+	"github.com/MagaluCloud/mgc-sdk-go/compute"
+	"github.com/MagaluCloud/mgc-sdk-go/helpers"
+	"github.com/MagaluCloud/mgc-sdk-go/kubernetes"
+	"github.com/MagaluCloud/mgc-sdk-go/mgctest"
+)
+
+func TestServer_NodePoolFlow(t *testing.T) {
+	srv := mgctest.NewServer(t)
+	srv.Handle(http.MethodPost, "/kubernetes/v0/clusters/cluster-1/node_pools", http.StatusOK,
+		map[string]any{"id": "pool-1", "name": "workers", "replicas": 2})
+	srv.Handle(http.MethodPatch, "/kubernetes/v0/clusters/cluster-1/node_pools/pool-1", http.StatusOK,
+		`{"id": "pool-1", "name": "workers", "replicas": 5}`)
+
+	k8s := kubernetes.New(srv.Client())
+
+	pool, err := k8s.Nodepools().Create(context.Background(), "cluster-1", kubernetes.CreateNodePoolRequest{
+		Name:     "workers",
+		Flavor:   "cloud-k8s.gp1.small",
+		Replicas: 2,
+	})
+	if err != nil {
+		t.Fatalf("Create() unexpected error: %v", err)
+	}
+
+	pool, err = k8s.Nodepools().Update(context.Background(), "cluster-1", pool.ID,
+		kubernetes.PatchNodePoolRequest{Replicas: helpers.IntPtr(5)})
+	if err != nil {
+		t.Fatalf("Update() unexpected error: %v", err)
+	}
+	if pool.Replicas != 5 {
+		t.Errorf("expected 5 replicas, got %d", pool.Replicas)
+	}
+
+	srv.AssertCalled(http.MethodPost, "/kubernetes/v0/clusters/cluster-1/node_pools", 1)
+	patches := srv.RequestsTo(http.MethodPatch, "/kubernetes/v0/clusters/cluster-1/node_pools/pool-1")
+	if len(patches) != 1 {
+		t.Fatalf("expected 1 PATCH, got %d", len(patches))
+	}
+	var body map[string]any
+	if err := patches[0].DecodeBody(&body); err != nil {
+		t.Fatalf("DecodeBody() unexpected error: %v", err)
+	}
+	if body["replicas"] != float64(5) {
+		t.Errorf("expected replicas 5 in request body, got %v", body["replicas"])
+	}
+	if patches[0].Header.Get("X-API-Key") == "" {
+		t.Error("expected API key header to be sent")
+	}
+}
+
+func TestServer_SnapshotFlow(t *testing.T) {
+	srv := mgctest.NewServer(t)
+	srv.Handle(http.MethodPost, "/compute/v1/snapshots", http.StatusOK, `{"id": "snap-1"}`)
+	path := "/compute/v1/snapshots/snap-1"
+	srv.Handle(http.MethodGet, path, http.StatusOK, map[string]any{"id": "snap-1", "status": "creating", "progress": 40})
+	srv.Handle(http.MethodGet, path, http.StatusOK, map[string]any{"id": "snap-1", "status": "completed", "progress": 100})
+	srv.Handle(http.MethodDelete, path, http.StatusLocked, `{"error": "snapshot is locked"}`)
+
+	vm := compute.New(srv.Client())
+
+	id, err := vm.Snapshots().Create(context.Background(), compute.CreateSnapshotRequest{
+		Name:     "backup",
+		Instance: compute.IDOrName{ID: helpers.StrPtr("instance-1")},
+	})
+	if err != nil {
+		t.Fatalf("Create() unexpected error: %v", err)
+	}
+
+	snapshot, err := vm.Snapshots().WaitUntilCompleted(context.Background(), id, compute.SnapshotWaitOptions{Interval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("WaitUntilCompleted() unexpected error: %v", err)
+	}
+	if *snapshot.Progress != 100 {
+		t.Errorf("expected progress 100, got %d", *snapshot.Progress)
+	}
+	srv.AssertCalled(http.MethodGet, path, 2)
+
+	err = vm.Snapshots().Delete(context.Background(), id)
+	if !errors.Is(err, compute.ErrSnapshotLocked) {
+		t.Errorf("expected ErrSnapshotLocked, got %v", err)
+	}
+	var httpErr *client.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusLocked {
+		t.Errorf("expected HTTP 423 error, got %v", err)
+	}
+}
+
+func TestServer_HandleFunc(t *testing.T) {
+	srv := mgctest.NewServer(t)
+	srv.HandleFunc(http.MethodGet, "/compute/v1/snapshots", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("_limit") != "10" {
+			t.Errorf("expected _limit=10, got %q", r.URL.Query().Get("_limit"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"snapshots": [{"id": "snap-1"}]}`))
+	})
+
+	snapshots, err := compute.New(srv.Client()).Snapshots().List(context.Background(),
+		compute.ListOptions{Limit: helpers.IntPtr(10)})
+	if err != nil {
+		t.Fatalf("List() unexpected error: %v", err)
+	}
+	if len(snapshots) != 1 {
+		t.Errorf("expected 1 snapshot, got %d", len(snapshots))
+	}
+	if got := srv.Requests()[0].Query.Get("_limit"); got != "10" {
+		t.Errorf("expected recorded query _limit=10, got %q", got)
+	}
+}
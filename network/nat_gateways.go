@@ -87,6 +87,7 @@ type NatGatewayService interface {
 	Delete(ctx context.Context, id string) error
 	Get(ctx context.Context, id string) (*NatGatewayDetailsResponse, error)
 	List(ctx context.Context, vpcID string, opts ListOptions) ([]NatGatewayResponse, error)
+	ListAll(ctx context.Context, vpcID string, opts ListOptions) ([]NatGatewayResponse, error)
 }
 
 // natGatewayService implements the NatGatewayService interface
@@ -137,8 +138,50 @@ func (s *natGatewayService) Get(ctx context.Context, id string) (*NatGatewayDeta
 	)
 }
 
-// List retrieves all NAT Gateways for a specific VPC
+// List retrieves all NAT Gateways for a specific VPC.
+// An empty slice is returned when the API sends null or omits the result.
 func (s *natGatewayService) List(ctx context.Context, vpcID string, opts ListOptions) ([]NatGatewayResponse, error) {
+	result, err := s.listPage(ctx, s.listQuery(vpcID, opts))
+	if err != nil {
+		return nil, err
+	}
+	if result.Result == nil {
+		return []NatGatewayResponse{}, nil
+	}
+
+	return result.Result, nil
+}
+
+// ListAll retrieves every NAT Gateway of a VPC by following the "meta.links.next" cursor
+// returned by the API instead of computing page numbers, so items are neither
+// skipped nor duplicated when gateways are created or deleted while paging.
+// An empty slice is returned when the VPC has no NAT Gateways.
+func (s *natGatewayService) ListAll(ctx context.Context, vpcID string, opts ListOptions) ([]NatGatewayResponse, error) {
+	gateways := []NatGatewayResponse{}
+
+	query := s.listQuery(vpcID, opts)
+	for {
+		result, err := s.listPage(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		gateways = append(gateways, result.Result...)
+
+		next, ok, err := nextPageQuery(result.Meta.Links.Next)
+		if err != nil {
+			return nil, err
+		}
+		if !ok || len(result.Result) == 0 || next.Encode() == query.Encode() {
+			return gateways, nil
+		}
+		query = next
+	}
+}
+
+// listQuery builds the query of a NAT Gateway listing; without opts.Limit the client's
+// DefaultPageSize, when set, is used as the page size.
+func (s *natGatewayService) listQuery(vpcID string, opts ListOptions) url.Values {
+	opts.Limit = s.client.GetConfig().PageLimit(opts.Limit)
 	queryParams := url.Values{}
 	queryParams.Add("vpc_id", vpcID)
 
@@ -161,18 +204,17 @@ func (s *natGatewayService) List(ctx context.Context, vpcID string, opts ListOpt
 		queryParams.Add("page", "1") // Default page
 	}
 
-	result, err := mgc_http.ExecuteSimpleRequestWithRespBody[NatGatewayListResponse](
+	return queryParams
+}
+
+func (s *natGatewayService) listPage(ctx context.Context, query url.Values) (*NatGatewayListResponse, error) {
+	return mgc_http.ExecuteSimpleRequestWithRespBody[NatGatewayListResponse](
 		ctx,
 		s.client.newRequest,
 		s.client.GetConfig(),
 		http.MethodGet,
 		"/v1/nat_gateways",
 		nil,
-		queryParams,
+		query,
 	)
-	if err != nil {
-		return nil, err
-	}
-
-	return result.Result, nil
 }
@@ -351,6 +351,94 @@ func TestNatGatewayService_List(t *testing.T) {
 	}
 }
 
+func TestNatGatewayService_ListAll(t *testing.T) {
+	t.Parallel()
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assertEqual(t, "/network/v1/nat_gateways", r.URL.Path)
+		assertEqual(t, "vpc1", r.URL.Query().Get("vpc_id"))
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		switch page {
+		case "1":
+			w.Write([]byte(`{
+				"meta": {"links": {"self": "/v1/nat_gateways?page=1", "next": "/v1/nat_gateways?vpc_id=vpc1&items_per_page=1&page=2"}},
+				"result": [{"id": "nat1", "name": "nat-1"}]
+			}`))
+		case "2":
+			w.Write([]byte(`{
+				"meta": {"links": {"self": "/v1/nat_gateways?page=2"}},
+				"result": [{"id": "nat2", "name": "nat-2"}]
+			}`))
+		default:
+			t.Errorf("unexpected page %s", page)
+		}
+	}))
+	defer server.Close()
+
+	client := testNatGatewayClient(server.URL)
+	gateways, err := client.ListAll(context.Background(), "vpc1", ListOptions{Limit: helpers.IntPtr(1)})
+
+	assertNoError(t, err)
+	assertEqual(t, 2, len(gateways))
+	assertEqual(t, "nat2", *gateways[1].ID)
+	assertEqual(t, "1,2", strings.Join(pages, ","))
+}
+
+func TestNatGatewayService_EmptyResults(t *testing.T) {
+	t.Parallel()
+	for _, body := range []string{`{"result": null}`, `{"result": []}`, `{}`} {
+		body := body
+		t.Run(body, func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(body))
+			}))
+			defer server.Close()
+
+			client := testNatGatewayClient(server.URL)
+			gateways, err := client.List(context.Background(), "vpc1", ListOptions{})
+			assertNoError(t, err)
+			assertEqual(t, true, gateways != nil && len(gateways) == 0)
+
+			gateways, err = client.ListAll(context.Background(), "vpc1", ListOptions{})
+			assertNoError(t, err)
+			assertEqual(t, true, gateways != nil && len(gateways) == 0)
+		})
+	}
+}
+
+func TestNatGatewayService_DefaultPageSize(t *testing.T) {
+	t.Parallel()
+	var sizes []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sizes = append(sizes, r.URL.Query().Get("items_per_page"))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"result": [{"id": "nat1"}]}`))
+	}))
+	defer server.Close()
+
+	core := client.NewMgcClient("test-api",
+		client.WithBaseURL(client.MgcUrl(server.URL)),
+		client.WithDefaultPageSize(20))
+	gateways := New(core).NatGateways()
+
+	_, err := gateways.List(context.Background(), "vpc1", ListOptions{})
+	assertNoError(t, err)
+	_, err = gateways.ListAll(context.Background(), "vpc1", ListOptions{})
+	assertNoError(t, err)
+	_, err = gateways.List(context.Background(), "vpc1", ListOptions{Limit: helpers.IntPtr(5)})
+	assertNoError(t, err)
+
+	assertEqual(t, "20,20,5", strings.Join(sizes, ","))
+}
+
 func testNatGatewayClient(baseURL string) NatGatewayService {
 	httpClient := &http.Client{}
 	core := client.NewMgcClient("test-api",
@@ -0,0 +1,22 @@
+package network
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// nextPageQuery extracts the query parameters from a "meta.links.next" link.
+// Only the query is reused, so the link may be absolute or relative to any base path.
+// It returns false when there is no further page to fetch.
+func nextPageQuery(next *string) (url.Values, bool, error) {
+	if next == nil || *next == "" {
+		return nil, false, nil
+	}
+
+	link, err := url.Parse(*next)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid next page link %q: %w", *next, err)
+	}
+
+	return link.Query(), true, nil
+}
@@ -0,0 +1,58 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/MagaluCloud/mgc-sdk-go/helpers"
+)
+
+func TestNextPageQuery(t *testing.T) {
+	tests := []struct {
+		name      string
+		next      *string
+		wantQuery string
+		wantMore  bool
+		wantErr   bool
+	}{
+		{
+			name:     "nil link",
+			next:     nil,
+			wantMore: false,
+		},
+		{
+			name:     "empty link",
+			next:     helpers.StrPtr(""),
+			wantMore: false,
+		},
+		{
+			name:      "relative link",
+			next:      helpers.StrPtr("/network/v0/subnetpools?_limit=10&_offset=10"),
+			wantQuery: "_limit=10&_offset=10",
+			wantMore:  true,
+		},
+		{
+			name:      "absolute link",
+			next:      helpers.StrPtr("https://api.magalu.cloud/br-se1/network/v1/nat_gateways?page=2&vpc_id=vpc-1"),
+			wantQuery: "page=2&vpc_id=vpc-1",
+			wantMore:  true,
+		},
+		{
+			name:    "malformed link",
+			next:    helpers.StrPtr("http://[::1"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, more, err := nextPageQuery(tt.next)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("nextPageQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assertEqual(t, tt.wantMore, more)
+			if tt.wantMore {
+				assertEqual(t, tt.wantQuery, query.Encode())
+			}
+		})
+	}
+}
@@ -101,6 +101,7 @@ type (
 // SubnetPoolService provides operations for managing subnet pools
 type SubnetPoolService interface {
 	List(ctx context.Context, opts ListOptions) ([]SubnetPoolResponse, error)
+	ListAll(ctx context.Context, opts ListOptions) ([]SubnetPoolResponse, error)
 	Get(ctx context.Context, id string) (*SubnetPoolDetailsResponse, error)
 	Create(ctx context.Context, req CreateSubnetPoolRequest) (string, error)
 	Delete(ctx context.Context, id string) error
@@ -113,8 +114,50 @@ type subnetPoolService struct {
 	client *NetworkClient
 }
 
-// List retrieves all subnet pools for the current tenant
+// List retrieves all subnet pools for the current tenant.
+// An empty slice is returned when the API sends null or omits the results.
 func (s *subnetPoolService) List(ctx context.Context, opts ListOptions) ([]SubnetPoolResponse, error) {
+	result, err := s.listPage(ctx, s.listQuery(opts))
+	if err != nil {
+		return nil, err
+	}
+	if result.Results == nil {
+		return []SubnetPoolResponse{}, nil
+	}
+	return result.Results, nil
+}
+
+// ListAll retrieves every subnet pool by following the "meta.links.next" cursor
+// returned by the API instead of incrementing the offset, so items are neither
+// skipped nor duplicated when pools are created or deleted while paging.
+// Limit and Sort from opts apply to the first page; Offset is only used as the starting point.
+// An empty slice is returned when there are no subnet pools.
+func (s *subnetPoolService) ListAll(ctx context.Context, opts ListOptions) ([]SubnetPoolResponse, error) {
+	pools := []SubnetPoolResponse{}
+
+	query := s.listQuery(opts)
+	for {
+		result, err := s.listPage(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		pools = append(pools, result.Results...)
+
+		next, ok, err := nextPageQuery(result.Meta.Links.Next)
+		if err != nil {
+			return nil, err
+		}
+		if !ok || len(result.Results) == 0 || next.Encode() == query.Encode() {
+			return pools, nil
+		}
+		query = next
+	}
+}
+
+// listQuery builds the query of a subnet pool listing; without opts.Limit the client's
+// DefaultPageSize, when set, is sent as the limit.
+func (s *subnetPoolService) listQuery(opts ListOptions) url.Values {
+	opts.Limit = s.client.GetConfig().PageLimit(opts.Limit)
 	query := make(url.Values)
 	if opts.Limit != nil {
 		query.Set("_limit", strconv.Itoa(*opts.Limit))
@@ -125,8 +168,11 @@ func (s *subnetPoolService) List(ctx context.Context, opts ListOptions) ([]Subne
 	if opts.Sort != nil {
 		query.Set("_sort", *opts.Sort)
 	}
+	return query
+}
 
-	result, err := mgc_http.ExecuteSimpleRequestWithRespBody[ListSubnetPoolsResponse](
+func (s *subnetPoolService) listPage(ctx context.Context, query url.Values) (*ListSubnetPoolsResponse, error) {
+	return mgc_http.ExecuteSimpleRequestWithRespBody[ListSubnetPoolsResponse](
 		ctx,
 		s.client.newRequest,
 		s.client.GetConfig(),
@@ -135,10 +181,6 @@ func (s *subnetPoolService) List(ctx context.Context, opts ListOptions) ([]Subne
 		nil,
 		query,
 	)
-	if err != nil {
-		return nil, err
-	}
-	return result.Results, nil
 }
 
 // Get retrieves details of a specific subnet pool by its ID
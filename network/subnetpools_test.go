@@ -102,6 +102,93 @@ func TestSubnetPoolService_List(t *testing.T) {
 	}
 }
 
+func TestSubnetPoolService_ListAll(t *testing.T) {
+	t.Parallel()
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assertEqual(t, "/network/v0/subnetpools", r.URL.Path)
+		requests = append(requests, r.URL.RawQuery)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		switch r.URL.Query().Get("_offset") {
+		case "":
+			w.Write([]byte(`{
+				"meta": {"links": {"self": "/network/v0/subnetpools?_limit=2", "next": "/network/v0/subnetpools?_limit=2&_offset=2"}},
+				"results": [{"id": "pool1"}, {"id": "pool2"}]
+			}`))
+		case "2":
+			w.Write([]byte(`{
+				"meta": {"links": {"self": "/network/v0/subnetpools?_limit=2&_offset=2", "next": null}},
+				"results": [{"id": "pool3"}]
+			}`))
+		default:
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+	}))
+	defer server.Close()
+
+	client := testSubnetPoolClient(server.URL)
+	pools, err := client.ListAll(context.Background(), ListOptions{Limit: helpers.IntPtr(2)})
+
+	assertNoError(t, err)
+	assertEqual(t, 3, len(pools))
+	assertEqual(t, "pool3", pools[2].ID)
+	assertEqual(t, 2, len(requests))
+	assertEqual(t, "_limit=2&_offset=2", requests[1])
+}
+
+func TestSubnetPoolService_EmptyResults(t *testing.T) {
+	t.Parallel()
+	for _, body := range []string{`{"results": null}`, `{"results": []}`, `{}`} {
+		body := body
+		t.Run(body, func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(body))
+			}))
+			defer server.Close()
+
+			client := testSubnetPoolClient(server.URL)
+			pools, err := client.List(context.Background(), ListOptions{})
+			assertNoError(t, err)
+			assertEqual(t, true, pools != nil && len(pools) == 0)
+
+			pools, err = client.ListAll(context.Background(), ListOptions{})
+			assertNoError(t, err)
+			assertEqual(t, true, pools != nil && len(pools) == 0)
+		})
+	}
+}
+
+func TestSubnetPoolService_DefaultPageSize(t *testing.T) {
+	t.Parallel()
+	var limits []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limits = append(limits, r.URL.Query().Get("_limit"))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"results": [{"id": "pool1"}]}`))
+	}))
+	defer server.Close()
+
+	core := client.NewMgcClient("test-api",
+		client.WithBaseURL(client.MgcUrl(server.URL)),
+		client.WithDefaultPageSize(20))
+	pools := New(core).SubnetPools()
+
+	_, err := pools.List(context.Background(), ListOptions{})
+	assertNoError(t, err)
+	_, err = pools.ListAll(context.Background(), ListOptions{})
+	assertNoError(t, err)
+	_, err = pools.List(context.Background(), ListOptions{Limit: helpers.IntPtr(5)})
+	assertNoError(t, err)
+
+	assertEqual(t, "20,20,5", strings.Join(limits, ","))
+}
+
 func TestSubnetPoolService_Get(t *testing.T) {
 	createdAt, _ := time.Parse(time.RFC3339, "2024-01-01T00:00:00Z")
 